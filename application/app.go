@@ -5,9 +5,11 @@ import (
 	"os"
 	"sort"
 
+	"github.com/ccremer/greposync/application/branchprotection"
 	"github.com/ccremer/greposync/application/clierror"
 	"github.com/ccremer/greposync/application/initialize"
 	"github.com/ccremer/greposync/application/labels"
+	"github.com/ccremer/greposync/application/settings"
 	"github.com/ccremer/greposync/application/test"
 	"github.com/ccremer/greposync/application/update"
 	"github.com/ccremer/greposync/cfg"
@@ -32,6 +34,8 @@ func init() {
 // NewApp initializes the CLI application.
 func NewApp(info VersionInfo, config *cfg.Configuration,
 	labelCommand *labels.Command,
+	settingsCommand *settings.Command,
+	branchProtectionCommand *branchprotection.Command,
 	updateCommand *update.Command,
 	initializeCommand *initialize.Command,
 	testCommand *test.Command,
@@ -56,6 +60,8 @@ greposync does just that.`,
 		Commands: []*cli.Command{
 			initializeCommand.GetCliCommand(),
 			labelCommand.GetCliCommand(),
+			settingsCommand.GetCliCommand(),
+			branchProtectionCommand.GetCliCommand(),
 			updateCommand.GetCliCommand(),
 			testCommand.GetCliCommand(),
 		},
@@ -63,6 +69,9 @@ greposync does just that.`,
 	}
 	for _, command := range a.Commands {
 		sort.Sort(cli.FlagsByName(command.Flags))
+		for _, subcommand := range command.Subcommands {
+			sort.Sort(cli.FlagsByName(subcommand.Flags))
+		}
 	}
 	app.app = a
 	return app
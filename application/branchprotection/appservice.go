@@ -0,0 +1,29 @@
+package branchprotection
+
+import (
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging"
+	"github.com/ccremer/greposync/infrastructure/repositorystore"
+)
+
+type AppService struct {
+	repoStore       *repositorystore.RepositoryStore
+	protectionStore domain.BranchProtectionStore
+	cfg             *cfg.Configuration
+	factory         logging.LoggerFactory
+}
+
+func NewConfigurator(
+	repoStore *repositorystore.RepositoryStore,
+	protectionStore domain.BranchProtectionStore,
+	cfg *cfg.Configuration,
+	factory logging.LoggerFactory,
+) *AppService {
+	return &AppService{
+		repoStore:       repoStore,
+		protectionStore: protectionStore,
+		cfg:             cfg,
+		factory:         factory,
+	}
+}
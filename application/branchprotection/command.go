@@ -0,0 +1,36 @@
+package branchprotection
+
+import (
+	"github.com/ccremer/greposync/application/flags"
+	"github.com/urfave/cli/v2"
+)
+
+// GetCliCommand returns the command instance for CLI library.
+func (c *Command) GetCliCommand() *cli.Command {
+	return c.createCommand()
+}
+
+func (c *Command) createCommand() *cli.Command {
+	cFlags := []cli.Flag{
+		flags.NewLogLevelFlag(&c.cfg.Log.Level),
+		flags.NewShowLogFlag(&c.cfg.Log.ShowLog),
+
+		flags.NewJobsFlag(&c.cfg.Project.Jobs),
+		flags.NewSkipBrokenFlag(&c.cfg.Project.SkipBroken),
+		flags.NewIncludeFlag(&c.appService.repoStore.IncludeFilter),
+		flags.NewExcludeFlag(&c.appService.repoStore.ExcludeFilter),
+
+		flags.NewGitDefaultNamespaceFlag(&c.appService.repoStore.DefaultNamespace),
+		flags.NewGitBaseURLFlag(&c.appService.repoStore.BaseURL),
+	}
+	return &cli.Command{
+		Name:  "branch-protection",
+		Usage: "Synchronizes branch protection rules",
+		Description: `Applies the rules in 'branchProtection' from the main config to the repositories.
+Rules that don't exist are created, rules that differ are updated and all other rules are left untouched.
+Repositories whose Git hosting service doesn't support branch protection are skipped.`,
+		Before: flags.And(flags.FromYAML(cFlags), c.validateCommand),
+		Action: c.runCommand,
+		Flags:  cFlags,
+	}
+}
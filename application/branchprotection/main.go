@@ -0,0 +1,90 @@
+package branchprotection
+
+import (
+	"context"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/ccremer/greposync/application/instrumentation"
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/urfave/cli/v2"
+)
+
+type (
+	// Command contains the logic to keep branch protection rules in sync.
+	Command struct {
+		cfg             *cfg.Configuration
+		appService      *AppService
+		repos           []*domain.GitRepository
+		instrumentation instrumentation.BatchInstrumentation
+	}
+)
+
+// NewCommand returns a new instance.
+func NewCommand(
+	cfg *cfg.Configuration,
+	appService *AppService,
+	instrumentation instrumentation.BatchInstrumentation,
+) *Command {
+	c := &Command{
+		cfg:             cfg,
+		appService:      appService,
+		instrumentation: instrumentation,
+	}
+	return c
+}
+
+func (c *Command) runCommand(cliCtx *cli.Context) error {
+	ctx := pipeline.MutableContext(cliCtx.Context)
+	p := pipeline.NewPipeline().AddBeforeHook(c.appService.factory.NewPipelineLogger("").Accept).WithSteps(
+		pipeline.NewStepFromFunc("fetch repositories", c.fetchRepositories),
+		pipeline.NewWorkerPoolStep("update branch protection for all repos", c.cfg.Project.Jobs, c.updateRepos(), c.instrumentation.NewCollectErrorHandler(c.cfg.Project.SkipBroken)),
+	)
+	p.WithFinalizer(func(ctx context.Context, result pipeline.Result) error {
+		c.instrumentation.BatchPipelineCompleted("Update finished", c.repos)
+		return result.Err()
+	})
+	return p.RunWithContext(ctx).Err()
+}
+
+func (c *Command) updateRepos() pipeline.Supplier {
+	return func(ctx context.Context, pipelinesCH chan *pipeline.Pipeline) {
+		defer close(pipelinesCH)
+		c.instrumentation.BatchPipelineStarted("Update started", c.repos)
+		for _, r := range c.repos {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				p := c.createPipeline(r)
+				pipelinesCH <- p
+			}
+		}
+	}
+}
+
+func (c *Command) createPipeline(r *domain.GitRepository) *pipeline.Pipeline {
+	pp := &protectionPipeline{
+		appService: c.appService,
+		repo:       r,
+	}
+	return pipeline.NewPipeline().AddBeforeHook(c.appService.factory.NewPipelineLogger("").Accept).WithSteps(
+		pipeline.NewStepFromFunc("setup instrumentation", func(_ context.Context) error {
+			c.instrumentation.PipelineForRepositoryStarted(r)
+			return nil
+		}),
+		pipeline.NewStepFromFunc("fetch branch protection", pp.fetchBranchProtectionsForRepository),
+		pipeline.ToStep("determine which rules to change", pp.determineRulesToChange, pp.isSupported),
+		pipeline.ToStep("update branch protection", pp.ensureBranchProtectionsForRepository, pp.hasChanges),
+	).WithFinalizer(func(ctx context.Context, result pipeline.Result) error {
+		c.instrumentation.PipelineForRepositoryCompleted(r, result.Err())
+		return result.Err()
+	})
+}
+
+func (c *Command) fetchRepositories(ctx context.Context) error {
+	repos, err := c.appService.repoStore.FetchGitRepositories()
+	c.repos = repos
+	pipeline.StoreInContext(ctx, instrumentation.RepositoriesContextKey{}, repos)
+	return err
+}
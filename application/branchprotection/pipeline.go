@@ -0,0 +1,55 @@
+package branchprotection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+)
+
+type protectionPipeline struct {
+	appService *AppService
+	repo       *domain.GitRepository
+	current    domain.BranchProtectionSet
+	changes    domain.BranchProtectionSet
+	// unsupported is true if the Git hosting service doesn't support branch protection.
+	unsupported bool
+}
+
+func (p *protectionPipeline) fetchBranchProtectionsForRepository(_ context.Context) error {
+	protections, err := p.appService.protectionStore.FetchBranchProtectionsForRepository(p.repo)
+	if errors.Is(err, githosting.ErrCapabilityNotSupported) {
+		p.unsupported = true
+		p.appService.factory.NewRepositoryLogger(p.repo).Info("Skipping repository, branch protection is not supported by the Git hosting service")
+		return nil
+	}
+	p.current = protections
+	return err
+}
+
+func (p *protectionPipeline) isSupported(_ context.Context) bool {
+	return !p.unsupported
+}
+
+func (p *protectionPipeline) determineRulesToChange(_ context.Context) error {
+	desired, err := cfg.BranchProtectionConverter{}.ConvertToEntity(p.appService.cfg.BranchProtection, p.repo.DefaultBranch)
+	if err != nil {
+		return err
+	}
+	p.changes = desired.Diff(p.current)
+	if !p.hasChanges(nil) {
+		p.appService.factory.NewRepositoryLogger(p.repo).Info("Branch protection is up-to-date")
+	}
+	return nil
+}
+
+func (p *protectionPipeline) hasChanges(_ context.Context) bool {
+	return len(p.changes) > 0
+}
+
+func (p *protectionPipeline) ensureBranchProtectionsForRepository(_ context.Context) error {
+	err := p.appService.protectionStore.EnsureBranchProtectionsForRepository(p.repo, p.changes)
+	return err
+}
@@ -0,0 +1,35 @@
+package branchprotection
+
+import (
+	"regexp"
+
+	"github.com/ccremer/greposync/application/clierror"
+	"github.com/ccremer/greposync/application/flags"
+	"github.com/ccremer/greposync/cfg"
+	"github.com/urfave/cli/v2"
+)
+
+func (c *Command) validateCommand(ctx *cli.Context) error {
+	if err := cfg.ParseConfig(c.cfg.Project.MainConfigFileName, c.cfg, ctx); err != nil {
+		return clierror.AsUsageError(err)
+	}
+
+	if _, err := regexp.Compile(c.appService.repoStore.IncludeFilter); err != nil {
+		return clierror.AsFlagUsageError(flags.ProjectIncludeFlagName, err)
+	}
+	if _, err := regexp.Compile(c.appService.repoStore.ExcludeFilter); err != nil {
+		return clierror.AsFlagUsageError(flags.ProjectExcludeFlagName, err)
+	}
+
+	if jobs := c.cfg.Project.Jobs; jobs > flags.JobsMaximumCount || jobs < flags.JobsMinimumCount {
+		return clierror.AsFlagUsageErrorf(flags.ProjectJobsFlagName, "value is not between %d and %d", flags.JobsMinimumCount, flags.JobsMaximumCount)
+	}
+
+	// The name of the default branch is only known per repository, a placeholder suffices to validate the rules.
+	if _, err := (cfg.BranchProtectionConverter{}).ConvertToEntity(c.cfg.BranchProtection, "defaultBranch"); err != nil {
+		return clierror.AsUsageError(err)
+	}
+	c.appService.factory.SetLogLevel(c.cfg.Log.Level)
+	c.appService.factory.NewGenericLogger("").V(1).Info("Using config", "config", flags.CollectFlagValues(ctx))
+	return nil
+}
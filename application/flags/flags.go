@@ -119,9 +119,93 @@ func NewGitCommitBranchFlag(dst *string) *altsrc.StringFlag {
 	})
 }
 
+func NewGitBackendFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.backend", EnvVars: Prefixed("GIT_BACKEND"),
+		Usage: "The implementation that performs the Git operations, one of [cli, go-git]. 'cli' runs the 'git' binary, 'go-git' runs the operations in-process.",
+		Value: "cli", Destination: dst,
+	})
+}
+
+func NewGitCloneDepthFlag(dst *int) *altsrc.IntFlag {
+	return altsrc.NewIntFlag(&cli.IntFlag{Name: "git.cloneDepth", EnvVars: Prefixed("GIT_CLONE_DEPTH"),
+		Usage: "Truncate the history of new clones to the given number of commits. The history is deepened on demand. If 0, the full history is cloned.",
+		Value: 0, Destination: dst,
+	})
+}
+
+func NewGitCloneFilterFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.cloneFilter", EnvVars: Prefixed("GIT_CLONE_FILTER"),
+		Usage: "Make a partial clone that downloads file contents on demand, one of [blob:none, tree:0]. If empty, all objects are cloned.",
+		Value: "", Destination: dst,
+	})
+}
+
+func NewGitSparseCheckoutFlag(dst *bool) *altsrc.BoolFlag {
+	return altsrc.NewBoolFlag(&cli.BoolFlag{Name: "git.sparseCheckout", EnvVars: Prefixed("GIT_SPARSE_CHECKOUT"),
+		Usage: "Check out only the files in the root directory and the directories that contain files managed by the template.",
+		Value: false, Destination: dst,
+	})
+}
+
+func NewGitSigningFormatFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.signingFormat", EnvVars: Prefixed("GIT_SIGNING_FORMAT"),
+		Usage: "Sign commits with a key of the given format, one of [gpg, ssh]. If empty, commits are not signed.",
+		Value: "", Destination: dst,
+	})
+}
+
+func NewGitSigningKeyFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.signingKey", EnvVars: Prefixed("GIT_SIGNING_KEY"),
+		Usage: "The GPG key ID, or the path to the SSH key file with which commits are signed. Required for SSH.",
+		Value: "", Destination: dst,
+	})
+}
+
+func NewGitSignoffFlag(dst *bool) *altsrc.BoolFlag {
+	return altsrc.NewBoolFlag(&cli.BoolFlag{Name: "git.signoff", EnvVars: Prefixed("GIT_SIGNOFF"),
+		Usage: "Add a 'Signed-off-by' trailer to the commit message.",
+		Value: false, Destination: dst,
+	})
+}
+
+func NewGitAuthorNameFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.authorName", EnvVars: Prefixed("GIT_AUTHOR_NAME"),
+		Usage: "The name of the commit author. If empty, 'user.name' of the Git configuration is used.",
+		Value: "", Destination: dst,
+	})
+}
+
+func NewGitAuthorEmailFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.authorEmail", EnvVars: Prefixed("GIT_AUTHOR_EMAIL"),
+		Usage: "The e-mail address of the commit author. If empty, 'user.email' of the Git configuration is used.",
+		Value: "", Destination: dst,
+	})
+}
+
+func NewGitCommitterNameFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.committerName", EnvVars: Prefixed("GIT_COMMITTER_NAME"),
+		Usage: "The name of the committer. If empty, 'user.name' of the Git configuration is used.",
+		Value: "", Destination: dst,
+	})
+}
+
+func NewGitCommitterEmailFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.committerEmail", EnvVars: Prefixed("GIT_COMMITTER_EMAIL"),
+		Usage: "The e-mail address of the committer. If empty, 'user.email' of the Git configuration is used.",
+		Value: "", Destination: dst,
+	})
+}
+
+func NewGitTrailersFlag(dst *cli.StringSlice) *altsrc.StringSliceFlag {
+	return altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "git.trailers", EnvVars: Prefixed("GIT_TRAILERS"),
+		Usage: "Array of 'Key: value' trailers appended to the commit message, e.g. 'Refs: TICKET-123'. Each trailer is a Go template with access to the same metadata as 'git.commitMessage'. Trailers that render empty are omitted.",
+		Value: &cli.StringSlice{}, Destination: dst,
+	})
+}
+
 func NewGitCommitMessageFlag(dst *string) *altsrc.StringFlag {
 	return altsrc.NewStringFlag(&cli.StringFlag{Name: "git.commitMessage", EnvVars: Prefixed("GIT_COMMIT_MSG"),
-		Usage: "The commit message when committing an update.",
+		Usage: "The commit message when committing an update. It is a Go template with access to the repository metadata. Can be overridden per repository with ':git' in .sync.yml.",
 		Value: "Update from greposync", Destination: dst,
 	})
 }
@@ -165,11 +249,134 @@ func NewPRTargetBranchFlag(dst *string) *altsrc.StringFlag {
 
 func NewPRLabelsFlag(dst *cli.StringSlice) *altsrc.StringSliceFlag {
 	return altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "pr.labels", EnvVars: Prefixed("PR_LABELS"),
-		Usage: "Array of issue labels to apply when creating a pull request. Labels on existing pull requests are not updated. It is not validated whether the labels exist, the API may or may not create non-existing labels dynamically.",
+		Usage: "Array of issue labels to apply on pull requests. Labels on existing pull requests are updated according to 'pr.labelSyncMode'. It is not validated whether the labels exist, the API may or may not create non-existing labels dynamically.",
+		Value: &cli.StringSlice{}, Destination: dst,
+	})
+}
+
+func NewPRLabelSyncModeFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "pr.labelSyncMode", EnvVars: Prefixed("PR_LABEL_SYNC_MODE"),
+		Usage: "How 'pr.labels' are applied to existing pull requests. 'merge' adds the labels, 'exact' replaces all labels, 'remove' removes the labels.",
+		Value: "merge", Destination: dst,
+	})
+}
+
+func NewPRReviewersFlag(dst *cli.StringSlice) *altsrc.StringSliceFlag {
+	return altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "pr.reviewers", EnvVars: Prefixed("PR_REVIEWERS"),
+		Usage: "Array of users ('@user') or teams ('@org/team') whose review is requested. Missing reviewers are added to existing pull requests. Can be overridden per repository with ':pr' in .sync.yml.",
+		Value: &cli.StringSlice{}, Destination: dst,
+	})
+}
+
+func NewPRTeamReviewersFlag(dst *cli.StringSlice) *altsrc.StringSliceFlag {
+	return altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "pr.teamReviewers", EnvVars: Prefixed("PR_TEAM_REVIEWERS"),
+		Usage: "Array of team slugs whose review is requested. Missing team reviewers are added to existing pull requests. Can be overridden per repository with ':pr' in .sync.yml.",
+		Value: &cli.StringSlice{}, Destination: dst,
+	})
+}
+
+func NewPRAssigneesFlag(dst *cli.StringSlice) *altsrc.StringSliceFlag {
+	return altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "pr.assignees", EnvVars: Prefixed("PR_ASSIGNEES"),
+		Usage: "Array of users that are assigned to pull requests. Missing assignees are added to existing pull requests. Can be overridden per repository with ':pr' in .sync.yml.",
+		Value: &cli.StringSlice{}, Destination: dst,
+	})
+}
+
+func NewPRDraftFlag(dst *bool) *altsrc.BoolFlag {
+	return altsrc.NewBoolFlag(&cli.BoolFlag{Name: "pr.draft", EnvVars: Prefixed("PR_DRAFT"),
+		Usage: "Open new pull requests as draft. If disabled, existing draft pull requests are marked as ready for review. Can be overridden per repository with ':pr' in .sync.yml.",
+		Value: false, Destination: dst,
+	})
+}
+
+func NewPRAutoMergeFlag(dst *bool) *altsrc.BoolFlag {
+	return altsrc.NewBoolFlag(&cli.BoolFlag{Name: "pr.autoMerge", EnvVars: Prefixed("PR_AUTO_MERGE"),
+		Usage: "Enable auto-merge on pull requests, so that they are merged once all requirements are met. Cannot be combined with draft pull requests. Can be overridden per repository with ':pr' in .sync.yml.",
+		Value: false, Destination: dst,
+	})
+}
+
+func NewPRMergeMethodFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "pr.mergeMethod", EnvVars: Prefixed("PR_MERGE_METHOD"),
+		Usage: "The merge method used for auto-merge, one of 'merge', 'squash' or 'rebase'. Can be overridden per repository with ':pr' in .sync.yml.",
+		Value: "merge", Destination: dst,
+	})
+}
+
+func NewPRCloseCommentFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "pr.closeComment", EnvVars: Prefixed("PR_CLOSE_COMMENT"),
+		Usage: "Comment added to an existing pull request before it is closed because the template doesn't produce any changes anymore. If empty, no comment is added.",
+		Value: "This pull request is obsolete as the greposync template doesn't produce any changes in this repository anymore.", Destination: dst,
+	})
+}
+
+func NewPRDeleteBranchFlag(dst *bool) *altsrc.BoolFlag {
+	return altsrc.NewBoolFlag(&cli.BoolFlag{Name: "pr.deleteBranch", EnvVars: Prefixed("PR_DELETE_BRANCH"),
+		Usage: "Delete the commit branch in remote if the template doesn't produce any changes anymore.",
+		Value: false, Destination: dst,
+	})
+}
+
+func NewPRForkOwnerFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "pr.forkOwner", EnvVars: Prefixed("PR_FORK_OWNER"),
+		Usage: "User or organization that owns the forks of the repositories. If set, the commit branch is pushed to a fork and pull requests are opened from the fork (GitHub only).",
+		Value: "", Destination: dst,
+	})
+}
+
+//// Label Flags
+
+func NewLabelsPruneFlag(dst *bool) *altsrc.BoolFlag {
+	return altsrc.NewBoolFlag(&cli.BoolFlag{Name: "labels.prune", EnvVars: Prefixed("LABELS_PRUNE"), Aliases: []string{"prune"},
+		Usage: "Delete remote labels that aren't configured in 'repositoryLabels', except those matching 'labels.pruneKeep'.",
+		Value: false, Destination: dst,
+	})
+}
+
+func NewLabelsPruneKeepFlag(dst *cli.StringSlice) *altsrc.StringSliceFlag {
+	return altsrc.NewStringSliceFlag(&cli.StringSliceFlag{Name: "labels.pruneKeep", EnvVars: Prefixed("LABELS_PRUNE_KEEP"),
+		Usage: "Array of regex patterns. Labels whose name match one of the patterns are never deleted by 'labels.prune'.",
 		Value: &cli.StringSlice{}, Destination: dst,
 	})
 }
 
+func NewLabelsPlanFlag(dst *string) *cli.StringFlag {
+	return &cli.StringFlag{Name: "plan", EnvVars: Prefixed("LABELS_PLAN"),
+		Usage: "Only print the label changes for each repository without applying them. Allowed values: table (human-readable), json (machine-readable, other output is written to stderr)",
+		Value: "", Destination: dst,
+	}
+}
+
+func NewLabelsImportMergeFlag(dst *bool) *cli.BoolFlag {
+	return &cli.BoolFlag{Name: "merge", EnvVars: Prefixed("LABELS_IMPORT_MERGE"),
+		Usage: "Keep the existing labels in the output file. Imported labels update existing labels with the same name and are added otherwise.",
+		Value: false, Destination: dst,
+	}
+}
+
+func NewLabelsImportOutputFlag(dst *string) *cli.PathFlag {
+	return &cli.PathFlag{Name: "output", EnvVars: Prefixed("LABELS_IMPORT_OUTPUT"), Aliases: []string{"o"},
+		Usage: "The YAML file where the imported labels are written to as 'repositoryLabels'. Other content of the file is preserved.",
+		Value: "greposync.yml", Destination: dst,
+	}
+}
+
+//// Git Hosting Flags
+
+func NewGitLabURLFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "gitlab.url", EnvVars: Prefixed("GITLAB_URL"),
+		Usage: "Base URL of the GitLab instance. Repositories on the same host are managed using the GitLab API. The token is read from the GITLAB_TOKEN environment variable.",
+		Value: "https://gitlab.com", Destination: dst,
+	})
+}
+
+func NewGiteaURLFlag(dst *string) *altsrc.StringFlag {
+	return altsrc.NewStringFlag(&cli.StringFlag{Name: "gitea.url", EnvVars: Prefixed("GITEA_URL"),
+		Usage: "Base URL of the Gitea or Forgejo instance. Repositories on the same host are managed using the Gitea API. The token is read from the GITEA_TOKEN environment variable.",
+		Value: "", Destination: dst,
+	})
+}
+
 //// Other Flags
 
 func NewTemplateRootDirFlag(dst *string) *altsrc.PathFlag {
@@ -5,25 +5,36 @@ import (
 	"github.com/ccremer/greposync/domain"
 	"github.com/ccremer/greposync/infrastructure/logging"
 	"github.com/ccremer/greposync/infrastructure/repositorystore"
+	"github.com/ccremer/greposync/infrastructure/ui"
+	"github.com/ccremer/greposync/infrastructure/valuestore"
 )
 
 type AppService struct {
-	repoStore  *repositorystore.RepositoryStore
-	labelStore domain.LabelStore
-	cfg        *cfg.Configuration
-	factory    logging.LoggerFactory
+	repoStore   *repositorystore.RepositoryStore
+	labelStore  domain.LabelStore
+	valueStore  *valuestore.KoanfStore
+	console     *ui.ColoredConsole
+	planPrinter *ui.LabelPlanPrinter
+	cfg         *cfg.Configuration
+	factory     logging.LoggerFactory
 }
 
 func NewConfigurator(
 	repoStore *repositorystore.RepositoryStore,
 	labelStore domain.LabelStore,
+	valueStore *valuestore.KoanfStore,
+	console *ui.ColoredConsole,
+	planPrinter *ui.LabelPlanPrinter,
 	cfg *cfg.Configuration,
 	factory logging.LoggerFactory,
 ) *AppService {
 	return &AppService{
-		repoStore:  repoStore,
-		labelStore: labelStore,
-		cfg:        cfg,
-		factory:    factory,
+		repoStore:   repoStore,
+		labelStore:  labelStore,
+		valueStore:  valueStore,
+		console:     console,
+		planPrinter: planPrinter,
+		cfg:         cfg,
+		factory:     factory,
 	}
 }
@@ -20,9 +20,16 @@ func (c *Command) createCommand() *cli.Command {
 		flags.NewIncludeFlag(&c.cfg.Project.Include),
 		flags.NewExcludeFlag(&c.cfg.Project.Exclude),
 
+		flags.NewLabelsPlanFlag(&c.planFlag),
+		flags.NewLabelsPruneFlag(&c.cfg.Labels.Prune),
+		flags.NewLabelsPruneKeepFlag(&c.pruneKeep),
+
 		flags.NewGitCommitBranchFlag(&c.cfg.Git.CommitBranch),
 		flags.NewGitDefaultNamespaceFlag(&c.appService.repoStore.DefaultNamespace),
 		flags.NewGitRootDirFlag(&c.appService.repoStore.ParentDir),
+
+		flags.NewGitLabURLFlag(&c.cfg.GitLab.URL),
+		flags.NewGiteaURLFlag(&c.cfg.Gitea.URL),
 	}
 	return &cli.Command{
 		Name:   "labels",
@@ -30,5 +37,33 @@ func (c *Command) createCommand() *cli.Command {
 		Before: flags.And(flags.FromYAML(cFlags), c.validateCommand),
 		Action: c.runCommand,
 		Flags:  cFlags,
+		Subcommands: []*cli.Command{
+			c.createImportCommand(),
+		},
+	}
+}
+
+func (c *Command) createImportCommand() *cli.Command {
+	cFlags := []cli.Flag{
+		flags.NewLogLevelFlag(&c.cfg.Log.Level),
+
+		flags.NewLabelsImportMergeFlag(&c.importMerge),
+		flags.NewLabelsImportOutputFlag(&c.importOutput),
+
+		flags.NewGitDefaultNamespaceFlag(&c.appService.repoStore.DefaultNamespace),
+		flags.NewGitBaseURLFlag(&c.appService.repoStore.BaseURL),
+
+		flags.NewGitLabURLFlag(&c.cfg.GitLab.URL),
+		flags.NewGiteaURLFlag(&c.cfg.Gitea.URL),
+	}
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Imports the labels of a repository into the configuration",
+		ArgsUsage: "<repository>",
+		Description: `Fetches the labels of the given repository and writes them as 'repositoryLabels' into the output file.
+The repository has the same format as in managed_repos.yml, e.g. 'namespace/repository'.`,
+		Before: flags.And(flags.FromYAML(cFlags), c.validateImportCommand),
+		Action: c.runImportCommand,
+		Flags:  cFlags,
 	}
 }
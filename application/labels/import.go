@@ -0,0 +1,55 @@
+package labels
+
+import (
+	"context"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/urfave/cli/v2"
+)
+
+type importPipeline struct {
+	appService *AppService
+	repoName   string
+	output     string
+	merge      bool
+	repo       *domain.GitRepository
+	labels     domain.LabelSet
+}
+
+func (c *Command) runImportCommand(ctx *cli.Context) error {
+	ip := &importPipeline{
+		appService: c.appService,
+		repoName:   ctx.Args().First(),
+		output:     c.importOutput,
+		merge:      c.importMerge,
+	}
+	result := pipeline.NewPipeline().AddBeforeHook(c.appService.factory.NewPipelineLogger("").Accept).WithSteps(
+		pipeline.NewStepFromFunc("resolve repository", ip.resolveRepository),
+		pipeline.NewStepFromFunc("fetch labels", ip.fetchLabels),
+		pipeline.NewStepFromFunc("write labels", ip.writeLabels),
+	).Run()
+	return result.Err()
+}
+
+func (p *importPipeline) resolveRepository(_ context.Context) error {
+	repo, err := p.appService.repoStore.FetchGitRepository(p.repoName)
+	p.repo = repo
+	return err
+}
+
+func (p *importPipeline) fetchLabels(_ context.Context) error {
+	labels, err := p.appService.labelStore.FetchLabelsForRepository(p.repo)
+	p.labels = labels
+	return err
+}
+
+func (p *importPipeline) writeLabels(_ context.Context) error {
+	converted := cfg.RepositoryLabelSetConverter{}.ConvertFromEntity(p.labels)
+	err := cfg.WriteRepositoryLabels(p.output, converted, p.merge)
+	if err == nil {
+		p.appService.factory.NewRepositoryLogger(p.repo).Info("Imported labels", "count", len(p.labels), "file", p.output)
+	}
+	return err
+}
@@ -2,11 +2,15 @@ package labels
 
 import (
 	"context"
+	"regexp"
+	"sort"
+	"sync"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
 	"github.com/ccremer/greposync/application/instrumentation"
 	"github.com/ccremer/greposync/cfg"
 	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/ui"
 	"github.com/go-logr/logr"
 	"github.com/urfave/cli/v2"
 )
@@ -20,6 +24,16 @@ type (
 		repos           []*domain.GitRepository
 		console         logr.Logger
 		instrumentation instrumentation.BatchInstrumentation
+
+		planFlag  string
+		plans     []ui.LabelPlan
+		plansLock sync.Mutex
+
+		pruneKeep         cli.StringSlice
+		pruneKeepPatterns []*regexp.Regexp
+
+		importMerge  bool
+		importOutput string
 	}
 )
 
@@ -41,6 +55,7 @@ func (c *Command) runCommand(_ *cli.Context) error {
 	result := pipeline.NewPipeline().WithSteps(
 		pipeline.NewStepFromFunc("fetch repositories", c.fetchRepositories),
 		pipeline.NewWorkerPoolStep("update labels for all repos", c.cfg.Project.Jobs, c.updateRepos(), c.instrumentation.NewCollectErrorHandler(c.cfg.Project.SkipBroken)),
+		pipeline.ToStep("print plan", c.printPlan, pipeline.Bool(c.isPlanning())),
 	).Run()
 	return result.Err()
 }
@@ -71,27 +86,42 @@ func (c *Command) createPipeline(r *domain.GitRepository) *pipeline.Pipeline {
 			c.instrumentation.PipelineForRepositoryStarted(r)
 			return nil
 		}),
+		pipeline.NewStepFromFunc("load label config", c.loadLabelConfig(uc, r)),
 		pipeline.NewStepFromFunc("fetch labels", uc.fetchLabelsForRepository),
 		pipeline.NewStepFromFunc("determine which labels to modify", c.determineLabelsToModify(uc, r)),
 		pipeline.NewStepFromFunc("determine which labels to delete", c.determineLabelsToDelete(uc, r)),
-		pipeline.NewStepFromFunc("update existing labels", uc.updateLabelsForRepository),
-		pipeline.NewStepFromFunc("delete unwanted labels", uc.deleteLabelsForRepository),
+		pipeline.ToStep("determine which labels to prune", c.determineLabelsToPrune(uc, r), pipeline.Bool(c.cfg.Labels.Prune)),
+		pipeline.ToStep("plan label changes", c.planLabelChanges(uc, r), pipeline.Bool(c.isPlanning())),
+		pipeline.ToStep("update existing labels", uc.updateLabelsForRepository, pipeline.Bool(!c.isPlanning())),
+		pipeline.ToStep("delete unwanted labels", uc.deleteLabelsForRepository, pipeline.Bool(!c.isPlanning())),
 	).WithFinalizer(func(ctx context.Context, result pipeline.Result) error {
 		c.instrumentation.PipelineForRepositoryCompleted(r, result.Err())
 		return result.Err()
 	})
 }
 
+func (c *Command) loadLabelConfig(uc *labelPipeline, r *domain.GitRepository) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if !r.RootDir.DirExists() {
+			c.appService.factory.NewRepositoryLogger(r).Info("Repository is not cloned locally, label settings of the repository are ignored", "dir", r.RootDir)
+		}
+		labelConfig, err := c.appService.valueStore.FetchRepositoryLabels(r, c.cfg.RepositoryLabels)
+		uc.labelConfig = labelConfig
+		return err
+	}
+}
+
 func (c *Command) determineLabelsToModify(uc *labelPipeline, r *domain.GitRepository) func(ctx context.Context) error {
 	converter := cfg.RepositoryLabelSetConverter{}
 	return func(ctx context.Context) error {
-		toModify, err := converter.ConvertToEntity(c.cfg.RepositoryLabels.SelectModifications())
+		toModify, err := converter.ConvertToEntity(uc.labelConfig.SelectModifications())
 		if err != nil {
 			return err
 		}
 		uc.labelsToModify = toModify
 
-		mergedSet := r.Labels.Merge(uc.labelsToModify)
+		// Remote labels that get renamed must not be ensured under their old name.
+		mergedSet := r.Labels.WithoutRenamed(uc.labelsToModify).Merge(uc.labelsToModify)
 		err = r.SetLabels(mergedSet)
 		return err
 	}
@@ -100,7 +130,7 @@ func (c *Command) determineLabelsToModify(uc *labelPipeline, r *domain.GitReposi
 func (c *Command) determineLabelsToDelete(uc *labelPipeline, r *domain.GitRepository) func(ctx context.Context) error {
 	converter := cfg.RepositoryLabelSetConverter{}
 	return func(ctx context.Context) error {
-		toDelete, err := converter.ConvertToEntity(c.cfg.RepositoryLabels.SelectDeletions())
+		toDelete, err := converter.ConvertToEntity(uc.labelConfig.SelectDeletions())
 		if err != nil {
 			return err
 		}
@@ -112,6 +142,56 @@ func (c *Command) determineLabelsToDelete(uc *labelPipeline, r *domain.GitReposi
 	}
 }
 
+func (c *Command) determineLabelsToPrune(uc *labelPipeline, r *domain.GitRepository) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		// Labels that get renamed are not unmanaged.
+		unmanaged := uc.remoteLabels.WithoutRenamed(uc.labelsToModify).Without(uc.labelsToModify)
+		toPrune := make(domain.LabelSet, 0, len(unmanaged))
+		for _, label := range unmanaged {
+			if !c.isKeptFromPruning(label) {
+				toPrune = append(toPrune, label)
+			}
+		}
+		uc.labelsToDelete = uc.labelsToDelete.Merge(toPrune)
+
+		reducedSet := r.Labels.Without(toPrune)
+		return r.SetLabels(reducedSet)
+	}
+}
+
+func (c *Command) isKeptFromPruning(label domain.Label) bool {
+	for _, pattern := range c.pruneKeepPatterns {
+		if pattern.MatchString(label.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Command) planLabelChanges(uc *labelPipeline, r *domain.GitRepository) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		changes := domain.PlanLabelChanges(uc.remoteLabels, uc.labelsToModify, uc.labelsToDelete)
+		c.plansLock.Lock()
+		defer c.plansLock.Unlock()
+		c.plans = append(c.plans, ui.LabelPlan{Repository: r, Changes: changes})
+		return nil
+	}
+}
+
+func (c *Command) printPlan(_ context.Context) error {
+	sort.Slice(c.plans, func(i, j int) bool {
+		return c.plans[i].Repository.URL.String() < c.plans[j].Repository.URL.String()
+	})
+	if c.planFlag == planFormatJSON {
+		return c.appService.planPrinter.PrintJSON(c.plans)
+	}
+	return c.appService.planPrinter.PrintTable(c.plans)
+}
+
+func (c *Command) isPlanning() bool {
+	return c.planFlag != ""
+}
+
 func (c *Command) fetchRepositories(ctx context.Context) error {
 	repos, err := c.appService.repoStore.FetchGitRepositories()
 	c.repos = repos
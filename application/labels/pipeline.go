@@ -3,11 +3,14 @@ package labels
 import (
 	"context"
 
+	"github.com/ccremer/greposync/cfg"
 	"github.com/ccremer/greposync/domain"
 )
 
 type labelPipeline struct {
 	appService     *AppService
+	labelConfig    cfg.RepositoryLabelMap
+	remoteLabels   domain.LabelSet
 	labelsToModify domain.LabelSet
 	labelsToDelete domain.LabelSet
 	repo           *domain.GitRepository
@@ -23,6 +26,7 @@ func (p *labelPipeline) fetchLabelsForRepository(_ context.Context) error {
 	if err != nil {
 		return err
 	}
+	p.remoteLabels = labels
 	return p.repo.SetLabels(labels)
 }
 
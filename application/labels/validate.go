@@ -1,6 +1,7 @@
 package labels
 
 import (
+	"os"
 	"regexp"
 
 	"github.com/ccremer/greposync/application/clierror"
@@ -9,6 +10,11 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	planFormatTable = "table"
+	planFormatJSON  = "json"
+)
+
 func (c *Command) validateCommand(ctx *cli.Context) error {
 	if err := cfg.ParseConfig(c.cfg.Project.MainConfigFileName, c.cfg, ctx); err != nil {
 		return clierror.AsUsageError(err)
@@ -29,6 +35,42 @@ func (c *Command) validateCommand(ctx *cli.Context) error {
 	if err != nil {
 		return clierror.AsUsageErrorf("invalid label configuration in '%s': %w", "repositoryLabels", err)
 	}
+
+	switch c.planFlag {
+	case "", planFormatTable:
+		break
+	case planFormatJSON:
+		// Keep stdout clean for the JSON document.
+		c.appService.console.SetOutput(os.Stderr)
+	default:
+		return clierror.AsFlagUsageErrorf(flags.NewLabelsPlanFlag(nil).Name, "unrecognized: %s", c.planFlag)
+	}
+
+	c.cfg.Labels.PruneKeep = c.pruneKeep.Value()
+	c.pruneKeepPatterns = make([]*regexp.Regexp, len(c.cfg.Labels.PruneKeep))
+	for i, pattern := range c.cfg.Labels.PruneKeep {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return clierror.AsFlagUsageError(flags.NewLabelsPruneKeepFlag(nil).Name, err)
+		}
+		c.pruneKeepPatterns[i] = compiled
+	}
+	c.appService.factory.SetLogLevel(c.cfg.Log.Level)
+	c.appService.factory.NewGenericLogger("").V(1).Info("Using config", "config", flags.CollectFlagValues(ctx))
+	return nil
+}
+
+func (c *Command) validateImportCommand(ctx *cli.Context) error {
+	if err := cfg.ParseConfig(c.cfg.Project.MainConfigFileName, c.cfg, ctx); err != nil {
+		return clierror.AsUsageError(err)
+	}
+
+	if ctx.NArg() != 1 {
+		return clierror.AsUsageErrorf("exactly one repository argument is required, got %d", ctx.NArg())
+	}
+	if c.importOutput == "" {
+		return clierror.AsFlagUsageErrorf(flags.NewLabelsImportOutputFlag(nil).Name, "cannot be empty")
+	}
 	c.appService.factory.SetLogLevel(c.cfg.Log.Level)
 	c.appService.factory.NewGenericLogger("").V(1).Info("Using config", "config", flags.CollectFlagValues(ctx))
 	return nil
@@ -0,0 +1,32 @@
+package settings
+
+import (
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging"
+	"github.com/ccremer/greposync/infrastructure/repositorystore"
+)
+
+type AppService struct {
+	repoStore     *repositorystore.RepositoryStore
+	settingsStore domain.SettingsStore
+	valueStore    domain.ValueStore
+	cfg           *cfg.Configuration
+	factory       logging.LoggerFactory
+}
+
+func NewConfigurator(
+	repoStore *repositorystore.RepositoryStore,
+	settingsStore domain.SettingsStore,
+	valueStore domain.ValueStore,
+	cfg *cfg.Configuration,
+	factory logging.LoggerFactory,
+) *AppService {
+	return &AppService{
+		repoStore:     repoStore,
+		settingsStore: settingsStore,
+		valueStore:    valueStore,
+		cfg:           cfg,
+		factory:       factory,
+	}
+}
@@ -0,0 +1,37 @@
+package settings
+
+import (
+	"github.com/ccremer/greposync/application/flags"
+	"github.com/urfave/cli/v2"
+)
+
+// GetCliCommand returns the command instance for CLI library.
+func (c *Command) GetCliCommand() *cli.Command {
+	return c.createCommand()
+}
+
+func (c *Command) createCommand() *cli.Command {
+	cFlags := []cli.Flag{
+		flags.NewLogLevelFlag(&c.cfg.Log.Level),
+		flags.NewShowLogFlag(&c.cfg.Log.ShowLog),
+
+		flags.NewJobsFlag(&c.cfg.Project.Jobs),
+		flags.NewSkipBrokenFlag(&c.cfg.Project.SkipBroken),
+		flags.NewIncludeFlag(&c.appService.repoStore.IncludeFilter),
+		flags.NewExcludeFlag(&c.appService.repoStore.ExcludeFilter),
+
+		flags.NewGitRootDirFlag(&c.appService.repoStore.ParentDir),
+		flags.NewGitDefaultNamespaceFlag(&c.appService.repoStore.DefaultNamespace),
+		flags.NewGitBaseURLFlag(&c.appService.repoStore.BaseURL),
+	}
+	return &cli.Command{
+		Name:  "settings",
+		Usage: "Synchronizes repository settings",
+		Description: `Applies the 'settings' from the main config and the ':settings' of each repository's sync config to the repositories.
+Only settings that are configured and differ from the current settings are changed.
+Repositories whose Git hosting service doesn't support repository settings are skipped.`,
+		Before: flags.And(flags.FromYAML(cFlags), c.validateCommand),
+		Action: c.runCommand,
+		Flags:  cFlags,
+	}
+}
@@ -0,0 +1,58 @@
+package settings
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+)
+
+type settingsPipeline struct {
+	appService *AppService
+	repo       *domain.GitRepository
+	defaults   domain.RepositorySettings
+	desired    domain.RepositorySettings
+	current    domain.RepositorySettings
+	changes    domain.RepositorySettings
+	// unsupported is true if the Git hosting service doesn't support repository settings.
+	unsupported bool
+}
+
+func (p *settingsPipeline) loadSettingsConfig(_ context.Context) error {
+	settings, err := p.appService.valueStore.FetchRepositorySettings(p.repo)
+	p.desired = settings.MergeWith(p.defaults)
+	return err
+}
+
+func (p *settingsPipeline) fetchSettingsForRepository(_ context.Context) error {
+	settings, err := p.appService.settingsStore.FetchSettingsForRepository(p.repo)
+	if errors.Is(err, githosting.ErrCapabilityNotSupported) {
+		p.unsupported = true
+		p.appService.factory.NewRepositoryLogger(p.repo).Info("Skipping repository, settings are not supported by the Git hosting service")
+		return nil
+	}
+	p.current = settings
+	return err
+}
+
+func (p *settingsPipeline) isSupported(_ context.Context) bool {
+	return !p.unsupported
+}
+
+func (p *settingsPipeline) determineSettingsToChange(_ context.Context) error {
+	p.changes = p.desired.Diff(p.current)
+	if !p.hasChanges(nil) {
+		p.appService.factory.NewRepositoryLogger(p.repo).Info("Settings are up-to-date")
+	}
+	return nil
+}
+
+func (p *settingsPipeline) hasChanges(_ context.Context) bool {
+	return !p.changes.IsEmpty()
+}
+
+func (p *settingsPipeline) updateSettingsForRepository(_ context.Context) error {
+	err := p.appService.settingsStore.UpdateSettingsForRepository(p.repo, p.changes)
+	return err
+}
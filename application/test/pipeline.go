@@ -18,7 +18,7 @@ type updatePipeline struct {
 }
 
 func (c *updatePipeline) renderTemplates(_ context.Context) error {
-	err := c.appService.renderService.RenderTemplates(domain.RenderContext{
+	_, err := c.appService.renderService.RenderTemplates(domain.RenderContext{
 		Repository:           c.repo,
 		ValueStore:           c.appService.valueStore,
 		TemplateStore:        c.appService.templateStore,
@@ -28,6 +28,18 @@ func (c *Command) createCliCommand() *cli.Command {
 		flags.NewGitCommitBranchFlag(&c.appService.repoStore.CommitBranch),
 		flags.NewGitDefaultNamespaceFlag(&c.appService.repoStore.DefaultNamespace),
 		flags.NewGitCommitMessageFlag(&c.cfg.Git.CommitMessage),
+		flags.NewGitBackendFlag(&c.cfg.Git.Backend),
+		flags.NewGitCloneDepthFlag(&c.cfg.Git.CloneDepth),
+		flags.NewGitCloneFilterFlag(&c.cfg.Git.CloneFilter),
+		flags.NewGitSparseCheckoutFlag(&c.cfg.Git.SparseCheckout),
+		flags.NewGitSigningFormatFlag(&c.cfg.Git.SigningFormat),
+		flags.NewGitSigningKeyFlag(&c.cfg.Git.SigningKey),
+		flags.NewGitSignoffFlag(&c.cfg.Git.Signoff),
+		flags.NewGitAuthorNameFlag(&c.cfg.Git.AuthorName),
+		flags.NewGitAuthorEmailFlag(&c.cfg.Git.AuthorEmail),
+		flags.NewGitCommitterNameFlag(&c.cfg.Git.CommitterName),
+		flags.NewGitCommitterEmailFlag(&c.cfg.Git.CommitterEmail),
+		flags.NewGitTrailersFlag(&c.GitTrailers),
 		flags.NewGitBaseURLFlag(&c.appService.repoStore.BaseURL),
 
 		flags.NewPRCreateFlag(&c.cfg.PullRequest.Create),
@@ -35,6 +47,19 @@ func (c *Command) createCliCommand() *cli.Command {
 		flags.NewPRSubjectFlag(&c.cfg.PullRequest.Subject),
 		flags.NewPRTargetBranchFlag(&c.cfg.PullRequest.TargetBranch),
 		flags.NewPRLabelsFlag(&c.PrLabels),
+		flags.NewPRLabelSyncModeFlag(&c.cfg.PullRequest.LabelSyncMode),
+		flags.NewPRReviewersFlag(&c.PrReviewers),
+		flags.NewPRTeamReviewersFlag(&c.PrTeamReviewers),
+		flags.NewPRAssigneesFlag(&c.PrAssignees),
+		flags.NewPRDraftFlag(&c.cfg.PullRequest.Draft),
+		flags.NewPRAutoMergeFlag(&c.cfg.PullRequest.AutoMerge),
+		flags.NewPRMergeMethodFlag(&c.cfg.PullRequest.MergeMethod),
+		flags.NewPRCloseCommentFlag(&c.cfg.PullRequest.CloseComment),
+		flags.NewPRDeleteBranchFlag(&c.cfg.PullRequest.DeleteBranch),
+		flags.NewPRForkOwnerFlag(&c.cfg.PullRequest.ForkOwner),
+
+		flags.NewGitLabURLFlag(&c.cfg.GitLab.URL),
+		flags.NewGiteaURLFlag(&c.cfg.Gitea.URL),
 
 		flags.NewTemplateRootDirFlag(&c.appService.templateStore.RootDir),
 	}
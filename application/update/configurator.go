@@ -8,12 +8,26 @@ import (
 	"github.com/ccremer/greposync/infrastructure/ui"
 )
 
+// gitRepositoryStore is a domain.GitRepositoryStore with additional methods that are implemented by all Git backends.
+type gitRepositoryStore interface {
+	domain.GitRepositoryStore
+	// IsDirty returns true if the working tree contains changes.
+	IsDirty(repository *domain.GitRepository) bool
+	// CheckSigningKey returns an error if the given key is not available for signing commits.
+	CheckSigningKey(key domain.SigningKey) error
+	// SparseCheckout limits the working tree to the given files.
+	SparseCheckout(repository *domain.GitRepository, files []domain.Path) error
+}
+
 type AppService struct {
 	engine         *gotemplate.GoTemplateEngine
 	repoStore      *repositorystore.RepositoryStore
+	goGitStore     *repositorystore.GoGitRepositoryStore
+	gitStore       gitRepositoryStore // gitStore is the Git backend selected by git.backend.
 	templateStore  *gotemplate.GoTemplateStore
 	valueStore     domain.ValueStore
 	prStore        domain.PullRequestStore
+	forkStore      domain.ForkStore
 	renderService  *domain.RenderService
 	diffPrinter    *ui.ConsoleDiffPrinter
 	cfg            *cfg.Configuration
@@ -25,9 +39,11 @@ type AppService struct {
 func NewConfigurator(
 	engine *gotemplate.GoTemplateEngine,
 	repoStore *repositorystore.RepositoryStore,
+	goGitStore *repositorystore.GoGitRepositoryStore,
 	templateStore *gotemplate.GoTemplateStore,
 	valueStore domain.ValueStore,
 	prStore domain.PullRequestStore,
+	forkStore domain.ForkStore,
 	renderService *domain.RenderService,
 	cleanupService *domain.CleanupService,
 	pullRequestService *domain.PullRequestService,
@@ -38,9 +54,12 @@ func NewConfigurator(
 	return &AppService{
 		engine:         engine,
 		repoStore:      repoStore,
+		goGitStore:     goGitStore,
+		gitStore:       repoStore,
 		templateStore:  templateStore,
 		valueStore:     valueStore,
 		prStore:        prStore,
+		forkStore:      forkStore,
 		renderService:  renderService,
 		cleanupService: cleanupService,
 		prService:      pullRequestService,
@@ -20,8 +20,13 @@ type (
 		instr        instrumentation.BatchInstrumentation
 		logFactory   logging.LoggerFactory
 
-		dryRunFlag string
-		PrLabels   cli.StringSlice
+		dryRunFlag      string
+		prAutoMerge     *bool
+		PrLabels        cli.StringSlice
+		PrReviewers     cli.StringSlice
+		PrTeamReviewers cli.StringSlice
+		PrAssignees     cli.StringSlice
+		GitTrailers     cli.StringSlice
 	}
 )
 
@@ -62,11 +67,31 @@ func (c *Command) createPipeline(r *domain.GitRepository) *pipeline.Pipeline {
 	enabledPush := !c.cfg.Git.SkipPush
 	showDiff := c.cfg.Log.ShowDiff
 	createPR := c.cfg.PullRequest.Create
+	deleteBranch := c.cfg.PullRequest.DeleteBranch
+	sparseCheckout := c.cfg.Git.SparseCheckout
+	// The fork is only needed if the remote is accessed.
+	forkRepo := c.cfg.PullRequest.ForkOwner != "" && (enabledPush || createPR)
 
 	up := &updatePipeline{
+		log:        c.logFactory.NewRepositoryLogger(r),
 		repo:       r,
 		appService: c.appService,
-		prLabels:   c.PrLabels.Value(),
+		commitSettings: domain.CommitSettings{
+			Message:  &c.cfg.Git.CommitMessage,
+			Trailers: c.GitTrailers.Value(),
+		},
+		prSettings: domain.PullRequestSettings{
+			Subject:       &c.cfg.PullRequest.Subject,
+			BodyTemplate:  &c.cfg.PullRequest.BodyTemplate,
+			Labels:        c.PrLabels.Value(),
+			TargetBranch:  c.cfg.PullRequest.TargetBranch,
+			Reviewers:     c.PrReviewers.Value(),
+			TeamReviewers: c.PrTeamReviewers.Value(),
+			Assignees:     c.PrAssignees.Value(),
+			Draft:         &c.cfg.PullRequest.Draft,
+			AutoMerge:     c.prAutoMerge,
+			MergeMethod:   domain.MergeMethod(c.cfg.PullRequest.MergeMethod),
+		},
 	}
 
 	logger := c.logFactory.NewPipelineLogger(r.URL.GetFullName())
@@ -81,10 +106,14 @@ func (c *Command) createPipeline(r *domain.GitRepository) *pipeline.Pipeline {
 			WithNestedSteps("prepare workspace",
 
 				pipeline.ToStep("clone repository", up.clone, up.dirMissing()),
+				pipeline.ToStep("ensure fork", up.ensureFork, pipeline.Bool(forkRepo)),
 				pipeline.ToStep("fetch", up.fetch, pipeline.Bool(resetRepo)),
 				pipeline.ToStep("reset", up.reset, pipeline.Bool(resetRepo)),
+				// The target branch is needed to create the commit branch.
+				pipeline.NewStepFromFunc("load pull request settings", up.loadPullRequestSettings),
 				pipeline.ToStep("checkout branch", up.checkout, pipeline.Bool(resetRepo)),
 				pipeline.ToStep("pull", up.pull, pipeline.Bool(resetRepo)),
+				pipeline.ToStep("sparse checkout", up.sparseCheckout, pipeline.Bool(sparseCheckout)),
 			),
 
 		pipeline.NewPipeline().AddBeforeHook(logger.Accept).
@@ -92,6 +121,7 @@ func (c *Command) createPipeline(r *domain.GitRepository) *pipeline.Pipeline {
 				pipeline.NewStepFromFunc("render templates", up.renderTemplates),
 				pipeline.NewStepFromFunc("cleanup unwanted files", up.cleanupUnwantedFiles),
 			),
+		pipeline.ToStep("load commit settings", up.loadCommitSettings, pipeline.Bool(enabledCommits)),
 
 		pipeline.If(pipeline.And(pipeline.Bool(enabledCommits), up.isDirty()),
 			pipeline.NewPipeline().
@@ -106,6 +136,8 @@ func (c *Command) createPipeline(r *domain.GitRepository) *pipeline.Pipeline {
 		pipeline.ToStep("push changes", up.push, pipeline.And(pipeline.Bool(enabledPush), up.hasCommits())),
 		pipeline.ToStep("find existing pull request", up.fetchPullRequest, pipeline.Bool(createPR)),
 		pipeline.ToStep("ensure pull request", up.ensurePullRequest, pipeline.And(up.hasCommits(), pipeline.Bool(createPR))),
+		pipeline.ToStep("close obsolete pull request", up.closePullRequest, pipeline.And(up.hasPullRequest(), pipeline.Not(up.hasCommits()))),
+		pipeline.ToStep("delete obsolete branch", up.deleteRemoteBranch, pipeline.And(pipeline.Bool(enabledPush && deleteBranch), pipeline.Not(up.hasCommits()))),
 	)
 	pipe.WithFinalizer(func(ctx context.Context, result pipeline.Result) error {
 		c.instr.PipelineForRepositoryCompleted(r, result.Err())
@@ -2,8 +2,11 @@ package update
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/ccremer/greposync/cfg"
 	"github.com/ccremer/greposync/domain"
 	"github.com/go-logr/logr"
 )
@@ -12,43 +15,151 @@ type updatePipeline struct {
 	log        logr.Logger
 	repo       *domain.GitRepository
 	appService *AppService
-	prLabels   []string
+	// prSettings are the defaults from the main configuration until the repository-specific settings are loaded.
+	prSettings domain.PullRequestSettings
+	// changes are exposed to the templates of new pull requests.
+	changes domain.ChangeSummary
+	// commitSettings are the defaults from the main configuration until the repository-specific settings are loaded.
+	commitSettings domain.CommitSettings
+
+	commitsFound *bool
 }
 
 func (c *updatePipeline) clone(_ context.Context) error {
-	return c.appService.repoStore.Clone(c.repo)
+	return c.appService.gitStore.Clone(c.repo, cloneOptions(c.appService.cfg))
+}
+
+// sparseCheckout limits the working tree to the files that are managed by the templates.
+func (c *updatePipeline) sparseCheckout(_ context.Context) error {
+	files, err := c.appService.renderService.TargetPaths(domain.RenderContext{
+		Repository:    c.repo,
+		ValueStore:    c.appService.valueStore,
+		TemplateStore: c.appService.templateStore,
+		Engine:        c.appService.engine,
+	})
+	if err != nil {
+		return err
+	}
+	return c.appService.gitStore.SparseCheckout(c.repo, files)
+}
+
+// cloneOptions returns the strategy with which repositories are cloned.
+func cloneOptions(config *cfg.Configuration) domain.CloneOptions {
+	return domain.CloneOptions{
+		Depth:  config.Git.CloneDepth,
+		Filter: domain.CloneFilter(config.Git.CloneFilter),
+		Sparse: config.Git.SparseCheckout,
+	}
 }
 
 func (c *updatePipeline) fetch(_ context.Context) error {
-	return c.appService.repoStore.Fetch(c.repo)
+	return c.appService.gitStore.Fetch(c.repo)
+}
+
+func (c *updatePipeline) ensureFork(_ context.Context) error {
+	return c.appService.forkStore.EnsureForkForRepository(c.repo, c.appService.cfg.PullRequest.ForkOwner)
 }
 
 func (c *updatePipeline) pull(_ context.Context) error {
-	return c.appService.repoStore.Pull(c.repo)
+	return c.appService.gitStore.Pull(c.repo)
 }
 
 func (c *updatePipeline) checkout(_ context.Context) error {
-	return c.appService.repoStore.Checkout(c.repo)
+	return c.appService.gitStore.Checkout(c.repo)
 }
 
 func (c *updatePipeline) reset(_ context.Context) error {
-	return c.appService.repoStore.Reset(c.repo)
+	return c.appService.gitStore.Reset(c.repo)
 }
 
 func (c *updatePipeline) add(_ context.Context) error {
-	return c.appService.repoStore.Add(c.repo)
+	return c.appService.gitStore.Add(c.repo)
 }
 
 func (c *updatePipeline) commit(_ context.Context) error {
-	err := c.appService.repoStore.Commit(c.repo, domain.CommitOptions{
-		Message: c.appService.cfg.Git.CommitMessage,
-		Amend:   c.appService.cfg.Git.Amend,
+	message, err := c.renderCommitMessage()
+	if err != nil {
+		return err
+	}
+	trailers, err := c.renderTrailers()
+	if err != nil {
+		return err
+	}
+	author, committer := identities(c.appService.cfg)
+	err = c.appService.gitStore.Commit(c.repo, domain.CommitOptions{
+		Message:    message,
+		Amend:      c.appService.cfg.Git.Amend,
+		SigningKey: signingKey(c.appService.cfg),
+		Signoff:    c.appService.cfg.Git.Signoff,
+		Author:     author,
+		Committer:  committer,
+		Trailers:   trailers,
 	})
 	return err
 }
 
+// renderCommitMessage renders the commit message template.
+func (c *updatePipeline) renderCommitMessage() (string, error) {
+	result, err := c.appService.engine.ExecuteString(*c.commitSettings.Message, c.commitValues())
+	if err != nil {
+		return "", err
+	}
+	message := result.String()
+	if strings.TrimSpace(message) == "" {
+		return "", fmt.Errorf("%w: commit message of %s is empty", domain.ErrInvalidArgument, c.repo.URL.GetFullName())
+	}
+	return message, nil
+}
+
+// renderTrailers renders the commit trailer templates.
+// Trailers that render to an empty string are omitted.
+func (c *updatePipeline) renderTrailers() ([]string, error) {
+	values := c.commitValues()
+	trailers := make([]string, 0, len(c.commitSettings.Trailers))
+	for _, tpl := range c.commitSettings.Trailers {
+		result, err := c.appService.engine.ExecuteString(tpl, values)
+		if err != nil {
+			return nil, err
+		}
+		trailer := strings.TrimSpace(result.String())
+		if trailer == "" {
+			continue
+		}
+		if err := domain.ValidateTrailer(trailer); err != nil {
+			return nil, err
+		}
+		trailers = append(trailers, trailer)
+	}
+	return trailers, nil
+}
+
+// commitValues returns the metadata with which the commit message and trailers are rendered.
+func (c *updatePipeline) commitValues() domain.Values {
+	return domain.Values{
+		domain.MetadataValueKey: domain.Values{
+			domain.RepositoryValueKey: c.repo.AsValues(),
+			domain.ChangesValueKey:    c.changes.AsValues(),
+		},
+	}
+}
+
+// identities returns the author and committer of commits.
+func identities(config *cfg.Configuration) (author, committer domain.Identity) {
+	author = domain.Identity{Name: config.Git.AuthorName, Email: config.Git.AuthorEmail}
+	committer = domain.Identity{Name: config.Git.CommitterName, Email: config.Git.CommitterEmail}
+	return author, committer
+}
+
+// signingKey returns the key with which commits are signed.
+func signingKey(config *cfg.Configuration) domain.SigningKey {
+	return domain.SigningKey{
+		Format: domain.SigningFormat(config.Git.SigningFormat),
+		Key:    config.Git.SigningKey,
+	}
+}
+
 func (c *updatePipeline) diff(_ context.Context) error {
-	diff, err := c.appService.repoStore.Diff(c.repo, domain.DiffOptions{
+	diff, err := c.appService.gitStore.Diff(c.repo, domain.DiffOptions{
 		WorkDirToHEAD: c.appService.cfg.Git.SkipCommit, // If we don't commit, show the unstaged changes
 	})
 	if err != nil {
@@ -59,49 +170,84 @@ func (c *updatePipeline) diff(_ context.Context) error {
 }
 
 func (c *updatePipeline) push(_ context.Context) error {
-	err := c.appService.repoStore.Push(c.repo, domain.PushOptions{
+	err := c.appService.gitStore.Push(c.repo, domain.PushOptions{
 		Force: c.appService.cfg.Git.ForcePush,
 	})
 	return err
 }
 
 func (c *updatePipeline) renderTemplates(_ context.Context) error {
-	err := c.appService.renderService.RenderTemplates(domain.RenderContext{
+	files, err := c.appService.renderService.RenderTemplates(domain.RenderContext{
 		Repository:    c.repo,
 		ValueStore:    c.appService.valueStore,
 		TemplateStore: c.appService.templateStore,
 		Engine:        c.appService.engine,
 	})
+	c.changes.RenderedFiles = files
 	return err
 }
 
 func (c *updatePipeline) cleanupUnwantedFiles(_ context.Context) error {
-	err := c.appService.cleanupService.CleanupUnwantedFiles(domain.CleanupPipeline{
+	files, err := c.appService.cleanupService.CleanupUnwantedFiles(domain.CleanupPipeline{
 		Repository:    c.repo,
 		ValueStore:    c.appService.valueStore,
 		TemplateStore: c.appService.templateStore,
 	})
+	c.changes.DeletedFiles = files
 	return err
 }
 
+func (c *updatePipeline) loadPullRequestSettings(_ context.Context) error {
+	settings, err := c.appService.valueStore.FetchPullRequestSettings(c.repo)
+	if err != nil {
+		return err
+	}
+	c.prSettings = settings.MergeWith(c.prSettings)
+	c.repo.TargetBranch = c.prSettings.TargetBranch
+	return nil
+}
+
+func (c *updatePipeline) loadCommitSettings(_ context.Context) error {
+	settings, err := c.appService.valueStore.FetchCommitSettings(c.repo)
+	if err != nil {
+		return err
+	}
+	c.commitSettings = settings.MergeWith(c.commitSettings)
+	return nil
+}
+
 func (c *updatePipeline) ensurePullRequest(_ context.Context) error {
 	if c.repo.PullRequest == nil {
-		err := c.appService.prService.NewPullRequestForRepository(domain.PullRequestServiceContext{
+		files, err := c.appService.gitStore.DiffStat(c.repo, c.targetBranch(), c.repo.CommitBranch)
+		if err != nil {
+			return err
+		}
+		c.changes.Files = files
+		err = c.appService.prService.NewPullRequestForRepository(domain.PullRequestServiceContext{
 			Repository:     c.repo,
 			TemplateEngine: c.appService.engine,
-			Body:           c.appService.cfg.PullRequest.BodyTemplate,
-			Title:          c.appService.cfg.PullRequest.Subject,
-			TargetBranch:   c.repo.DefaultBranch,
+			Body:           *c.prSettings.BodyTemplate,
+			Title:          *c.prSettings.Subject,
+			TargetBranch:   c.targetBranch(),
+			Changes:        c.changes,
 		})
 		if err != nil {
 			return err
 		}
 	}
-	if err := c.repo.PullRequest.AttachLabels(domain.FromStringSlice(c.prLabels)); err != nil {
+	mode := domain.LabelSyncMode(c.appService.cfg.PullRequest.LabelSyncMode)
+	if err := c.repo.PullRequest.SyncLabels(domain.FromStringSlice(c.prSettings.Labels), mode); err != nil {
 		return err
 	}
-	err := c.appService.prStore.EnsurePullRequest(c.repo)
-	return err
+	if err := c.prSettings.ApplyTo(c.repo.PullRequest); err != nil {
+		return err
+	}
+	return c.appService.prStore.EnsurePullRequest(c.repo)
+}
+
+// targetBranch returns the branch into which the commit branch is merged.
+func (c *updatePipeline) targetBranch() string {
+	return c.repo.GetTargetBranch()
 }
 
 func (c *updatePipeline) dirMissing() pipeline.Predicate {
@@ -112,20 +258,30 @@ func (c *updatePipeline) dirMissing() pipeline.Predicate {
 
 func (c *updatePipeline) isDirty() pipeline.Predicate {
 	return func(_ context.Context) bool {
-		return c.appService.repoStore.IsDirty(c.repo)
+		return c.appService.gitStore.IsDirty(c.repo)
 	}
 }
 
+// hasCommits returns true if the commit branch contains changes that aren't in the target branch yet.
+// The result is determined once and then reused, as it doesn't change after committing.
+// If it can't be determined, it's assumed that there are changes.
 func (c *updatePipeline) hasCommits() pipeline.Predicate {
 	return func(_ context.Context) bool {
-		return true
-		// TODO: There should be a better way to determine whether to push...
+		if c.commitsFound == nil {
+			hasCommits, err := c.appService.gitStore.HasCommitsBetween(c.repo, c.targetBranch(), c.repo.CommitBranch)
+			if err != nil {
+				c.log.Info("Could not determine whether there are changes to push", "reason", err.Error())
+				hasCommits = true
+			}
+			c.commitsFound = &hasCommits
+		}
+		return *c.commitsFound
+	}
+}
 
-		/*		hasCommits, err := repositorystore.HasCommitsBetween(c.repo, c.repo.DefaultBranch, c.repo.CommitBranch)
-				if err != nil {
-					c.log.WarnF("%w", err)
-				}
-				return hasCommits*/
+func (c *updatePipeline) hasPullRequest() pipeline.Predicate {
+	return func(_ context.Context) bool {
+		return c.repo.PullRequest != nil
 	}
 }
 
@@ -134,3 +290,11 @@ func (c *updatePipeline) fetchPullRequest(_ context.Context) error {
 	c.repo.PullRequest = pr
 	return err
 }
+
+func (c *updatePipeline) closePullRequest(_ context.Context) error {
+	return c.appService.prStore.ClosePullRequest(c.repo, c.appService.cfg.PullRequest.CloseComment)
+}
+
+func (c *updatePipeline) deleteRemoteBranch(_ context.Context) error {
+	return c.appService.gitStore.DeleteRemoteBranch(c.repo)
+}
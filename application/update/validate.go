@@ -6,6 +6,7 @@ import (
 	"github.com/ccremer/greposync/application/clierror"
 	"github.com/ccremer/greposync/application/flags"
 	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
 	"github.com/urfave/cli/v2"
 )
 
@@ -25,6 +26,25 @@ func (c *Command) validateUpdateCommand(ctx *cli.Context) error {
 		return clierror.AsFlagUsageErrorf(flags.ProjectJobsFlagName, "value is not between %d and %d", flags.JobsMinimumCount, flags.JobsMaximumCount)
 	}
 
+	if err := domain.LabelSyncMode(c.cfg.PullRequest.LabelSyncMode).Validate(); err != nil {
+		return clierror.AsFlagUsageError(flags.NewPRLabelSyncModeFlag(nil).Name, err)
+	}
+	if err := domain.MergeMethod(c.cfg.PullRequest.MergeMethod).Validate(); err != nil {
+		return clierror.AsFlagUsageError(flags.NewPRMergeMethodFlag(nil).Name, err)
+	}
+	if c.cfg.PullRequest.Draft && c.cfg.PullRequest.AutoMerge {
+		return clierror.AsFlagUsageErrorf(flags.NewPRAutoMergeFlag(nil).Name, "cannot be combined with %s", flags.NewPRDraftFlag(nil).Name)
+	}
+	// Auto-merge that has been enabled by hand is only disabled if auto-merge has been disabled explicitly.
+	autoMergeSet, err := cfg.IsSet(c.cfg.Project.MainConfigFileName, flags.NewPRAutoMergeFlag(nil).Name, ctx)
+	if err != nil {
+		return clierror.AsUsageError(err)
+	}
+	c.prAutoMerge = nil
+	if autoMergeSet {
+		c.prAutoMerge = &c.cfg.PullRequest.AutoMerge
+	}
+
 	switch c.dryRunFlag {
 	case "":
 		break
@@ -41,6 +61,41 @@ func (c *Command) validateUpdateCommand(ctx *cli.Context) error {
 	default:
 		return clierror.AsFlagUsageErrorf(flags.NewDryRunFlag(nil).Name, "unrecognized: %s", c.dryRunFlag)
 	}
+	clone := cloneOptions(c.cfg)
+	if err := clone.Validate(); err != nil {
+		flagName := flags.NewGitCloneFilterFlag(nil).Name
+		if clone.Depth < 0 {
+			flagName = flags.NewGitCloneDepthFlag(nil).Name
+		}
+		return clierror.AsFlagUsageError(flagName, err)
+	}
+	switch c.cfg.Git.Backend {
+	case "", "cli":
+		c.appService.gitStore = c.appService.repoStore
+	case "go-git":
+		if clone.Filter != domain.CloneFilterNone || clone.Sparse {
+			return clierror.AsFlagUsageErrorf(flags.NewGitBackendFlag(nil).Name, "go-git doesn't support partial and sparse clones")
+		}
+		c.appService.gitStore = c.appService.goGitStore
+	default:
+		return clierror.AsFlagUsageErrorf(flags.NewGitBackendFlag(nil).Name, "unrecognized: %s", c.cfg.Git.Backend)
+	}
+	if !c.cfg.Git.SkipCommit {
+		key := signingKey(c.cfg)
+		if err := key.Validate(); err != nil {
+			return clierror.AsFlagUsageError(flags.NewGitSigningFormatFlag(nil).Name, err)
+		}
+		if err := c.appService.gitStore.CheckSigningKey(key); err != nil {
+			return clierror.AsFlagUsageError(flags.NewGitSigningKeyFlag(nil).Name, err)
+		}
+		author, committer := identities(c.cfg)
+		if err := author.Validate(); err != nil {
+			return clierror.AsFlagUsageError(flags.NewGitAuthorEmailFlag(nil).Name, err)
+		}
+		if err := committer.Validate(); err != nil {
+			return clierror.AsFlagUsageError(flags.NewGitCommitterEmailFlag(nil).Name, err)
+		}
+	}
 	c.appService.console.Quiet = !c.cfg.Log.ShowLog
 	c.appService.engine.RootDir = c.appService.templateStore.RootDir
 	c.logFactory.SetLogLevel(c.cfg.Log.Level)
@@ -0,0 +1,46 @@
+package cfg
+
+import (
+	"fmt"
+
+	"github.com/ccremer/greposync/domain"
+)
+
+type BranchProtectionConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+// The rule for the default branch gets the given default branch name as pattern.
+func (BranchProtectionConverter) ConvertToEntity(config *BranchProtectionConfig, defaultBranch string) (domain.BranchProtectionSet, error) {
+	set := make(domain.BranchProtectionSet, 0)
+	if config == nil {
+		return set, nil
+	}
+	if config.DefaultBranch != nil {
+		if defaultBranch == "" {
+			return set, fmt.Errorf("invalid branch protection configuration: default branch is unknown")
+		}
+		rule := *config.DefaultBranch
+		rule.Pattern = defaultBranch
+		set = append(set, convertBranchProtectionRule(rule))
+	}
+	for _, rule := range config.Branches {
+		set = append(set, convertBranchProtectionRule(rule))
+	}
+	if err := set.Validate(); err != nil {
+		return set, fmt.Errorf("invalid branch protection configuration: %w", err)
+	}
+	return set, nil
+}
+
+func convertBranchProtectionRule(rule BranchProtectionRule) domain.BranchProtection {
+	return domain.BranchProtection{
+		Pattern:                  rule.Pattern,
+		RequiredStatusChecks:     rule.RequiredStatusChecks,
+		StrictStatusChecks:       rule.StrictStatusChecks,
+		RequiredApprovingReviews: rule.RequiredApprovingReviews,
+		DismissStaleReviews:      rule.DismissStaleReviews,
+		RequireCodeOwnerReviews:  rule.RequireCodeOwnerReviews,
+		RequireLinearHistory:     rule.RequireLinearHistory,
+		EnforceAdmins:            rule.EnforceAdmins,
+	}
+}
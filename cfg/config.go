@@ -41,3 +41,16 @@ func ParseConfig(configPath string, config *Configuration, ctx *cli.Context) err
 
 	return koanfInstance.Unmarshal("", &config)
 }
+
+// IsSet returns true if the given key is set in the config file, with an environment variable or with a CLI flag.
+// Unlike the values in Configuration, which are always populated with the flag defaults, this distinguishes explicit values from defaults.
+func IsSet(configPath string, key string, ctx *cli.Context) (bool, error) {
+	if ctx.IsSet(key) {
+		return true, nil
+	}
+	koanfInstance := koanf.New(".")
+	if err := koanfInstance.Load(file.Provider(configPath), yaml.Parser()); err != nil {
+		return false, err
+	}
+	return koanfInstance.Exists(key), nil
+}
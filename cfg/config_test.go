@@ -0,0 +1,47 @@
+package cfg
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestIsSet(t *testing.T) {
+	tests := map[string]struct {
+		givenConfig   string
+		givenArgs     []string
+		expectedIsSet bool
+	}{
+		"GivenNoValue_ThenExpectFalse": {
+			givenConfig:   "pr:\n  create: true\n",
+			expectedIsSet: false,
+		},
+		"GivenFalseInConfigFile_ThenExpectTrue": {
+			givenConfig:   "pr:\n  autoMerge: false\n",
+			expectedIsSet: true,
+		},
+		"GivenFlag_ThenExpectTrue": {
+			givenConfig:   "pr:\n  create: true\n",
+			givenArgs:     []string{"--pr.autoMerge=false"},
+			expectedIsSet: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "greposync.yml")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.givenConfig), 0644))
+			set := flag.NewFlagSet(name, flag.ContinueOnError)
+			set.Bool("pr.autoMerge", false, "")
+			require.NoError(t, set.Parse(tt.givenArgs))
+
+			result, err := IsSet(configPath, "pr.autoMerge", cli.NewContext(nil, set, nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIsSet, result)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package cfg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+const repositoryLabelsKey = "repositoryLabels"
+
+// WriteRepositoryLabels writes the given labels as `repositoryLabels` into the given YAML file.
+// Only `repositoryLabels` is replaced, other keys of the file are preserved including their order and comments.
+// If merge is true, the labels are merged into the existing `repositoryLabels` of the file with RepositoryLabelMap.Merge.
+// Otherwise, the existing `repositoryLabels` are replaced.
+// The file is created if it doesn't exist.
+func WriteRepositoryLabels(path string, labels RepositoryLabelMap, merge bool) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(content, doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top-level element is not a map", path)
+	}
+
+	valueNode := findMapValue(root, repositoryLabelsKey)
+	if merge && valueNode != nil {
+		existing, err := decodeRepositoryLabels(valueNode)
+		if err != nil {
+			return err
+		}
+		labels = existing.Merge(labels)
+	}
+	newValueNode, err := encodeRepositoryLabels(labels)
+	if err != nil {
+		return err
+	}
+	if valueNode != nil {
+		// Keep the comments attached to the existing node.
+		newValueNode.HeadComment, newValueNode.LineComment, newValueNode.FootComment = valueNode.HeadComment, valueNode.LineComment, valueNode.FootComment
+		*valueNode = *newValueNode
+	} else {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: repositoryLabelsKey}, newValueNode)
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// findMapValue returns the value node of the given key in the mapping node, or nil if the key doesn't exist.
+func findMapValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// decodeRepositoryLabels decodes the node with the JSON tags of RepositoryLabel, like koanf does when reading the config.
+func decodeRepositoryLabels(node *yaml.Node) (RepositoryLabelMap, error) {
+	raw, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	labels := RepositoryLabelMap{}
+	err = k8syaml.Unmarshal(raw, &labels)
+	return labels, err
+}
+
+// encodeRepositoryLabels encodes the labels with the JSON tags of RepositoryLabel into a node.
+func encodeRepositoryLabels(labels RepositoryLabelMap) (*yaml.Node, error) {
+	raw, err := k8syaml.Marshal(labels)
+	if err != nil {
+		return nil, err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(raw, doc); err != nil {
+		return nil, err
+	}
+	return doc.Content[0], nil
+}
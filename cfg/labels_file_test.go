@@ -0,0 +1,109 @@
+package cfg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRepositoryLabels(t *testing.T) {
+	labels := RepositoryLabelMap{
+		"bug": {Name: "bug", Description: "Something isn't working", Color: "d73a4a"},
+	}
+	tests := map[string]struct {
+		givenContent    string
+		givenMerge      bool
+		expectedContent string
+	}{
+		"GivenNoFile_ThenExpectNewFile": {
+			expectedContent: `repositoryLabels:
+  bug:
+    color: d73a4a
+    delete: false
+    description: Something isn't working
+    name: bug
+`,
+		},
+		"GivenExistingConfig_WhenReplacing_ThenExpectOtherKeysPreserved": {
+			givenContent: `# greposync config
+pr:
+  create: true # open pull requests
+repositoryLabels:
+  greposync:
+    name: greposync
+    color: ededed
+git:
+  commitMessage: Update from template
+`,
+			expectedContent: `# greposync config
+pr:
+  create: true # open pull requests
+repositoryLabels:
+  bug:
+    color: d73a4a
+    delete: false
+    description: Something isn't working
+    name: bug
+git:
+  commitMessage: Update from template
+`,
+		},
+		"GivenExistingLabels_WhenMerging_ThenExpectMergedLabels": {
+			givenContent: `# greposync config
+repositoryLabels:
+  kind-bug:
+    name: bug
+    color: ffffff
+    renamedFrom: [defect]
+  greposync:
+    name: greposync
+    color: ededed
+`,
+			givenMerge: true,
+			expectedContent: `# greposync config
+repositoryLabels:
+  greposync:
+    color: ededed
+    delete: false
+    description: ""
+    name: greposync
+  kind-bug:
+    color: d73a4a
+    delete: false
+    description: Something isn't working
+    name: bug
+    renamedFrom:
+      - defect
+`,
+		},
+		"GivenNoLabels_WhenMerging_ThenExpectLabelsAppended": {
+			givenContent: "pr:\n  create: true\n",
+			givenMerge:   true,
+			expectedContent: `pr:
+  create: true
+repositoryLabels:
+  bug:
+    color: d73a4a
+    delete: false
+    description: Something isn't working
+    name: bug
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "greposync.yml")
+			if tt.givenContent != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tt.givenContent), 0644))
+			}
+			err := WriteRepositoryLabels(path, labels, tt.givenMerge)
+			require.NoError(t, err)
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedContent, string(content))
+		})
+	}
+}
@@ -17,6 +17,12 @@ func (RepositoryLabelConverter) ConvertToEntity(label RepositoryLabel) (domain.L
 	entity := domain.Label{
 		Name:        label.Name,
 		Description: label.Description,
+		RenamedFrom: label.RenamedFrom,
+	}
+	for _, previous := range label.RenamedFrom {
+		if previous == "" || previous == label.Name {
+			return entity, fmt.Errorf("invalid label configuration: invalid renamedFrom for '%s': '%s'", label.Name, previous)
+		}
 	}
 	err := entity.SetColor(domain.Color(label.Color))
 	if err != nil {
@@ -24,3 +30,12 @@ func (RepositoryLabelConverter) ConvertToEntity(label RepositoryLabel) (domain.L
 	}
 	return entity, nil
 }
+
+// ConvertFromEntity converts the given object to another.
+func (RepositoryLabelConverter) ConvertFromEntity(label domain.Label) RepositoryLabel {
+	return RepositoryLabel{
+		Name:        label.Name,
+		Description: label.Description,
+		Color:       label.GetColor().String(),
+	}
+}
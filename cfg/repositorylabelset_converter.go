@@ -23,3 +23,13 @@ func (RepositoryLabelSetConverter) ConvertToEntity(labels []RepositoryLabel) (do
 	}
 	return converted, nil
 }
+
+// ConvertFromEntity converts the given object to another.
+// The keys are derived from the label names.
+func (RepositoryLabelSetConverter) ConvertFromEntity(labels domain.LabelSet) RepositoryLabelMap {
+	converted := make(RepositoryLabelMap, len(labels))
+	for _, label := range labels {
+		converted.add(RepositoryLabelConverter{}.ConvertFromEntity(label))
+	}
+	return converted
+}
@@ -0,0 +1,25 @@
+package cfg
+
+import (
+	"github.com/ccremer/greposync/domain"
+)
+
+type SettingsConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+func (SettingsConverter) ConvertToEntity(settings *SettingsConfig) domain.RepositorySettings {
+	if settings == nil {
+		return domain.RepositorySettings{}
+	}
+	return domain.RepositorySettings{
+		Description:         settings.Description,
+		Homepage:            settings.Homepage,
+		Topics:              settings.Topics,
+		AllowMergeCommit:    settings.AllowMergeCommit,
+		AllowSquashMerge:    settings.AllowSquashMerge,
+		AllowRebaseMerge:    settings.AllowRebaseMerge,
+		DeleteBranchOnMerge: settings.DeleteBranchOnMerge,
+		HasWiki:             settings.HasWiki,
+		HasProjects:         settings.HasProjects,
+	}
+}
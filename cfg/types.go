@@ -1,14 +1,26 @@
 package cfg
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 type (
 	// Configuration holds a strongly-typed tree of the main configuration
 	Configuration struct {
-		Project          *ProjectConfig     `json:"project" koanf:"project"`
-		Log              *LogConfig         `json:"log" koanf:"log"`
-		PullRequest      *PullRequestConfig `json:"pr" koanf:"pr"`
-		Template         *TemplateConfig    `json:"template" koanf:"template"`
-		Git              *GitConfig         `json:"git" koanf:"git"`
-		RepositoryLabels RepositoryLabelMap `json:"repositoryLabels" koanf:"repositoryLabels"`
+		Project          *ProjectConfig          `json:"project" koanf:"project"`
+		Log              *LogConfig              `json:"log" koanf:"log"`
+		PullRequest      *PullRequestConfig      `json:"pr" koanf:"pr"`
+		Template         *TemplateConfig         `json:"template" koanf:"template"`
+		Git              *GitConfig              `json:"git" koanf:"git"`
+		RepositoryLabels RepositoryLabelMap      `json:"repositoryLabels" koanf:"repositoryLabels"`
+		Labels           *LabelConfig            `json:"labels" koanf:"labels"`
+		Settings         *SettingsConfig         `json:"settings" koanf:"settings"`
+		BranchProtection *BranchProtectionConfig `json:"branchProtection" koanf:"branchProtection"`
+		GitHub           *GitHubConfig           `json:"github" koanf:"github"`
+		GitLab           *GitLabConfig           `json:"gitlab" koanf:"gitlab"`
+		Gitea            *GiteaConfig            `json:"gitea" koanf:"gitea"`
 	}
 	// ProjectConfig configures the main config settings
 	ProjectConfig struct {
@@ -46,10 +58,29 @@ type (
 		// TargetBranch is the target remote branch of the pull request.
 		// If left empty, it will target the default branch.
 		TargetBranch string `json:"targetBranch" koanf:"targetBranch"`
-		// Labels is an array of issue labels to apply when creating a pull request.
-		// Labels on existing pull requests are not updated.
+		// Labels is an array of issue labels to apply on pull requests.
+		// Labels on existing pull requests are updated according to LabelSyncMode.
 		// It is not validated whether the labels exist, the API may or may not create non-existing labels dynamically.
 		Labels []string `json:"labels" koanf:"labels"`
+		// LabelSyncMode determines how Labels are applied to existing pull requests, one of `merge`, `exact` or `remove`.
+		LabelSyncMode string `json:"labelSyncMode" koanf:"labelSyncMode"`
+		// Reviewers is an array of users or teams whose review is requested, in CODEOWNERS-style (`@user` or `@org/team`).
+		Reviewers []string `json:"reviewers" koanf:"reviewers"`
+		// TeamReviewers is an array of team slugs whose review is requested.
+		TeamReviewers []string `json:"teamReviewers" koanf:"teamReviewers"`
+		// Assignees is an array of users that are assigned to the pull request.
+		Assignees []string `json:"assignees" koanf:"assignees"`
+		// Draft opens new pull requests as draft.
+		// Existing draft pull requests are marked as ready for review if false.
+		Draft bool `json:"draft" koanf:"draft"`
+		// AutoMerge enables merging pull requests automatically once all requirements are met.
+		AutoMerge bool `json:"autoMerge" koanf:"autoMerge"`
+		// MergeMethod is the method used for auto-merge, one of `merge`, `squash` or `rebase`.
+		MergeMethod string `json:"mergeMethod" koanf:"mergeMethod"`
+		// CloseComment is the comment added to pull requests that are closed because the template doesn't produce changes anymore.
+		CloseComment string `json:"closeComment" koanf:"closeComment"`
+		// DeleteBranch deletes the remote commit branch if the template doesn't produce changes anymore.
+		DeleteBranch bool `json:"deleteBranch" koanf:"deleteBranch"`
 		// BodyTemplate is the description used in pull requests.
 		// Supports Go template with the `.Metadata` key.
 		// If this string is a relative path to an existing file in the greposync directory, the file is parsed as a Go template.
@@ -57,6 +88,10 @@ type (
 		BodyTemplate string `json:"bodyTemplate" koanf:"bodyTemplate"`
 		// Subject is the Pull Request title.
 		Subject string `json:"subject" koanf:"subject"`
+		// ForkOwner is the user or organization that owns the forks of the repositories.
+		// If set, the commit branch is pushed to a fork instead of origin, which is created if necessary.
+		// Pull requests are opened from the fork into the repository.
+		ForkOwner string `json:"forkOwner" koanf:"forkOwner"`
 	}
 	// RepositoryLabel is a struct describing a Label on a Git hosting service like GitHub.
 	RepositoryLabel struct {
@@ -68,8 +103,68 @@ type (
 		Color string `json:"color" koanf:"color"`
 		// Delete will remove this label.
 		Delete bool `json:"delete" koanf:"delete"`
+		// RenamedFrom is a list of previous label names.
+		// An existing label with one of these names is renamed instead of creating a new label.
+		RenamedFrom []string `json:"renamedFrom,omitempty" koanf:"renamedFrom"`
 	}
 	RepositoryLabelMap map[string]RepositoryLabel
+	// LabelConfig configures the `labels` command.
+	LabelConfig struct {
+		// Prune deletes remote labels that aren't configured in `repositoryLabels`.
+		Prune bool `json:"prune" koanf:"prune"`
+		// PruneKeep is a list of regex patterns.
+		// Labels whose name match one of the patterns are never pruned.
+		PruneKeep []string `json:"pruneKeep" koanf:"pruneKeep"`
+	}
+	// SettingsConfig configures the repository settings of the `settings` command.
+	// Settings that aren't set are not managed.
+	SettingsConfig struct {
+		// Description is the short description of the repository.
+		Description *string `json:"description,omitempty" koanf:"description"`
+		// Homepage is the URL of the project's website.
+		Homepage *string `json:"homepage,omitempty" koanf:"homepage"`
+		// Topics is an array of repository topics.
+		Topics []string `json:"topics,omitempty" koanf:"topics"`
+		// AllowMergeCommit allows merging pull requests with a merge commit.
+		AllowMergeCommit *bool `json:"allowMergeCommit,omitempty" koanf:"allowMergeCommit"`
+		// AllowSquashMerge allows squash-merging pull requests.
+		AllowSquashMerge *bool `json:"allowSquashMerge,omitempty" koanf:"allowSquashMerge"`
+		// AllowRebaseMerge allows rebase-merging pull requests.
+		AllowRebaseMerge *bool `json:"allowRebaseMerge,omitempty" koanf:"allowRebaseMerge"`
+		// DeleteBranchOnMerge deletes head branches automatically after pull requests are merged.
+		DeleteBranchOnMerge *bool `json:"deleteBranchOnMerge,omitempty" koanf:"deleteBranchOnMerge"`
+		// HasWiki enables the wiki.
+		HasWiki *bool `json:"hasWiki,omitempty" koanf:"hasWiki"`
+		// HasProjects enables projects.
+		HasProjects *bool `json:"hasProjects,omitempty" koanf:"hasProjects"`
+	}
+	// BranchProtectionConfig configures the protection rules of the `branch-protection` command.
+	BranchProtectionConfig struct {
+		// DefaultBranch is the protection rule for the default branch of each repository.
+		// The pattern is ignored.
+		DefaultBranch *BranchProtectionRule `json:"defaultBranch,omitempty" koanf:"defaultBranch"`
+		// Branches is a list of protection rules for other branches.
+		Branches []BranchProtectionRule `json:"branches,omitempty" koanf:"branches"`
+	}
+	// BranchProtectionRule is a struct describing the protection rule of one or more branches.
+	BranchProtectionRule struct {
+		// Pattern is the name of a branch or a pattern like `release/*` that matches multiple branches.
+		Pattern string `json:"pattern,omitempty" koanf:"pattern"`
+		// RequiredStatusChecks is a list of status checks that must pass before merging.
+		RequiredStatusChecks []string `json:"requiredStatusChecks,omitempty" koanf:"requiredStatusChecks"`
+		// StrictStatusChecks requires branches to be up-to-date before merging.
+		StrictStatusChecks bool `json:"strictStatusChecks" koanf:"strictStatusChecks"`
+		// RequiredApprovingReviews is the number of approving reviews that are required before merging.
+		RequiredApprovingReviews int `json:"requiredApprovingReviews" koanf:"requiredApprovingReviews"`
+		// DismissStaleReviews dismisses approving reviews when new commits are pushed.
+		DismissStaleReviews bool `json:"dismissStaleReviews" koanf:"dismissStaleReviews"`
+		// RequireCodeOwnerReviews requires an approving review of a code owner.
+		RequireCodeOwnerReviews bool `json:"requireCodeOwnerReviews" koanf:"requireCodeOwnerReviews"`
+		// RequireLinearHistory prevents merge commits from being pushed.
+		RequireLinearHistory bool `json:"requireLinearHistory" koanf:"requireLinearHistory"`
+		// EnforceAdmins applies the rule to administrators as well.
+		EnforceAdmins bool `json:"enforceAdmins" koanf:"enforceAdmins"`
+	}
 
 	// GitConfig configures a git repository.
 	// This structure is used to configuring the sync behaviour
@@ -87,6 +182,38 @@ type (
 		// It can contain newlines, for example to pass a long description.
 		CommitMessage string `json:"commitMessage" koanf:"commitMessage"`
 		CommitBranch  string `json:"commitBranch" koanf:"commitBranch"`
+		// Backend is the implementation that performs the Git operations, one of `cli` or `go-git`.
+		Backend string `json:"backend" koanf:"backend"`
+		// CloneDepth truncates the history of new clones to the given number of commits.
+		// If 0, the full history is cloned.
+		CloneDepth int `json:"cloneDepth" koanf:"cloneDepth"`
+		// CloneFilter is the partial clone filter of new clones, one of `blob:none` or `tree:0`.
+		// If empty, all objects are cloned.
+		CloneFilter string `json:"cloneFilter" koanf:"cloneFilter"`
+		// SparseCheckout limits the working tree to the root directory and the directories that contain files managed by the template.
+		SparseCheckout bool `json:"sparseCheckout" koanf:"sparseCheckout"`
+		// SigningFormat is the kind of key with which commits are signed, one of `gpg` or `ssh`.
+		// If empty, commits are not signed.
+		SigningFormat string `json:"signingFormat" koanf:"signingFormat"`
+		// SigningKey is the GPG key ID, or the path to the SSH key file.
+		SigningKey string `json:"signingKey" koanf:"signingKey"`
+		// Signoff adds a `Signed-off-by` trailer to the commit message.
+		Signoff bool `json:"signoff" koanf:"signoff"`
+		// AuthorName is the name of the commit author.
+		// If empty, `user.name` of the Git configuration is used.
+		AuthorName string `json:"authorName" koanf:"authorName"`
+		// AuthorEmail is the e-mail address of the commit author.
+		// If empty, `user.email` of the Git configuration is used.
+		AuthorEmail string `json:"authorEmail" koanf:"authorEmail"`
+		// CommitterName is the name of the committer.
+		// If empty, `user.name` of the Git configuration is used.
+		CommitterName string `json:"committerName" koanf:"committerName"`
+		// CommitterEmail is the e-mail address of the committer.
+		// If empty, `user.email` of the Git configuration is used.
+		CommitterEmail string `json:"committerEmail" koanf:"committerEmail"`
+		// Trailers is an array of `Key: value` trailers that are appended to the commit message.
+		// Each trailer is a Go template that has access to the same metadata as the pull request body.
+		Trailers []string `json:"trailers" koanf:"trailers"`
 		// DefaultBranch is the name of the default branch in origin.
 		DefaultBranch string `json:"defaultBranch"`
 		// Name is the git repository name without .git extension.
@@ -95,6 +222,56 @@ type (
 		// This is often a user or organization name in GitHub.com or GitLab.com.
 		Namespace string `json:"namespace"`
 	}
+	// GitHubConfig configures the GitHub remote provider.
+	GitHubConfig struct {
+		// Hosts is a list of GitHub Enterprise Server instances in addition to github.com.
+		// github.com is always supported, but it can be configured with a different token environment variable.
+		Hosts []*GitHubHostConfig `json:"hosts" koanf:"hosts"`
+	}
+	// GitHubHostConfig configures the API access of a GitHub instance.
+	GitHubHostConfig struct {
+		// Host is the host name of the Git URLs that are managed by this instance, for example `github.example.com`.
+		Host string `json:"host" koanf:"host"`
+		// APIURL is the base URL of the REST API.
+		// If empty, it defaults to `https://<host>/api/v3/`.
+		APIURL string `json:"apiUrl" koanf:"apiUrl"`
+		// UploadURL is the base URL for uploads.
+		// If empty, it defaults to `https://<host>/api/uploads/`.
+		UploadURL string `json:"uploadUrl" koanf:"uploadUrl"`
+		// TokenEnvVar is the name of the environment variable that contains the access token.
+		// If empty, it defaults to `GITHUB_TOKEN`.
+		TokenEnvVar string `json:"tokenEnvVar" koanf:"tokenEnvVar"`
+		// CAFile is the path to a PEM-encoded CA bundle that is trusted in addition to the system roots.
+		CAFile string `json:"caFile" koanf:"caFile"`
+		// App configures authentication as a GitHub App.
+		// If set, TokenEnvVar is ignored.
+		App *GitHubAppConfig `json:"app" koanf:"app"`
+	}
+	// GitHubAppConfig configures authentication as a GitHub App.
+	GitHubAppConfig struct {
+		// ID is the App ID of the GitHub App.
+		ID int64 `json:"id" koanf:"id"`
+		// InstallationID is the ID of the installation that is used for all repositories of the host.
+		// If 0, the installation is resolved for each repository owner.
+		InstallationID int64 `json:"installationId" koanf:"installationId"`
+		// PrivateKeyFile is the path to the PEM-encoded private key of the GitHub App.
+		PrivateKeyFile string `json:"privateKeyFile" koanf:"privateKeyFile"`
+	}
+	// GitLabConfig configures the GitLab remote provider.
+	// The access token is read from the `GITLAB_TOKEN` environment variable.
+	GitLabConfig struct {
+		// URL is the base URL of the GitLab instance, for example `https://gitlab.com`.
+		// Repositories whose host matches the host of this URL are managed using the GitLab API.
+		URL string `json:"url" koanf:"url"`
+	}
+	// GiteaConfig configures the Gitea remote provider, which also supports Forgejo.
+	// The access token is read from the `GITEA_TOKEN` environment variable.
+	GiteaConfig struct {
+		// URL is the base URL of the Gitea instance, for example `https://codeberg.org`.
+		// Repositories whose host matches the host of this URL are managed using the Gitea API.
+		// If empty, the Gitea provider is disabled.
+		URL string `json:"url" koanf:"url"`
+	}
 	// TemplateConfig configures template settings
 	TemplateConfig struct {
 		// RootDir is the path relative to the current workdir where the template files are located.
@@ -116,12 +293,23 @@ func NewDefaultConfig() *Configuration {
 			CommitMessage: "Update from greposync",
 		},
 		PullRequest: &PullRequestConfig{
-			BodyTemplate: `This Pull request updates this repository with changes from a greposync template repository.`,
-			Subject:      "Update from greposync",
+			BodyTemplate:  `This Pull request updates this repository with changes from a greposync template repository.`,
+			Subject:       "Update from greposync",
+			MergeMethod:   "merge",
+			LabelSyncMode: "merge",
+			CloseComment:  "This pull request is obsolete as the greposync template doesn't produce any changes in this repository anymore.",
 		},
 		Template: &TemplateConfig{
 			RootDir: "template",
 		},
+		Labels:           &LabelConfig{},
+		Settings:         &SettingsConfig{},
+		BranchProtection: &BranchProtectionConfig{},
+		GitHub:           &GitHubConfig{},
+		GitLab: &GitLabConfig{
+			URL: "https://gitlab.com",
+		},
+		Gitea: &GiteaConfig{},
 	}
 }
 
@@ -133,6 +321,56 @@ func (s RepositoryLabelMap) Values() []RepositoryLabel {
 	return list
 }
 
+// Merge returns a new RepositoryLabelMap that contains the labels of both maps.
+// A label in other replaces Name, Description and Color of the existing label with the same name, regardless of its key.
+// Other properties of existing labels are kept.
+// Labels that don't exist yet are added with a key derived from the label name.
+func (s RepositoryLabelMap) Merge(other RepositoryLabelMap) RepositoryLabelMap {
+	merged := make(RepositoryLabelMap, len(s)+len(other))
+	for key, label := range s {
+		merged[key] = label
+	}
+	for _, label := range other {
+		if key, found := merged.findKeyByName(label.Name); found {
+			existing := merged[key]
+			existing.Description = label.Description
+			existing.Color = label.Color
+			merged[key] = existing
+			continue
+		}
+		merged.add(label)
+	}
+	return merged
+}
+
+var invalidKeyCharsRegex = regexp.MustCompile("[^a-z0-9]+")
+
+// add adds the given label with a key derived from the label name.
+// If the key is already taken, a numeric suffix is appended.
+func (s RepositoryLabelMap) add(label RepositoryLabel) {
+	key := strings.Trim(invalidKeyCharsRegex.ReplaceAllString(strings.ToLower(label.Name), "-"), "-")
+	if key == "" {
+		key = "label"
+	}
+	unique := key
+	for i := 2; ; i++ {
+		if _, exists := s[unique]; !exists {
+			break
+		}
+		unique = fmt.Sprintf("%s-%d", key, i)
+	}
+	s[unique] = label
+}
+
+func (s RepositoryLabelMap) findKeyByName(name string) (string, bool) {
+	for key, label := range s {
+		if label.Name == name {
+			return key, true
+		}
+	}
+	return "", false
+}
+
 func (s RepositoryLabelMap) SelectDeletions() []RepositoryLabel {
 	list := make([]RepositoryLabel, 0)
 	for _, label := range s {
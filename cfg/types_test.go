@@ -0,0 +1,69 @@
+package cfg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryLabelMap_Merge(t *testing.T) {
+	tests := map[string]struct {
+		givenLabels    RepositoryLabelMap
+		givenOther     RepositoryLabelMap
+		expectedLabels RepositoryLabelMap
+	}{
+		"GivenEmptyMap_ThenExpectKeysDerivedFromName": {
+			givenLabels: RepositoryLabelMap{},
+			givenOther: RepositoryLabelMap{
+				"any": {Name: "Kind: Bug", Color: "d73a4a"},
+			},
+			expectedLabels: RepositoryLabelMap{
+				"kind-bug": {Name: "Kind: Bug", Color: "d73a4a"},
+			},
+		},
+		"GivenExistingLabel_ThenExpectUpdatedUnderExistingKey": {
+			givenLabels: RepositoryLabelMap{
+				"defect": {Name: "bug", Color: "ffffff", Delete: true, RenamedFrom: []string{"defect"}},
+			},
+			givenOther: RepositoryLabelMap{
+				"bug": {Name: "bug", Description: "Something isn't working", Color: "d73a4a"},
+			},
+			expectedLabels: RepositoryLabelMap{
+				"defect": {Name: "bug", Description: "Something isn't working", Color: "d73a4a", Delete: true, RenamedFrom: []string{"defect"}},
+			},
+		},
+		"GivenTakenKey_ThenExpectSuffix": {
+			givenLabels: RepositoryLabelMap{
+				"bug": {Name: "kind/bug"},
+			},
+			givenOther: RepositoryLabelMap{
+				"bug": {Name: "bug"},
+			},
+			expectedLabels: RepositoryLabelMap{
+				"bug":   {Name: "kind/bug"},
+				"bug-2": {Name: "bug"},
+			},
+		},
+		"GivenNameWithoutValidChars_ThenExpectFallbackKey": {
+			givenLabels: RepositoryLabelMap{},
+			givenOther: RepositoryLabelMap{
+				"any": {Name: "🐛"},
+			},
+			expectedLabels: RepositoryLabelMap{
+				"label": {Name: "🐛"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tt.givenLabels.Merge(tt.givenOther)
+			assert.Equal(t, tt.expectedLabels, result)
+		})
+	}
+}
+
+func TestRepositoryLabelMap_Merge_DoesNotModifyReceiver(t *testing.T) {
+	labels := RepositoryLabelMap{"bug": {Name: "bug", Color: "ffffff"}}
+	_ = labels.Merge(RepositoryLabelMap{"bug": {Name: "bug", Color: "d73a4a"}})
+	assert.Equal(t, "ffffff", labels["bug"].Color)
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxRequiredApprovingReviews is the highest number of approving reviews that a BranchProtection can require.
+const MaxRequiredApprovingReviews = 6
+
+// BranchProtection is a Value object describing the protection rule of one or more branches in a Git hosting service.
+type BranchProtection struct {
+	// Pattern is the name of a branch or a pattern like `release/*` that matches multiple branches.
+	Pattern string
+	// RequiredStatusChecks are the names of the status checks that must pass before merging.
+	// No status checks are required if empty.
+	// The order of the status checks is irrelevant.
+	RequiredStatusChecks []string
+	// StrictStatusChecks requires branches to be up-to-date with the protected branch before merging.
+	StrictStatusChecks bool
+	// RequiredApprovingReviews is the number of approving reviews that are required before merging.
+	// No reviews are required if 0.
+	RequiredApprovingReviews int
+	// DismissStaleReviews dismisses approving reviews when new commits are pushed.
+	DismissStaleReviews bool
+	// RequireCodeOwnerReviews requires an approving review of a code owner.
+	RequireCodeOwnerReviews bool
+	// RequireLinearHistory prevents merge commits from being pushed.
+	RequireLinearHistory bool
+	// EnforceAdmins applies the rules to administrators as well.
+	EnforceAdmins bool
+}
+
+// Validate returns ErrInvalidArgument if the pattern is empty or RequiredApprovingReviews is out of range.
+func (p BranchProtection) Validate() error {
+	if strings.TrimSpace(p.Pattern) == "" {
+		return fmt.Errorf("%w: branch protection pattern cannot be empty", ErrInvalidArgument)
+	}
+	if p.RequiredApprovingReviews < 0 || p.RequiredApprovingReviews > MaxRequiredApprovingReviews {
+		return fmt.Errorf("%w: required approving reviews for '%s' is not between 0 and %d", ErrInvalidArgument, p.Pattern, MaxRequiredApprovingReviews)
+	}
+	return nil
+}
+
+// IsEqualTo returns true if the other BranchProtection has the same pattern and rules.
+func (p BranchProtection) IsEqualTo(other BranchProtection) bool {
+	return p.Pattern == other.Pattern &&
+		hasSameElements(p.RequiredStatusChecks, other.RequiredStatusChecks) &&
+		p.StrictStatusChecks == other.StrictStatusChecks &&
+		p.RequiredApprovingReviews == other.RequiredApprovingReviews &&
+		p.DismissStaleReviews == other.DismissStaleReviews &&
+		p.RequireCodeOwnerReviews == other.RequireCodeOwnerReviews &&
+		p.RequireLinearHistory == other.RequireLinearHistory &&
+		p.EnforceAdmins == other.EnforceAdmins
+}
+
+// BranchProtectionSet is a set of BranchProtection.
+type BranchProtectionSet []BranchProtection
+
+// FindByPattern returns the BranchProtection with the given pattern, if there is one matching.
+func (s BranchProtectionSet) FindByPattern(pattern string) (BranchProtection, bool) {
+	for _, protection := range s {
+		if protection.Pattern == pattern {
+			return protection, true
+		}
+	}
+	return BranchProtection{}, false
+}
+
+// Validate returns an error if one of the BranchProtection is invalid or if two or more have the same pattern.
+func (s BranchProtectionSet) Validate() error {
+	patterns := make(map[string]bool, len(s))
+	for _, protection := range s {
+		if err := protection.Validate(); err != nil {
+			return err
+		}
+		if patterns[protection.Pattern] {
+			return fmt.Errorf("%w: branch protection pattern '%s' is duplicated", ErrInvalidArgument, protection.Pattern)
+		}
+		patterns[protection.Pattern] = true
+	}
+	return nil
+}
+
+// Diff returns a new BranchProtectionSet that contains only the elements that don't exist with equal rules in the given current set.
+// The result contains the rules that have to be created or updated in order to match this set.
+func (s BranchProtectionSet) Diff(current BranchProtectionSet) BranchProtectionSet {
+	changes := make(BranchProtectionSet, 0)
+	for _, protection := range s {
+		if existing, found := current.FindByPattern(protection.Pattern); found && existing.IsEqualTo(protection) {
+			continue
+		}
+		changes = append(changes, protection)
+	}
+	return changes
+}
@@ -0,0 +1,14 @@
+package domain
+
+// BranchProtectionStore provides methods to interact with branch protection rules on a Git hosting service.
+//
+// In Domain-Driven Design language, the term `Store` corresponds to `Repository`, but to avoid name clash it was named `Store`.
+type BranchProtectionStore interface {
+	// FetchBranchProtectionsForRepository retrieves the existing BranchProtectionSet of the given repository.
+	// If GitRepository.DefaultBranch is empty, it's set to the default branch of the remote repository.
+	FetchBranchProtectionsForRepository(repository *GitRepository) (BranchProtectionSet, error)
+	// EnsureBranchProtectionsForRepository creates or updates the given BranchProtectionSet in the given repository.
+	// A BranchProtection is updated if a remote rule with the same BranchProtection.Pattern exists.
+	// Remote rules that aren't in the given BranchProtectionSet are left untouched.
+	EnsureBranchProtectionsForRepository(repository *GitRepository, protections BranchProtectionSet) error
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchProtectionSet_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenSet      BranchProtectionSet
+		expectedError string
+	}{
+		"GivenValidSet_ThenExpectNoError": {
+			givenSet: BranchProtectionSet{{Pattern: "main", RequiredApprovingReviews: 1}, {Pattern: "release/*"}},
+		},
+		"GivenEmptyPattern_ThenExpectError": {
+			givenSet:      BranchProtectionSet{{Pattern: " "}},
+			expectedError: "invalid argument: branch protection pattern cannot be empty",
+		},
+		"GivenTooManyReviews_ThenExpectError": {
+			givenSet:      BranchProtectionSet{{Pattern: "main", RequiredApprovingReviews: 7}},
+			expectedError: "invalid argument: required approving reviews for 'main' is not between 0 and 6",
+		},
+		"GivenDuplicatedPattern_ThenExpectError": {
+			givenSet:      BranchProtectionSet{{Pattern: "main"}, {Pattern: "main", EnforceAdmins: true}},
+			expectedError: "invalid argument: branch protection pattern 'main' is duplicated",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.givenSet.Validate()
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBranchProtectionSet_Diff(t *testing.T) {
+	current := BranchProtectionSet{
+		{Pattern: "main", RequiredStatusChecks: []string{"build", "test"}, RequiredApprovingReviews: 1},
+		{Pattern: "unmanaged"},
+	}
+	tests := map[string]struct {
+		givenSet       BranchProtectionSet
+		expectedResult BranchProtectionSet
+	}{
+		"GivenEqualRules_WhenDifferentOrder_ThenExpectEmpty": {
+			givenSet:       BranchProtectionSet{{Pattern: "main", RequiredStatusChecks: []string{"test", "build"}, RequiredApprovingReviews: 1}},
+			expectedResult: BranchProtectionSet{},
+		},
+		"GivenChangedRule_ThenExpectRule": {
+			givenSet:       BranchProtectionSet{{Pattern: "main", RequiredStatusChecks: []string{"build"}, RequiredApprovingReviews: 1}},
+			expectedResult: BranchProtectionSet{{Pattern: "main", RequiredStatusChecks: []string{"build"}, RequiredApprovingReviews: 1}},
+		},
+		"GivenNewRule_ThenExpectRule": {
+			givenSet:       BranchProtectionSet{{Pattern: "release/*", RequireLinearHistory: true}},
+			expectedResult: BranchProtectionSet{{Pattern: "release/*", RequireLinearHistory: true}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tt.givenSet.Diff(current)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
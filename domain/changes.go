@@ -0,0 +1,82 @@
+package domain
+
+// FileChangeType describes how a file has been changed in a GitRepository.
+type FileChangeType string
+
+const (
+	// FileAdded is a new file.
+	FileAdded FileChangeType = "added"
+	// FileModified is an existing file with changed content or permissions.
+	FileModified FileChangeType = "modified"
+	// FileDeleted is a removed file.
+	FileDeleted FileChangeType = "deleted"
+)
+
+// FileChange is a Value object describing the change of a single file between two branches.
+type FileChange struct {
+	// Path is the relative path of the file within the repository.
+	Path Path
+	// Type is the kind of change.
+	Type FileChangeType
+	// Additions is the number of added lines.
+	// It is 0 for binary files.
+	Additions int
+	// Deletions is the number of deleted lines.
+	// It is 0 for binary files.
+	Deletions int
+}
+
+// ChangeSummary is a Value object that summarizes the changes that greposync made in a GitRepository.
+type ChangeSummary struct {
+	// Files are the files that differ between the target branch and the commit branch.
+	Files []FileChange
+	// RenderedFiles are the relative paths of the files that have been rendered from templates, regardless whether their content changed.
+	RenderedFiles []Path
+	// DeletedFiles are the relative paths of the files that have been deleted because they're unwanted.
+	DeletedFiles []Path
+}
+
+// AsValues returns the summary as Values for rendering.
+func (s ChangeSummary) AsValues() Values {
+	added := make([]string, 0)
+	modified := make([]string, 0)
+	deleted := make([]string, 0)
+	files := make([]Values, len(s.Files))
+	additions, deletions := 0, 0
+	for i, change := range s.Files {
+		switch change.Type {
+		case FileAdded:
+			added = append(added, change.Path.String())
+		case FileModified:
+			modified = append(modified, change.Path.String())
+		case FileDeleted:
+			deleted = append(deleted, change.Path.String())
+		}
+		files[i] = Values{
+			"Path":      change.Path.String(),
+			"Type":      string(change.Type),
+			"Additions": change.Additions,
+			"Deletions": change.Deletions,
+		}
+		additions += change.Additions
+		deletions += change.Deletions
+	}
+	return Values{
+		"Added":         added,
+		"Modified":      modified,
+		"Deleted":       deleted,
+		"Files":         files,
+		"Additions":     additions,
+		"Deletions":     deletions,
+		"RenderedFiles": pathsToStrings(s.RenderedFiles),
+		"DeletedFiles":  pathsToStrings(s.DeletedFiles),
+	}
+}
+
+func pathsToStrings(paths []Path) []string {
+	arr := make([]string, len(paths))
+	for i, p := range paths {
+		arr[i] = p.String()
+	}
+	return arr
+}
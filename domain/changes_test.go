@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSummary_AsValues(t *testing.T) {
+	tests := map[string]struct {
+		givenSummary   ChangeSummary
+		expectedValues Values
+	}{
+		"GivenEmptySummary_ThenExpectEmptyValues": {
+			givenSummary: ChangeSummary{},
+			expectedValues: Values{
+				"Added":         []string{},
+				"Modified":      []string{},
+				"Deleted":       []string{},
+				"Files":         []Values{},
+				"Additions":     0,
+				"Deletions":     0,
+				"RenderedFiles": []string{},
+				"DeletedFiles":  []string{},
+			},
+		},
+		"GivenChanges_ThenExpectFilesGroupedByType": {
+			givenSummary: ChangeSummary{
+				Files: []FileChange{
+					{Path: "README.md", Type: FileModified, Additions: 2, Deletions: 1},
+					{Path: ".github/workflows/test.yml", Type: FileAdded, Additions: 10},
+					{Path: "Makefile", Type: FileDeleted, Deletions: 5},
+				},
+				RenderedFiles: []Path{"README.md", ".github/workflows/test.yml"},
+				DeletedFiles:  []Path{"Makefile"},
+			},
+			expectedValues: Values{
+				"Added":    []string{".github/workflows/test.yml"},
+				"Modified": []string{"README.md"},
+				"Deleted":  []string{"Makefile"},
+				"Files": []Values{
+					{"Path": "README.md", "Type": "modified", "Additions": 2, "Deletions": 1},
+					{"Path": ".github/workflows/test.yml", "Type": "added", "Additions": 10, "Deletions": 0},
+					{"Path": "Makefile", "Type": "deleted", "Additions": 0, "Deletions": 5},
+				},
+				"Additions":     12,
+				"Deletions":     6,
+				"RenderedFiles": []string{"README.md", ".github/workflows/test.yml"},
+				"DeletedFiles":  []string{"Makefile"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedValues, tt.givenSummary.AsValues())
+		})
+	}
+}
@@ -16,8 +16,9 @@ type CleanupPipeline struct {
 	ValueStore    ValueStore
 	TemplateStore TemplateStore
 
-	files     []Path
-	templates []*Template
+	files        []Path
+	templates    []*Template
+	deletedFiles []Path
 
 	instrumentation CleanupServiceInstrumentation
 }
@@ -30,7 +31,10 @@ func NewCleanupService(
 	}
 }
 
-func (s *CleanupService) CleanupUnwantedFiles(pipe CleanupPipeline) error {
+// CleanupUnwantedFiles deletes the files that are configured to be deleted in the GitRepository.RootDir of the given CleanupPipeline.Repository.
+// Returns the paths of the deleted files relative to GitRepository.RootDir.
+// Files that don't exist are not included.
+func (s *CleanupService) CleanupUnwantedFiles(pipe CleanupPipeline) ([]Path, error) {
 	pipe.instrumentation = s.instrumentation.WithRepository(pipe.Repository)
 	result := pipeline.NewPipeline().WithSteps(
 		pipeline.NewStepFromFunc("preflight check", pipe.preFlightCheck),
@@ -38,7 +42,7 @@ func (s *CleanupService) CleanupUnwantedFiles(pipe CleanupPipeline) error {
 		pipeline.NewStepFromFunc("load files", pipe.loadFiles),
 		pipeline.NewStepFromFunc("delete files", pipe.deleteFiles),
 	).Run()
-	return result.Err()
+	return pipe.deletedFiles, result.Err()
 }
 
 func (p *CleanupPipeline) preFlightCheck(_ context.Context) error {
@@ -69,6 +73,7 @@ func (p *CleanupPipeline) deleteFiles(_ context.Context) error {
 			if err := os.Remove(absoluteFile.String()); hasFailed(err) {
 				return err
 			}
+			p.deletedFiles = append(p.deletedFiles, file)
 			p.instrumentation.DeletedFile(absoluteFile)
 		}
 	}
@@ -0,0 +1,50 @@
+package domain
+
+import "fmt"
+
+// CloneFilter is a partial clone filter that omits objects when cloning.
+// The omitted objects are downloaded on demand when they're needed.
+type CloneFilter string
+
+const (
+	// CloneFilterNone clones all objects.
+	CloneFilterNone CloneFilter = ""
+	// CloneFilterBlobless omits the file contents, except the ones that are checked out.
+	CloneFilterBlobless CloneFilter = "blob:none"
+	// CloneFilterTreeless omits the trees and file contents, except the ones that are checked out.
+	CloneFilterTreeless CloneFilter = "tree:0"
+)
+
+// Validate returns ErrInvalidArgument if the filter is not one of the known clone filters.
+func (f CloneFilter) Validate() error {
+	switch f {
+	case CloneFilterNone, CloneFilterBlobless, CloneFilterTreeless:
+		return nil
+	}
+	return fmt.Errorf("%w: clone filter '%s' is not one of [%s, %s]", ErrInvalidArgument, f, CloneFilterBlobless, CloneFilterTreeless)
+}
+
+// CloneOptions contains settings to influence the GitRepositoryStore.Clone action.
+type CloneOptions struct {
+	// Depth truncates the history to the given number of commits.
+	// The full history is cloned if 0.
+	Depth int
+	// Filter omits objects when cloning.
+	Filter CloneFilter
+	// Sparse checks out only the files in the root directory.
+	// Further files are checked out with a sparse checkout after cloning.
+	Sparse bool
+}
+
+// IsEmpty returns true if a complete clone is made.
+func (o CloneOptions) IsEmpty() bool {
+	return o.Depth == 0 && o.Filter == CloneFilterNone && !o.Sparse
+}
+
+// Validate returns ErrInvalidArgument if the Depth is negative or if the Filter is unknown.
+func (o CloneOptions) Validate() error {
+	if o.Depth < 0 {
+		return fmt.Errorf("%w: clone depth %d cannot be negative", ErrInvalidArgument, o.Depth)
+	}
+	return o.Filter.Validate()
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneOptions_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions  CloneOptions
+		expectedError string
+	}{
+		"GivenEmptyOptions_ThenExpectNoError": {
+			givenOptions: CloneOptions{},
+		},
+		"GivenShallowBloblessSparseClone_ThenExpectNoError": {
+			givenOptions: CloneOptions{Depth: 1, Filter: CloneFilterBlobless, Sparse: true},
+		},
+		"GivenTreelessClone_ThenExpectNoError": {
+			givenOptions: CloneOptions{Filter: CloneFilterTreeless},
+		},
+		"GivenNegativeDepth_ThenExpectError": {
+			givenOptions:  CloneOptions{Depth: -1},
+			expectedError: "invalid argument: clone depth -1 cannot be negative",
+		},
+		"GivenUnknownFilter_ThenExpectError": {
+			givenOptions:  CloneOptions{Filter: "blob:limit=1m"},
+			expectedError: "invalid argument: clone filter 'blob:limit=1m' is not one of [blob:none, tree:0]",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.givenOptions.Validate()
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+// CommitSettings contains repository-specific settings for commits.
+// A nil slice or pointer indicates that the setting isn't configured for the repository.
+type CommitSettings struct {
+	// Message is the commit message.
+	// It is a template that is rendered with the metadata of the repository.
+	Message *string
+	// Trailers are the `Key: value` trailers appended to the commit message.
+	// Each trailer is a template that is rendered with the metadata of the repository.
+	Trailers []string
+}
+
+// MergeWith returns new settings in which the settings that aren't configured are taken from the given defaults.
+func (s CommitSettings) MergeWith(defaults CommitSettings) CommitSettings {
+	merged := s
+	if merged.Message == nil {
+		merged.Message = defaults.Message
+	}
+	if merged.Trailers == nil {
+		merged.Trailers = defaults.Trailers
+	}
+	return merged
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitSettings_MergeWith(t *testing.T) {
+	message, override := "default", "override"
+	defaults := CommitSettings{
+		Message:  &message,
+		Trailers: []string{"Refs: TICKET-123"},
+	}
+	tests := map[string]struct {
+		givenSettings  CommitSettings
+		expectedResult CommitSettings
+	}{
+		"GivenNoOverrides_ThenExpectDefaults": {
+			givenSettings:  CommitSettings{},
+			expectedResult: defaults,
+		},
+		"GivenOverrides_ThenExpectOverrides": {
+			givenSettings: CommitSettings{
+				Message:  &override,
+				Trailers: []string{},
+			},
+			expectedResult: CommitSettings{
+				Message:  &override,
+				Trailers: []string{},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tt.givenSettings.MergeWith(defaults)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
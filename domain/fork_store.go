@@ -0,0 +1,11 @@
+package domain
+
+// ForkStore provides methods to interact with forks of a repository on a Git hosting service.
+//
+// In Domain-Driven Design language, the term `Store` corresponds to `Repository`, but to avoid name clash it was named `Store`.
+type ForkStore interface {
+	// EnsureForkForRepository ensures that a fork of the given repository exists under the given owner.
+	// The fork is created if it doesn't exist yet.
+	// On success, GitRepository.ForkURL is set to the remote URL of the fork.
+	EnsureForkForRepository(repository *GitRepository, owner string) error
+}
@@ -10,6 +10,9 @@ type GitRepository struct {
 	RootDir Path
 	// URL is the remote URL of origin.
 	URL *GitURL
+	// ForkURL is the remote URL of the fork that the CommitBranch is pushed to.
+	// If nil, the CommitBranch is pushed to origin.
+	ForkURL *GitURL
 	// PullRequest is the associated PullRequest for this repository in the remote Git hosting service.
 	PullRequest *PullRequest
 	// Labels contains the LabelSet that is present in the remote Git hosting service.
@@ -19,6 +22,9 @@ type GitRepository struct {
 	CommitBranch string
 	// DefaultBranch is the branch name of the remote default branch (usually `master` or `main`).
 	DefaultBranch string
+	// TargetBranch is the branch name into which the CommitBranch is merged.
+	// If empty, the DefaultBranch is used.
+	TargetBranch string
 }
 
 // NewGitRepository creates a new instance.
@@ -29,6 +35,14 @@ func NewGitRepository(u *GitURL, root Path) *GitRepository {
 	}
 }
 
+// GetTargetBranch returns TargetBranch, or DefaultBranch if TargetBranch is empty.
+func (r *GitRepository) GetTargetBranch() string {
+	if r.TargetBranch == "" {
+		return r.DefaultBranch
+	}
+	return r.TargetBranch
+}
+
 func (r *GitRepository) validateLabels(labels LabelSet) error {
 	return firstOf(labels.CheckForEmptyLabelNames(), labels.CheckForDuplicates())
 }
@@ -43,6 +57,11 @@ func (r *GitRepository) SetLabels(labels LabelSet) error {
 	return nil
 }
 
+// IsForked returns true if the CommitBranch is pushed to a fork instead of origin.
+func (r *GitRepository) IsForked() bool {
+	return r.ForkURL != nil
+}
+
 // AsValues returns the metadata as Values for rendering.
 func (r GitRepository) AsValues() Values {
 	return Values{
@@ -11,8 +11,9 @@ type GitRepositoryStore interface {
 
 	// Clone will download the given GitRepository to local filesystem.
 	// The location is specified in GitRepository.RootDir.
-	Clone(repository *GitRepository) error
+	Clone(repository *GitRepository, options CloneOptions) error
 	// Checkout checks out the GitRepository.CommitBranch.
+	// If the target branch differs from GitRepository.DefaultBranch, the CommitBranch is created from or reset to the remote CommitBranch if it exists, otherwise to the target branch.
 	Checkout(repository *GitRepository) error
 	// Fetch retrieves the objects and refs from remote.
 	Fetch(repository *GitRepository) error
@@ -28,9 +29,19 @@ type GitRepositoryStore interface {
 	// Diff returns a `patch`-compatible diff using given options.
 	// The diff may be empty without error.
 	Diff(repository *GitRepository, options DiffOptions) (string, error)
+	// DiffStat returns the files that have been changed in headBranch since it diverged from baseBranch.
+	// The result may be empty without error.
+	DiffStat(repository *GitRepository, baseBranch, headBranch string) ([]FileChange, error)
 
 	// Push updates remote refs.
 	Push(repository *GitRepository, options PushOptions) error
+	// DeleteRemoteBranch deletes the GitRepository.CommitBranch in remote.
+	// It's not an error if the branch doesn't exist in remote.
+	DeleteRemoteBranch(repository *GitRepository) error
+
+	// HasCommitsBetween returns true if headBranch contains commits that change files compared to baseBranch.
+	// Commits whose changes are already present in baseBranch, e.g. because they have been reverted or applied manually, are not considered.
+	HasCommitsBetween(repository *GitRepository, baseBranch, headBranch string) (bool, error)
 }
 
 // CommitOptions contains settings to influence the GitRepositoryStore.Commit action.
@@ -38,7 +49,20 @@ type CommitOptions struct {
 	// Message contains the commit message.
 	Message string
 	// Amend will edit the last commit instead of creating a new one.
+	// The last commit is only amended if it isn't in the target branch of the GitRepository.
 	Amend bool
+	// SigningKey is the key with which the commit is signed.
+	SigningKey SigningKey
+	// Signoff adds a `Signed-off-by` trailer to the commit message.
+	Signoff bool
+	// Author is the author of the commit.
+	// If empty, the author configured in Git is used.
+	Author Identity
+	// Committer is the committer of the commit.
+	// If empty, the committer configured in Git is used.
+	Committer Identity
+	// Trailers are additional `Key: value` trailers appended to the commit message, e.g. `Co-authored-by: Name <email>`.
+	Trailers []string
 }
 
 // PushOptions contains settings to influence the GitRepositoryStore.Push action.
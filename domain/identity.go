@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Identity is a Value object describing the author or committer of a commit.
+type Identity struct {
+	// Name is the display name.
+	Name string
+	// Email is the e-mail address.
+	Email string
+}
+
+// IsEmpty returns true if neither Name nor Email are set.
+func (i Identity) IsEmpty() bool {
+	return i.Name == "" && i.Email == ""
+}
+
+// String returns the identity in the `Name <Email>` format.
+func (i Identity) String() string {
+	return fmt.Sprintf("%s <%s>", i.Name, i.Email)
+}
+
+// Validate returns ErrInvalidArgument if only one of Name or Email is set, or if Email is not an e-mail address.
+// An empty identity is valid.
+func (i Identity) Validate() error {
+	if i.IsEmpty() {
+		return nil
+	}
+	if i.Name == "" || i.Email == "" {
+		return fmt.Errorf("%w: identity '%s' requires both name and email", ErrInvalidArgument, i)
+	}
+	if !strings.Contains(i.Email, "@") || strings.ContainsAny(i.Email, "<> ") {
+		return fmt.Errorf("%w: '%s' is not an email address", ErrInvalidArgument, i.Email)
+	}
+	return nil
+}
+
+// ValidateTrailer returns ErrInvalidArgument if the given trailer is not in the `Key: value` format.
+func ValidateTrailer(trailer string) error {
+	key, value, found := strings.Cut(trailer, ":")
+	if !found || strings.TrimSpace(value) == "" || key == "" || strings.ContainsAny(key, " \t\n") {
+		return fmt.Errorf("%w: trailer '%s' is not in the format 'Key: value'", ErrInvalidArgument, trailer)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentity_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenIdentity Identity
+		expectedError string
+	}{
+		"GivenEmptyIdentity_ThenExpectNoError": {
+			givenIdentity: Identity{},
+		},
+		"GivenNameAndEmail_ThenExpectNoError": {
+			givenIdentity: Identity{Name: "greposync", Email: "greposync@example.com"},
+		},
+		"GivenOnlyName_ThenExpectError": {
+			givenIdentity: Identity{Name: "greposync"},
+			expectedError: "invalid argument: identity 'greposync <>' requires both name and email",
+		},
+		"GivenInvalidEmail_ThenExpectError": {
+			givenIdentity: Identity{Name: "greposync", Email: "greposync"},
+			expectedError: "invalid argument: 'greposync' is not an email address",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.givenIdentity.Validate()
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateTrailer(t *testing.T) {
+	tests := map[string]struct {
+		givenTrailer  string
+		expectedError bool
+	}{
+		"GivenKeyAndValue_ThenExpectNoError": {
+			givenTrailer: "Refs: TICKET-123",
+		},
+		"GivenNoSeparator_ThenExpectError": {
+			givenTrailer:  "TICKET-123",
+			expectedError: true,
+		},
+		"GivenEmptyValue_ThenExpectError": {
+			givenTrailer:  "Refs: ",
+			expectedError: true,
+		},
+		"GivenKeyWithSpace_ThenExpectError": {
+			givenTrailer:  "Co authored by: greposync",
+			expectedError: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateTrailer(tt.givenTrailer)
+			if tt.expectedError {
+				assert.ErrorIs(t, err, ErrInvalidArgument)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
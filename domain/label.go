@@ -6,6 +6,9 @@ type Label struct {
 	Name string
 	// Description adds additional details to the label.
 	Description string
+	// RenamedFrom contains previous names of the label.
+	// A remote label matching one of these names is renamed to Name instead of creating a new label.
+	RenamedFrom []string
 	color       Color
 }
 
@@ -33,3 +36,13 @@ func (l Label) IsSameAs(label Label) bool {
 func (l Label) IsEqualTo(label Label) bool {
 	return l.Name == label.Name && l.Description == label.Description && l.color == label.color
 }
+
+// WasRenamedFrom returns true if the given name is one of Label.RenamedFrom.
+func (l Label) WasRenamedFrom(name string) bool {
+	for _, previous := range l.RenamedFrom {
+		if previous == name {
+			return true
+		}
+	}
+	return false
+}
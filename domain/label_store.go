@@ -10,7 +10,8 @@ type LabelStore interface {
 	// Labels that exist remotely, but not in the given LabelSet are ignored.
 	// Remote labels have to be updated when Label.GetColor or Label.Description are not matching.
 	//
-	// Renaming labels are currently not supported.
+	// If a Label doesn't exist remotely, but a remote label matches one of Label.RenamedFrom, then the remote label is renamed in place.
+	// If the Label already exists remotely under its new name, the old label is left untouched and the Label is updated instead.
 	EnsureLabelsForRepository(repository *GitRepository, labels LabelSet) error
 	// RemoveLabelsFromRepository remotely removes all labels in the given LabelSet.
 	// Only the Label.Name is relevant to determine label equality.
@@ -75,3 +75,30 @@ func TestLabel_IsEqualTo(t *testing.T) {
 		})
 	}
 }
+
+func TestLabel_WasRenamedFrom(t *testing.T) {
+	tests := map[string]struct {
+		givenLabel     Label
+		givenName      string
+		expectedResult bool
+	}{
+		"GivenNoPreviousNames_ThenExpectFalse": {
+			givenLabel: Label{Name: "kind/bug"},
+			givenName:  "bug",
+		},
+		"GivenMatchingPreviousName_ThenExpectTrue": {
+			givenLabel:     Label{Name: "kind/bug", RenamedFrom: []string{"defect", "bug"}},
+			givenName:      "bug",
+			expectedResult: true,
+		},
+		"GivenCurrentName_ThenExpectFalse": {
+			givenLabel: Label{Name: "kind/bug", RenamedFrom: []string{"bug"}},
+			givenName:  "kind/bug",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedResult, tt.givenLabel.WasRenamedFrom(tt.givenName))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"sort"
+)
+
+// LabelChangeType describes how a Label is changed in a Git hosting service.
+type LabelChangeType string
+
+const (
+	// LabelCreate creates a new label.
+	LabelCreate LabelChangeType = "create"
+	// LabelUpdate updates the color or description of an existing label.
+	LabelUpdate LabelChangeType = "update"
+	// LabelRename renames an existing label, see Label.RenamedFrom.
+	LabelRename LabelChangeType = "rename"
+	// LabelDelete deletes an existing label.
+	LabelDelete LabelChangeType = "delete"
+)
+
+// LabelChange is a Value object describing a change of a Label in a Git hosting service.
+type LabelChange struct {
+	// Type is the kind of change.
+	Type LabelChangeType
+	// Label is the desired Label.
+	// If Type is LabelDelete, it's the label to delete.
+	Label Label
+	// Current is the existing remote Label.
+	// It is empty if Type is LabelCreate.
+	Current Label
+}
+
+// LabelFieldChange describes the change of a single property of a Label.
+type LabelFieldChange struct {
+	// Field is the name of the property, one of `name`, `color` or `description`.
+	Field string
+	// Old is the current value.
+	Old string
+	// New is the desired value.
+	New string
+}
+
+// FieldChanges returns the properties that differ between LabelChange.Current and LabelChange.Label.
+// Returns an empty slice if Type is LabelDelete.
+func (c LabelChange) FieldChanges() []LabelFieldChange {
+	changes := make([]LabelFieldChange, 0)
+	if c.Type == LabelDelete {
+		return changes
+	}
+	if c.Current.Name != c.Label.Name {
+		changes = append(changes, LabelFieldChange{Field: "name", Old: c.Current.Name, New: c.Label.Name})
+	}
+	if c.Current.GetColor() != c.Label.GetColor() {
+		changes = append(changes, LabelFieldChange{Field: "color", Old: c.Current.GetColor().String(), New: c.Label.GetColor().String()})
+	}
+	if c.Current.Description != c.Label.Description {
+		changes = append(changes, LabelFieldChange{Field: "description", Old: c.Current.Description, New: c.Label.Description})
+	}
+	return changes
+}
+
+// PlanLabelChanges returns the changes that are needed to ensure the labels in toModify and to remove the labels in toDelete, given the current remote labels.
+// The changes follow the rules of LabelStore.EnsureLabelsForRepository and LabelStore.RemoveLabelsFromRepository.
+// Labels that don't need any change are not included, the same applies to labels to delete that don't exist remotely.
+// The result is sorted by label name.
+func PlanLabelChanges(current, toModify, toDelete LabelSet) []LabelChange {
+	changes := make([]LabelChange, 0)
+	for _, label := range toModify {
+		if existing, found := current.FindLabelByName(label.Name); found {
+			if !existing.IsEqualTo(label) {
+				changes = append(changes, LabelChange{Type: LabelUpdate, Label: label, Current: existing})
+			}
+			continue
+		}
+		if previous, found := current.findPreviousLabel(label); found {
+			changes = append(changes, LabelChange{Type: LabelRename, Label: label, Current: previous})
+			continue
+		}
+		changes = append(changes, LabelChange{Type: LabelCreate, Label: label})
+	}
+	for _, label := range toDelete {
+		if existing, found := current.FindLabelByName(label.Name); found {
+			changes = append(changes, LabelChange{Type: LabelDelete, Label: existing, Current: existing})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Label.Name < changes[j].Label.Name
+	})
+	return changes
+}
+
+func (s LabelSet) findPreviousLabel(label Label) (Label, bool) {
+	for _, previous := range label.RenamedFrom {
+		if existing, found := s.FindLabelByName(previous); found {
+			return existing, true
+		}
+	}
+	return Label{}, false
+}
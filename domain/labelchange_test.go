@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanLabelChanges(t *testing.T) {
+	current := LabelSet{
+		{Name: "bug", color: "#D73A4A"},
+		{Name: "greposync", color: "#EDEDED", Description: "old"},
+		{Name: "wontfix", color: "#FFFFFF"},
+	}
+	tests := map[string]struct {
+		givenToModify   LabelSet
+		givenToDelete   LabelSet
+		expectedChanges []LabelChange
+	}{
+		"GivenSameLabels_ThenExpectNoChanges": {
+			givenToModify:   LabelSet{{Name: "bug", color: "#D73A4A"}},
+			expectedChanges: []LabelChange{},
+		},
+		"GivenNewLabel_ThenExpectCreate": {
+			givenToModify: LabelSet{{Name: "feature", color: "#000000"}},
+			expectedChanges: []LabelChange{
+				{Type: LabelCreate, Label: Label{Name: "feature", color: "#000000"}},
+			},
+		},
+		"GivenChangedDescription_ThenExpectUpdate": {
+			givenToModify: LabelSet{{Name: "greposync", color: "#EDEDED", Description: "new"}},
+			expectedChanges: []LabelChange{
+				{Type: LabelUpdate, Label: Label{Name: "greposync", color: "#EDEDED", Description: "new"}, Current: current[1]},
+			},
+		},
+		"GivenRenamedLabel_ThenExpectRename": {
+			givenToModify: LabelSet{{Name: "kind/bug", color: "#D73A4A", RenamedFrom: []string{"bug"}}},
+			expectedChanges: []LabelChange{
+				{Type: LabelRename, Label: Label{Name: "kind/bug", color: "#D73A4A", RenamedFrom: []string{"bug"}}, Current: current[0]},
+			},
+		},
+		"GivenLabelsToDelete_ThenExpectDeleteOnlyExisting": {
+			givenToDelete: FromStringSlice([]string{"wontfix", "nonexisting"}),
+			expectedChanges: []LabelChange{
+				{Type: LabelDelete, Label: current[2], Current: current[2]},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := PlanLabelChanges(current, tt.givenToModify, tt.givenToDelete)
+			assert.Equal(t, tt.expectedChanges, result)
+		})
+	}
+}
+
+func TestLabelChange_FieldChanges(t *testing.T) {
+	tests := map[string]struct {
+		givenChange     LabelChange
+		expectedChanges []LabelFieldChange
+	}{
+		"GivenDelete_ThenExpectEmpty": {
+			givenChange:     LabelChange{Type: LabelDelete, Label: Label{Name: "bug"}, Current: Label{Name: "bug"}},
+			expectedChanges: []LabelFieldChange{},
+		},
+		"GivenRename_ThenExpectNameAndColor": {
+			givenChange: LabelChange{Type: LabelRename, Label: Label{Name: "kind/bug", color: "#000000"}, Current: Label{Name: "bug", color: "#FFFFFF"}},
+			expectedChanges: []LabelFieldChange{
+				{Field: "name", Old: "bug", New: "kind/bug"},
+				{Field: "color", Old: "#FFFFFF", New: "#000000"},
+			},
+		},
+		"GivenCreate_ThenExpectAllFields": {
+			givenChange: LabelChange{Type: LabelCreate, Label: Label{Name: "bug", color: "#000000", Description: "desc"}},
+			expectedChanges: []LabelFieldChange{
+				{Field: "name", New: "bug"},
+				{Field: "color", New: "#000000"},
+				{Field: "description", New: "desc"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedChanges, tt.givenChange.FieldChanges())
+		})
+	}
+}
@@ -102,6 +102,44 @@ func (s LabelSet) Without(other LabelSet) LabelSet {
 	return newSet
 }
 
+// WithoutRenamed returns a new LabelSet that doesn't contain the labels whose name is one of Label.RenamedFrom of a Label in other.
+//
+// No validation checks are performed.
+// The original order is preserved.
+func (s LabelSet) WithoutRenamed(other LabelSet) LabelSet {
+	newSet := make(LabelSet, 0, len(s))
+	for i := range s {
+		label := s[i]
+		if !other.hasRenamedFrom(label.Name) {
+			newSet = append(newSet, label)
+		}
+	}
+	return newSet
+}
+
+func (s LabelSet) hasRenamedFrom(name string) bool {
+	for _, label := range s {
+		if label.WasRenamedFrom(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Equals returns true if both sets contain labels with the same names, regardless of the order.
+// Properties other than the name are not compared.
+func (s LabelSet) Equals(other LabelSet) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for _, label := range s {
+		if _, found := other.FindLabelByName(label.Name); !found {
+			return false
+		}
+	}
+	return true
+}
+
 // String implements fmt.Stringer.
 func (s LabelSet) String() string {
 	if s == nil || len(s) == 0 {
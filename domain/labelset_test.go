@@ -190,3 +190,66 @@ func TestLabelSet_String(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelSet_Equals(t *testing.T) {
+	tests := map[string]struct {
+		givenSet       LabelSet
+		givenOther     LabelSet
+		expectedResult bool
+	}{
+		"GivenNilAndEmptySet_ThenExpectTrue": {
+			givenSet:       nil,
+			givenOther:     LabelSet{},
+			expectedResult: true,
+		},
+		"GivenSameNames_WhenDifferentOrder_ThenExpectTrue": {
+			givenSet:       FromStringSlice([]string{"foo", "bar"}),
+			givenOther:     FromStringSlice([]string{"bar", "foo"}),
+			expectedResult: true,
+		},
+		"GivenSubset_ThenExpectFalse": {
+			givenSet:       FromStringSlice([]string{"foo"}),
+			givenOther:     FromStringSlice([]string{"foo", "bar"}),
+			expectedResult: false,
+		},
+		"GivenDifferentNames_ThenExpectFalse": {
+			givenSet:       FromStringSlice([]string{"foo"}),
+			givenOther:     FromStringSlice([]string{"bar"}),
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedResult, tt.givenSet.Equals(tt.givenOther))
+		})
+	}
+}
+
+func TestLabelSet_WithoutRenamed(t *testing.T) {
+	tests := map[string]struct {
+		givenSet       LabelSet
+		givenOther     LabelSet
+		expectedResult LabelSet
+	}{
+		"GivenNilOther_ThenExpectSameLabels": {
+			givenSet:       FromStringSlice([]string{"bug", "feature"}),
+			givenOther:     nil,
+			expectedResult: FromStringSlice([]string{"bug", "feature"}),
+		},
+		"GivenRenamedLabel_ThenExpectOldNameRemoved": {
+			givenSet:       FromStringSlice([]string{"bug", "feature"}),
+			givenOther:     LabelSet{{Name: "kind/bug", RenamedFrom: []string{"defect", "bug"}}},
+			expectedResult: FromStringSlice([]string{"feature"}),
+		},
+		"GivenNoMatchingPreviousName_ThenExpectSameLabels": {
+			givenSet:       FromStringSlice([]string{"feature"}),
+			givenOther:     LabelSet{{Name: "kind/bug", RenamedFrom: []string{"bug"}}},
+			expectedResult: FromStringSlice([]string{"feature"}),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedResult, tt.givenSet.WithoutRenamed(tt.givenOther))
+		})
+	}
+}
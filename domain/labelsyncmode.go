@@ -0,0 +1,47 @@
+package domain
+
+import "fmt"
+
+// LabelSyncMode determines how the configured labels are applied to the labels of an existing PullRequest.
+type LabelSyncMode string
+
+const (
+	// LabelSyncModeMerge adds the configured labels and keeps the other labels.
+	LabelSyncModeMerge LabelSyncMode = "merge"
+	// LabelSyncModeExact replaces the labels with the configured labels.
+	LabelSyncModeExact LabelSyncMode = "exact"
+	// LabelSyncModeRemove removes the configured labels and keeps the other labels.
+	LabelSyncModeRemove LabelSyncMode = "remove"
+)
+
+// Validate returns ErrInvalidArgument if the mode is not one of the known label sync modes.
+func (m LabelSyncMode) Validate() error {
+	switch m {
+	case LabelSyncModeMerge, LabelSyncModeExact, LabelSyncModeRemove:
+		return nil
+	}
+	return fmt.Errorf("%w: label sync mode '%s' is not one of [%s, %s, %s]", ErrInvalidArgument, m, LabelSyncModeMerge, LabelSyncModeExact, LabelSyncModeRemove)
+}
+
+// Apply returns a new LabelSet that results from applying the configured labels to the current labels.
+// The result is never nil.
+func (m LabelSyncMode) Apply(current, configured LabelSet) LabelSet {
+	if current == nil {
+		current = LabelSet{}
+	}
+	if configured == nil {
+		configured = LabelSet{}
+	}
+	switch m {
+	case LabelSyncModeExact:
+		return configured.Merge(LabelSet{})
+	case LabelSyncModeRemove:
+		return current.Without(configured)
+	}
+	return current.Merge(configured)
+}
+
+// String returns the mode as string.
+func (m LabelSyncMode) String() string {
+	return string(m)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelSyncMode_Apply(t *testing.T) {
+	current := FromStringSlice([]string{"needs-rebase", "dependency"})
+	configured := FromStringSlice([]string{"dependency", "greposync"})
+	tests := map[string]struct {
+		givenMode      LabelSyncMode
+		givenCurrent   LabelSet
+		expectedLabels LabelSet
+	}{
+		"GivenMerge_ThenExpectConfiguredLabelsAdded": {
+			givenMode:      LabelSyncModeMerge,
+			givenCurrent:   current,
+			expectedLabels: FromStringSlice([]string{"dependency", "greposync", "needs-rebase"}),
+		},
+		"GivenExact_ThenExpectConfiguredLabels": {
+			givenMode:      LabelSyncModeExact,
+			givenCurrent:   current,
+			expectedLabels: FromStringSlice([]string{"dependency", "greposync"}),
+		},
+		"GivenRemove_ThenExpectConfiguredLabelsRemoved": {
+			givenMode:      LabelSyncModeRemove,
+			givenCurrent:   current,
+			expectedLabels: FromStringSlice([]string{"needs-rebase"}),
+		},
+		"GivenRemove_WhenNoCurrentLabels_ThenExpectEmptySet": {
+			givenMode:      LabelSyncModeRemove,
+			givenCurrent:   nil,
+			expectedLabels: LabelSet{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tt.givenMode.Apply(tt.givenCurrent, configured)
+			assert.Equal(t, tt.expectedLabels, result)
+		})
+	}
+}
+
+func TestLabelSyncMode_Validate(t *testing.T) {
+	assert.NoError(t, LabelSyncModeExact.Validate())
+	assert.EqualError(t, LabelSyncMode("replace").Validate(), "invalid argument: label sync mode 'replace' is not one of [merge, exact, remove]")
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "fmt"
+
+// MergeMethod is the method with which a PullRequest is merged into the base branch.
+type MergeMethod string
+
+const (
+	// MergeMethodMerge merges the commits of the PullRequest with a merge commit.
+	MergeMethodMerge MergeMethod = "merge"
+	// MergeMethodSquash squashes the commits of the PullRequest into a single commit.
+	MergeMethodSquash MergeMethod = "squash"
+	// MergeMethodRebase rebases the commits of the PullRequest onto the base branch.
+	MergeMethodRebase MergeMethod = "rebase"
+)
+
+// Validate returns ErrInvalidArgument if the method is not one of the known merge methods.
+func (m MergeMethod) Validate() error {
+	switch m {
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+		return nil
+	}
+	return fmt.Errorf("%w: merge method '%s' is not one of [%s, %s, %s]", ErrInvalidArgument, m, MergeMethodMerge, MergeMethodSquash, MergeMethodRebase)
+}
+
+// String returns the merge method as string.
+func (m MergeMethod) String() string {
+	return string(m)
+}
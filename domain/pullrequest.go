@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 )
 
 // PullRequest is a model that represents a pull request in a remote Git hosting service.
@@ -16,6 +17,13 @@ type PullRequest struct {
 	BaseBranch string
 
 	labels LabelSet
+
+	reviewers     []string
+	teamReviewers []string
+	assignees     []string
+
+	draft     bool
+	autoMerge MergeMethod
 }
 
 // NewPullRequest returns a new instance.
@@ -95,3 +103,102 @@ func (pr *PullRequest) AttachLabels(labels LabelSet) error {
 	pr.labels = labels
 	return nil
 }
+
+// SyncLabels applies the given labels to the LabelSet of this PR according to the given LabelSyncMode.
+// There cannot be duplicates or labels with no name.
+func (pr *PullRequest) SyncLabels(labels LabelSet, mode LabelSyncMode) error {
+	if err := firstOf(mode.Validate(), pr.validateLabels(labels)); hasFailed(err) {
+		return err
+	}
+	return pr.AttachLabels(mode.Apply(pr.labels, labels))
+}
+
+// RequestReviews sets the users and teams whose review is requested.
+// Teams are identified by their slug.
+// There cannot be empty names.
+func (pr *PullRequest) RequestReviews(reviewers, teamReviewers []string) error {
+	if err := firstOf(pr.validateNames("reviewer", reviewers), pr.validateNames("team reviewer", teamReviewers)); hasFailed(err) {
+		return err
+	}
+	pr.reviewers = reviewers
+	pr.teamReviewers = teamReviewers
+	return nil
+}
+
+// GetReviewers returns the user logins whose review is requested.
+func (pr *PullRequest) GetReviewers() []string {
+	return pr.reviewers
+}
+
+// GetTeamReviewers returns the team slugs whose review is requested.
+func (pr *PullRequest) GetTeamReviewers() []string {
+	return pr.teamReviewers
+}
+
+// AssignTo sets the users that are assigned to this PR.
+// There cannot be empty names.
+func (pr *PullRequest) AssignTo(assignees []string) error {
+	if err := pr.validateNames("assignee", assignees); hasFailed(err) {
+		return err
+	}
+	pr.assignees = assignees
+	return nil
+}
+
+// GetAssignees returns the user logins that are assigned to this PR.
+func (pr *PullRequest) GetAssignees() []string {
+	return pr.assignees
+}
+
+func (pr *PullRequest) validateNames(kind string, names []string) error {
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: %s cannot be empty", ErrInvalidArgument, kind)
+		}
+	}
+	return nil
+}
+
+// SetDraft marks this PR as draft or as ready for review.
+// A PR cannot be a draft if auto-merge is enabled.
+func (pr *PullRequest) SetDraft(draft bool) error {
+	if draft && pr.IsAutoMergeEnabled() {
+		return fmt.Errorf("%w: a draft PR cannot be merged automatically", ErrInvalidArgument)
+	}
+	pr.draft = draft
+	return nil
+}
+
+// IsDraft returns true if this PR is a draft.
+func (pr *PullRequest) IsDraft() bool {
+	return pr.draft
+}
+
+// EnableAutoMerge enables merging this PR automatically with the given MergeMethod once all requirements are met.
+// Auto-merge cannot be enabled on draft PRs.
+func (pr *PullRequest) EnableAutoMerge(method MergeMethod) error {
+	if err := method.Validate(); hasFailed(err) {
+		return err
+	}
+	if pr.IsDraft() {
+		return fmt.Errorf("%w: a draft PR cannot be merged automatically", ErrInvalidArgument)
+	}
+	pr.autoMerge = method
+	return nil
+}
+
+// DisableAutoMerge disables merging this PR automatically.
+func (pr *PullRequest) DisableAutoMerge() {
+	pr.autoMerge = ""
+}
+
+// IsAutoMergeEnabled returns true if this PR is merged automatically.
+func (pr *PullRequest) IsAutoMergeEnabled() bool {
+	return pr.autoMerge != ""
+}
+
+// GetAutoMergeMethod returns the MergeMethod with which this PR is merged automatically.
+// It returns an empty string if auto-merge is disabled.
+func (pr *PullRequest) GetAutoMergeMethod() MergeMethod {
+	return pr.autoMerge
+}
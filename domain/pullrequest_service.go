@@ -20,12 +20,15 @@ type PullRequestServiceContext struct {
 	Title          string
 	TargetBranch   string
 	Labels         LabelSet
+	// Changes are exposed to the Body and Title templates.
+	Changes ChangeSummary
 }
 
 func (prs *PullRequestService) NewPullRequestForRepository(prsCtx PullRequestServiceContext) error {
 	values := Values{
 		MetadataValueKey: Values{
 			RepositoryValueKey: prsCtx.Repository.AsValues(),
+			ChangesValueKey:    prsCtx.Changes.AsValues(),
 		},
 	}
 
@@ -0,0 +1,166 @@
+package domain
+
+import "strings"
+
+// PullRequestSettings contains repository-specific settings for pull requests.
+// A nil slice or pointer indicates that the setting isn't configured for the repository.
+type PullRequestSettings struct {
+	// Subject is the title of new pull requests.
+	// It is a template that is rendered with the metadata of the repository.
+	Subject *string
+	// BodyTemplate is the description of new pull requests.
+	// It is a template that is rendered with the metadata of the repository.
+	BodyTemplate *string
+	// Labels are the names of the labels that are applied to pull requests.
+	Labels []string
+	// TargetBranch is the branch into which the pull request is merged.
+	// An empty string indicates that the setting isn't configured for the repository.
+	TargetBranch string
+	// Reviewers are the users or teams whose review is requested, in CODEOWNERS-style (`@user` or `@org/team`).
+	Reviewers []string
+	// TeamReviewers are the team slugs whose review is requested.
+	TeamReviewers []string
+	// Assignees are the users that are assigned to the pull request.
+	Assignees []string
+	// Draft determines whether new pull requests are opened as draft.
+	// Existing draft pull requests are marked as ready for review if false.
+	Draft *bool
+	// AutoMerge determines whether pull requests are merged automatically once all requirements are met.
+	AutoMerge *bool
+	// MergeMethod is the method used for auto-merge.
+	// An empty string indicates that the setting isn't configured for the repository.
+	MergeMethod MergeMethod
+}
+
+// MergeWith returns new settings in which the settings that aren't configured are taken from the given defaults.
+func (s PullRequestSettings) MergeWith(defaults PullRequestSettings) PullRequestSettings {
+	merged := s
+	if merged.Subject == nil {
+		merged.Subject = defaults.Subject
+	}
+	if merged.BodyTemplate == nil {
+		merged.BodyTemplate = defaults.BodyTemplate
+	}
+	if merged.Labels == nil {
+		merged.Labels = defaults.Labels
+	}
+	if merged.TargetBranch == "" {
+		merged.TargetBranch = defaults.TargetBranch
+	}
+	if merged.Reviewers == nil {
+		merged.Reviewers = defaults.Reviewers
+	}
+	if merged.TeamReviewers == nil {
+		merged.TeamReviewers = defaults.TeamReviewers
+	}
+	if merged.Assignees == nil {
+		merged.Assignees = defaults.Assignees
+	}
+	if merged.Draft == nil {
+		merged.Draft = defaults.Draft
+	}
+	if merged.AutoMerge == nil {
+		merged.AutoMerge = defaults.AutoMerge
+	}
+	if merged.MergeMethod == "" {
+		merged.MergeMethod = defaults.MergeMethod
+	}
+	return merged
+}
+
+// GetTargetBranch returns TargetBranch, or the given default branch if TargetBranch isn't configured.
+func (s PullRequestSettings) GetTargetBranch(defaultBranch string) string {
+	if s.TargetBranch == "" {
+		return defaultBranch
+	}
+	return s.TargetBranch
+}
+
+// ApplyTo requests the reviews and assigns the users on the given PullRequest.
+// Subject, BodyTemplate, Labels and TargetBranch are not applied, as they need to be rendered or synced first.
+// Teams in Reviewers are added to the team reviewers.
+// If configured, it also changes the draft and auto-merge state, using MergeMethodMerge if MergeMethod is empty.
+func (s PullRequestSettings) ApplyTo(pr *PullRequest) error {
+	if err := s.applyMergeSettings(pr); hasFailed(err) {
+		return err
+	}
+	users, teams := SplitOwners(s.Reviewers)
+	for _, team := range s.TeamReviewers {
+		if slug := teamSlug(team); slug != "" {
+			teams = append(teams, slug)
+		}
+	}
+	assignees := make([]string, 0, len(s.Assignees))
+	for _, assignee := range s.Assignees {
+		if login := strings.TrimPrefix(strings.TrimSpace(assignee), "@"); login != "" {
+			assignees = append(assignees, login)
+		}
+	}
+	return firstOf(pr.RequestReviews(unique(users), unique(teams)), pr.AssignTo(unique(assignees)))
+}
+
+func (s PullRequestSettings) applyMergeSettings(pr *PullRequest) error {
+	if s.Draft != nil {
+		if *s.Draft {
+			pr.DisableAutoMerge()
+		}
+		if err := pr.SetDraft(*s.Draft); hasFailed(err) {
+			return err
+		}
+	}
+	if s.AutoMerge == nil {
+		return nil
+	}
+	if !*s.AutoMerge {
+		pr.DisableAutoMerge()
+		return nil
+	}
+	method := s.MergeMethod
+	if method == "" {
+		method = MergeMethodMerge
+	}
+	return pr.EnableAutoMerge(method)
+}
+
+// SplitOwners splits CODEOWNERS-style owners into user logins and team slugs.
+// `@user` and `user` are users, `@org/team` and `org/team` are teams, for which only the team slug is returned.
+// Empty entries are ignored.
+func SplitOwners(owners []string) (users []string, teams []string) {
+	users = make([]string, 0)
+	teams = make([]string, 0)
+	for _, owner := range owners {
+		name := strings.TrimPrefix(strings.TrimSpace(owner), "@")
+		if name == "" {
+			continue
+		}
+		if strings.Contains(name, "/") {
+			teams = append(teams, teamSlug(name))
+			continue
+		}
+		users = append(users, name)
+	}
+	return users, teams
+}
+
+// teamSlug returns the team slug of `@org/team`, `org/team` or `team`.
+func teamSlug(team string) string {
+	name := strings.TrimPrefix(strings.TrimSpace(team), "@")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// unique returns the given names without duplicates, preserving the order.
+func unique(names []string) []string {
+	result := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitOwners(t *testing.T) {
+	tests := map[string]struct {
+		givenOwners   []string
+		expectedUsers []string
+		expectedTeams []string
+	}{
+		"GivenNil_ThenExpectEmpty": {
+			givenOwners:   nil,
+			expectedUsers: []string{},
+			expectedTeams: []string{},
+		},
+		"GivenUsers_WhenWithAndWithoutPrefix_ThenExpectLogins": {
+			givenOwners:   []string{"@ccremer", "octocat"},
+			expectedUsers: []string{"ccremer", "octocat"},
+			expectedTeams: []string{},
+		},
+		"GivenTeams_ThenExpectSlugs": {
+			givenOwners:   []string{"@org/maintainers", "org/reviewers"},
+			expectedUsers: []string{},
+			expectedTeams: []string{"maintainers", "reviewers"},
+		},
+		"GivenEmptyEntries_ThenIgnore": {
+			givenOwners:   []string{"", " @ "},
+			expectedUsers: []string{},
+			expectedTeams: []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			users, teams := SplitOwners(tt.givenOwners)
+			assert.Equal(t, tt.expectedUsers, users)
+			assert.Equal(t, tt.expectedTeams, teams)
+		})
+	}
+}
+
+func TestPullRequestSettings_MergeWith(t *testing.T) {
+	subject, override := "default subject", "override"
+	defaults := PullRequestSettings{
+		Subject:       &subject,
+		BodyTemplate:  &subject,
+		Labels:        []string{"default-label"},
+		TargetBranch:  "main",
+		Reviewers:     []string{"@default"},
+		TeamReviewers: []string{"default-team"},
+		Assignees:     []string{"default-assignee"},
+	}
+	tests := map[string]struct {
+		givenSettings  PullRequestSettings
+		expectedResult PullRequestSettings
+	}{
+		"GivenNoOverrides_ThenExpectDefaults": {
+			givenSettings:  PullRequestSettings{},
+			expectedResult: defaults,
+		},
+		"GivenOverrides_ThenExpectOverrides": {
+			givenSettings: PullRequestSettings{
+				Subject:      &override,
+				Labels:       []string{},
+				TargetBranch: "develop",
+				Reviewers:    []string{"@override"},
+				Assignees:    []string{},
+			},
+			expectedResult: PullRequestSettings{
+				Subject:       &override,
+				BodyTemplate:  &subject,
+				Labels:        []string{},
+				TargetBranch:  "develop",
+				Reviewers:     []string{"@override"},
+				TeamReviewers: []string{"default-team"},
+				Assignees:     []string{},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tt.givenSettings.MergeWith(defaults)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestPullRequestSettings_ApplyTo(t *testing.T) {
+	settings := PullRequestSettings{
+		Reviewers:     []string{"@ccremer", "@org/maintainers", "@CCREMER"},
+		TeamReviewers: []string{"reviewers", "@org/maintainers"},
+		Assignees:     []string{"@octocat"},
+	}
+	pr := &PullRequest{}
+	err := settings.ApplyTo(pr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ccremer"}, pr.GetReviewers())
+	assert.Equal(t, []string{"maintainers", "reviewers"}, pr.GetTeamReviewers())
+	assert.Equal(t, []string{"octocat"}, pr.GetAssignees())
+}
+
+func TestPullRequestSettings_applyMergeSettings(t *testing.T) {
+	yes, no := true, false
+	tests := map[string]struct {
+		givenSettings     PullRequestSettings
+		givenDraft        bool
+		givenAutoMerge    MergeMethod
+		expectedDraft     bool
+		expectedAutoMerge MergeMethod
+		expectedError     string
+	}{
+		"GivenNoSettings_ThenExpectUnchanged": {
+			givenSettings: PullRequestSettings{},
+			givenDraft:    true,
+			expectedDraft: true,
+		},
+		"GivenDraft_WhenReadyPR_ThenExpectDraft": {
+			givenSettings: PullRequestSettings{Draft: &yes},
+			expectedDraft: true,
+		},
+		"GivenNoDraft_WhenDraftPR_ThenExpectReady": {
+			givenSettings: PullRequestSettings{Draft: &no},
+			givenDraft:    true,
+			expectedDraft: false,
+		},
+		"GivenAutoMerge_WhenNoMergeMethod_ThenExpectMerge": {
+			givenSettings:     PullRequestSettings{Draft: &no, AutoMerge: &yes},
+			givenDraft:        true,
+			expectedAutoMerge: MergeMethodMerge,
+		},
+		"GivenAutoMerge_WhenMergeMethod_ThenExpectMergeMethod": {
+			givenSettings:     PullRequestSettings{AutoMerge: &yes, MergeMethod: MergeMethodSquash},
+			expectedAutoMerge: MergeMethodSquash,
+		},
+		"GivenNoAutoMerge_WhenAutoMergeEnabled_ThenExpectUnchanged": {
+			givenSettings:     PullRequestSettings{Draft: &no},
+			givenAutoMerge:    MergeMethodSquash,
+			expectedAutoMerge: MergeMethodSquash,
+		},
+		"GivenAutoMergeDisabled_WhenAutoMergeEnabled_ThenExpectDisabled": {
+			givenSettings:  PullRequestSettings{AutoMerge: &no},
+			givenAutoMerge: MergeMethodSquash,
+		},
+		"GivenAutoMerge_WhenDraft_ThenExpectError": {
+			givenSettings: PullRequestSettings{Draft: &yes, AutoMerge: &yes},
+			expectedError: "invalid argument: a draft PR cannot be merged automatically",
+		},
+		"GivenAutoMerge_WhenInvalidMergeMethod_ThenExpectError": {
+			givenSettings: PullRequestSettings{AutoMerge: &yes, MergeMethod: "fast-forward"},
+			expectedError: "invalid argument: merge method 'fast-forward' is not one of [merge, squash, rebase]",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pr := &PullRequest{draft: tt.givenDraft, autoMerge: tt.givenAutoMerge}
+			err := tt.givenSettings.ApplyTo(pr)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDraft, pr.IsDraft())
+			assert.Equal(t, tt.expectedAutoMerge, pr.GetAutoMergeMethod())
+		})
+	}
+}
@@ -11,10 +11,14 @@ type PullRequestStore interface {
 	// EnsurePullRequest creates or updates the GitRepository.PullRequest in the repository.
 	//
 	//  * This operation does not alter any properties of existing labels.
-	//  * Existing labels are left untouched, but any extraneous labels are removed.
+	//  * The labels are set to exactly the LabelSet of the PullRequest, any extraneous labels are removed.
 	//  * Title and Body are updated.
 	//  * Existing Commit and Base branches are left untouched.
 	//
 	// The first error encountered aborts the operation.
 	EnsurePullRequest(repository *GitRepository) error
+
+	// ClosePullRequest closes the GitRepository.PullRequest in the repository.
+	// If comment is not empty, it is added to the PullRequest before closing it.
+	ClosePullRequest(repository *GitRepository, comment string) error
 }
@@ -27,6 +27,8 @@ type RenderContext struct {
 	templates       []*Template
 	values          Values
 	deletedFiles    []Path
+	renderedFiles   []Path
+	targetPaths     []Path
 }
 
 func NewRenderService(instrumentation RenderServiceInstrumentation) *RenderService {
@@ -36,7 +38,8 @@ func NewRenderService(instrumentation RenderServiceInstrumentation) *RenderServi
 }
 
 // RenderTemplates loads the TemplateStore and renders them in the GitRepository.RootDir of the given RenderContext.Repository.
-func (s *RenderService) RenderTemplates(ctx RenderContext) error {
+// Returns the paths of the rendered files relative to GitRepository.RootDir.
+func (s *RenderService) RenderTemplates(ctx RenderContext) ([]Path, error) {
 	ctx.instrumentation = s.instrumentation.WithRepository(ctx.Repository)
 	result := pipeline.NewPipeline().WithSteps(
 		pipeline.NewStepFromFunc("preflight check", ctx.preFlightCheck),
@@ -44,7 +47,21 @@ func (s *RenderService) RenderTemplates(ctx RenderContext) error {
 		pipeline.NewStepFromFunc("load deleted file names", ctx.loadDeletedFiles),
 		pipeline.NewStepFromFunc("render templates", ctx.renderTemplates),
 	).Run()
-	return result.Err()
+	return ctx.renderedFiles, result.Err()
+}
+
+// TargetPaths loads the TemplateStore and returns the paths of the files that the templates manage in the GitRepository.RootDir of the given RenderContext.Repository.
+// These are the files that RenderTemplates renders, and the files that are going to be deleted.
+// The paths are relative to GitRepository.RootDir.
+func (s *RenderService) TargetPaths(ctx RenderContext) ([]Path, error) {
+	ctx.instrumentation = s.instrumentation.WithRepository(ctx.Repository)
+	result := pipeline.NewPipeline().WithSteps(
+		pipeline.NewStepFromFunc("preflight check", ctx.preFlightCheck),
+		pipeline.NewStepFromFunc("load templates", ctx.loadTemplates),
+		pipeline.NewStepFromFunc("load deleted file names", ctx.loadDeletedFiles),
+		pipeline.NewStepFromFunc("collect target paths", ctx.collectTargetPaths),
+	).Run()
+	return ctx.targetPaths, result.Err()
 }
 
 func (ctx *RenderContext) preFlightCheck(_ context.Context) error {
@@ -84,7 +101,7 @@ func (ctx *RenderContext) renderTemplate(template *Template) error {
 	defer unix.Umask(originalUmask)
 
 	ctx.instrumentation.AttemptingToRenderTemplate(template)
-	alternativePath, err := ctx.ValueStore.FetchTargetPath(template, ctx.Repository)
+	targetPath, err := ctx.targetPath(template)
 	if err != nil {
 		return err
 	}
@@ -93,14 +110,6 @@ func (ctx *RenderContext) renderTemplate(template *Template) error {
 		return err
 	}
 
-	targetPath := template.CleanPath()
-	if ctx.SkipExtensionRemoval {
-		targetPath = template.RelativePath
-	}
-	if alternativePath != "" {
-		targetPath = alternativePath
-	}
-
 	actualFile := ctx.Repository.RootDir.Join(targetPath)
 	err = os.MkdirAll(filepath.Dir(actualFile.String()), 0775)
 	if err != nil {
@@ -108,9 +117,40 @@ func (ctx *RenderContext) renderTemplate(template *Template) error {
 	}
 
 	err = result.WriteToFile(actualFile, template.FilePermissions)
+	if err == nil {
+		ctx.renderedFiles = append(ctx.renderedFiles, targetPath)
+	}
 	return ctx.instrumentation.WrittenRenderResultToFile(template, targetPath, err)
 }
 
+// targetPath returns the path relative to GitRepository.RootDir where the given template is rendered to.
+func (ctx *RenderContext) targetPath(template *Template) (Path, error) {
+	alternativePath, err := ctx.ValueStore.FetchTargetPath(template, ctx.Repository)
+	if err != nil {
+		return "", err
+	}
+	if alternativePath != "" {
+		return alternativePath, nil
+	}
+	if ctx.SkipExtensionRemoval {
+		return template.RelativePath, nil
+	}
+	return template.CleanPath(), nil
+}
+
+func (ctx *RenderContext) collectTargetPaths(_ context.Context) error {
+	paths := make([]Path, 0, len(ctx.templates)+len(ctx.deletedFiles))
+	for _, template := range ctx.templates {
+		targetPath, err := ctx.targetPath(template)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, targetPath)
+	}
+	ctx.targetPaths = append(paths, ctx.deletedFiles...)
+	return nil
+}
+
 func (ctx *RenderContext) loadTemplates(_ context.Context) error {
 	templates, err := ctx.TemplateStore.FetchTemplates()
 	ctx.templates = templates
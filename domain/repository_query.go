@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+)
+
+// RepositoryQuery is a Value object describing a dynamic selection of repositories in a Git hosting service.
+// At least one of Owner, Topic or Search has to be set, all that are set have to match.
+type RepositoryQuery struct {
+	// Owner selects the repositories of the given organization.
+	Owner string
+	// Topic selects the repositories with the given topic.
+	Topic string
+	// Search selects the repositories matching the given search query in the syntax of the Git hosting service.
+	Search string
+	// IncludeArchived includes archived repositories, which are skipped otherwise.
+	IncludeArchived bool
+	// IncludeForks includes forked repositories, which are skipped otherwise.
+	IncludeForks bool
+}
+
+// GitRepositoryFinder provides methods to discover repositories in a Git hosting service.
+type GitRepositoryFinder interface {
+	// FindRepositories returns the full names (`namespace/repository`) of the repositories that match the given RepositoryQuery.
+	// The Git hosting service is determined by the host of the given URL.
+	FindRepositories(url *GitURL, query RepositoryQuery) ([]string, error)
+}
+
+// String returns a human-readable representation of the query.
+func (q RepositoryQuery) String() string {
+	parts := make([]string, 0, 3)
+	if q.Owner != "" {
+		parts = append(parts, "org:"+q.Owner)
+	}
+	if q.Topic != "" {
+		parts = append(parts, "topic:"+q.Topic)
+	}
+	if q.Search != "" {
+		parts = append(parts, q.Search)
+	}
+	return strings.Join(parts, " ")
+}
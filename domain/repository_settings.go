@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"sort"
+)
+
+// RepositorySettings contains the settings of a repository in a Git hosting service.
+// A nil field indicates that the setting isn't managed for the repository.
+type RepositorySettings struct {
+	// Description is the short description of the repository.
+	Description *string
+	// Homepage is the URL of the project's website.
+	Homepage *string
+	// Topics are the topics (or tags) of the repository.
+	// The order of the topics is irrelevant.
+	Topics []string
+	// AllowMergeCommit determines whether pull requests can be merged with a merge commit.
+	AllowMergeCommit *bool
+	// AllowSquashMerge determines whether pull requests can be squash-merged.
+	AllowSquashMerge *bool
+	// AllowRebaseMerge determines whether pull requests can be rebase-merged.
+	AllowRebaseMerge *bool
+	// DeleteBranchOnMerge determines whether head branches are deleted automatically after pull requests are merged.
+	DeleteBranchOnMerge *bool
+	// HasWiki determines whether the wiki is enabled.
+	HasWiki *bool
+	// HasProjects determines whether projects are enabled.
+	HasProjects *bool
+}
+
+// MergeWith returns new settings in which the settings that aren't configured are taken from the given defaults.
+func (s RepositorySettings) MergeWith(defaults RepositorySettings) RepositorySettings {
+	merged := s
+	if merged.Description == nil {
+		merged.Description = defaults.Description
+	}
+	if merged.Homepage == nil {
+		merged.Homepage = defaults.Homepage
+	}
+	if merged.Topics == nil {
+		merged.Topics = defaults.Topics
+	}
+	if merged.AllowMergeCommit == nil {
+		merged.AllowMergeCommit = defaults.AllowMergeCommit
+	}
+	if merged.AllowSquashMerge == nil {
+		merged.AllowSquashMerge = defaults.AllowSquashMerge
+	}
+	if merged.AllowRebaseMerge == nil {
+		merged.AllowRebaseMerge = defaults.AllowRebaseMerge
+	}
+	if merged.DeleteBranchOnMerge == nil {
+		merged.DeleteBranchOnMerge = defaults.DeleteBranchOnMerge
+	}
+	if merged.HasWiki == nil {
+		merged.HasWiki = defaults.HasWiki
+	}
+	if merged.HasProjects == nil {
+		merged.HasProjects = defaults.HasProjects
+	}
+	return merged
+}
+
+// Diff returns new settings that contain only the configured settings that differ from the given current settings.
+// Settings that are equal or not configured are nil in the result.
+func (s RepositorySettings) Diff(current RepositorySettings) RepositorySettings {
+	diff := RepositorySettings{}
+	if stringDiffers(s.Description, current.Description) {
+		diff.Description = s.Description
+	}
+	if stringDiffers(s.Homepage, current.Homepage) {
+		diff.Homepage = s.Homepage
+	}
+	if s.Topics != nil && !hasSameElements(s.Topics, current.Topics) {
+		diff.Topics = s.Topics
+	}
+	if boolDiffers(s.AllowMergeCommit, current.AllowMergeCommit) {
+		diff.AllowMergeCommit = s.AllowMergeCommit
+	}
+	if boolDiffers(s.AllowSquashMerge, current.AllowSquashMerge) {
+		diff.AllowSquashMerge = s.AllowSquashMerge
+	}
+	if boolDiffers(s.AllowRebaseMerge, current.AllowRebaseMerge) {
+		diff.AllowRebaseMerge = s.AllowRebaseMerge
+	}
+	if boolDiffers(s.DeleteBranchOnMerge, current.DeleteBranchOnMerge) {
+		diff.DeleteBranchOnMerge = s.DeleteBranchOnMerge
+	}
+	if boolDiffers(s.HasWiki, current.HasWiki) {
+		diff.HasWiki = s.HasWiki
+	}
+	if boolDiffers(s.HasProjects, current.HasProjects) {
+		diff.HasProjects = s.HasProjects
+	}
+	return diff
+}
+
+// IsEmpty returns true if none of the settings are configured.
+func (s RepositorySettings) IsEmpty() bool {
+	return len(s.Fields()) == 0
+}
+
+// Fields returns the names of the configured settings.
+func (s RepositorySettings) Fields() []string {
+	fields := make([]string, 0)
+	appendIf := func(configured bool, name string) {
+		if configured {
+			fields = append(fields, name)
+		}
+	}
+	appendIf(s.Description != nil, "description")
+	appendIf(s.Homepage != nil, "homepage")
+	appendIf(s.Topics != nil, "topics")
+	appendIf(s.AllowMergeCommit != nil, "allowMergeCommit")
+	appendIf(s.AllowSquashMerge != nil, "allowSquashMerge")
+	appendIf(s.AllowRebaseMerge != nil, "allowRebaseMerge")
+	appendIf(s.DeleteBranchOnMerge != nil, "deleteBranchOnMerge")
+	appendIf(s.HasWiki != nil, "hasWiki")
+	appendIf(s.HasProjects != nil, "hasProjects")
+	return fields
+}
+
+// stringDiffers returns true if desired is configured and differs from current.
+func stringDiffers(desired, current *string) bool {
+	if desired == nil {
+		return false
+	}
+	return current == nil || *desired != *current
+}
+
+// boolDiffers returns true if desired is configured and differs from current.
+func boolDiffers(desired, current *bool) bool {
+	if desired == nil {
+		return false
+	}
+	return current == nil || *desired != *current
+}
+
+// hasSameElements returns true if both slices contain the same strings, regardless of order.
+func hasSameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositorySettings_MergeWith(t *testing.T) {
+	description, override := "default", "override"
+	yes, no := true, false
+	defaults := RepositorySettings{
+		Description: &description,
+		Topics:      []string{"default"},
+		HasWiki:     &yes,
+	}
+	tests := map[string]struct {
+		givenSettings  RepositorySettings
+		expectedResult RepositorySettings
+	}{
+		"GivenNoOverrides_ThenExpectDefaults": {
+			givenSettings:  RepositorySettings{},
+			expectedResult: defaults,
+		},
+		"GivenOverrides_ThenExpectOverrides": {
+			givenSettings: RepositorySettings{
+				Description: &override,
+				Topics:      []string{},
+				HasWiki:     &no,
+			},
+			expectedResult: RepositorySettings{
+				Description: &override,
+				Topics:      []string{},
+				HasWiki:     &no,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tt.givenSettings.MergeWith(defaults)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestRepositorySettings_Diff(t *testing.T) {
+	description, otherDescription := "description", "other"
+	yes, no := true, false
+	current := RepositorySettings{
+		Description:      &description,
+		Topics:           []string{"go", "cli"},
+		AllowMergeCommit: &yes,
+		HasWiki:          &yes,
+	}
+	tests := map[string]struct {
+		givenSettings  RepositorySettings
+		expectedResult RepositorySettings
+		expectedFields []string
+	}{
+		"GivenNoSettings_ThenExpectEmptyDiff": {
+			givenSettings:  RepositorySettings{},
+			expectedResult: RepositorySettings{},
+			expectedFields: []string{},
+		},
+		"GivenEqualSettings_ThenExpectEmptyDiff": {
+			givenSettings: RepositorySettings{
+				Description:      &description,
+				Topics:           []string{"cli", "go"},
+				AllowMergeCommit: &yes,
+			},
+			expectedResult: RepositorySettings{},
+			expectedFields: []string{},
+		},
+		"GivenDifferentSettings_ThenExpectOnlyDifferences": {
+			givenSettings: RepositorySettings{
+				Description:      &otherDescription,
+				Topics:           []string{"go"},
+				AllowMergeCommit: &yes,
+				HasWiki:          &no,
+			},
+			expectedResult: RepositorySettings{
+				Description: &otherDescription,
+				Topics:      []string{"go"},
+				HasWiki:     &no,
+			},
+			expectedFields: []string{"description", "topics", "hasWiki"},
+		},
+		"GivenSettings_WhenNotSetRemotely_ThenExpectDifference": {
+			givenSettings: RepositorySettings{
+				HasProjects: &no,
+			},
+			expectedResult: RepositorySettings{
+				HasProjects: &no,
+			},
+			expectedFields: []string{"hasProjects"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tt.givenSettings.Diff(current)
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedFields, result.Fields())
+			assert.Equal(t, len(tt.expectedFields) == 0, result.IsEmpty())
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+// SettingsStore provides methods to interact with repository settings on a Git hosting service.
+//
+// In Domain-Driven Design language, the term `Store` corresponds to `Repository`, but to avoid name clash it was named `Store`.
+type SettingsStore interface {
+	// FetchSettingsForRepository retrieves the current RepositorySettings of the given repository.
+	// All settings that are supported by the Git hosting service are set.
+	FetchSettingsForRepository(repository *GitRepository) (RepositorySettings, error)
+	// UpdateSettingsForRepository updates the given RepositorySettings in the given repository.
+	// Only the settings that are configured (non-nil) are changed, all others remain untouched.
+	UpdateSettingsForRepository(repository *GitRepository, settings RepositorySettings) error
+}
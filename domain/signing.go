@@ -0,0 +1,51 @@
+package domain
+
+import "fmt"
+
+// SigningFormat is the kind of key with which commits are signed.
+type SigningFormat string
+
+const (
+	// SigningFormatNone disables commit signing.
+	SigningFormatNone SigningFormat = ""
+	// SigningFormatGPG signs commits with an OpenPGP key.
+	SigningFormatGPG SigningFormat = "gpg"
+	// SigningFormatSSH signs commits with an SSH key.
+	SigningFormatSSH SigningFormat = "ssh"
+)
+
+// Validate returns ErrInvalidArgument if the format is not one of the known signing formats.
+func (f SigningFormat) Validate() error {
+	switch f {
+	case SigningFormatNone, SigningFormatGPG, SigningFormatSSH:
+		return nil
+	}
+	return fmt.Errorf("%w: signing format '%s' is not one of [%s, %s]", ErrInvalidArgument, f, SigningFormatGPG, SigningFormatSSH)
+}
+
+// SigningKey is a Value object describing the key with which commits are signed.
+type SigningKey struct {
+	// Format is the kind of key.
+	// Commits are not signed if empty.
+	Format SigningFormat
+	// Key identifies the key.
+	// For SigningFormatGPG it's the key ID, or empty to use the key matching the committer identity.
+	// For SigningFormatSSH it's the path to the private or public key file, or a literal public key prefixed with `key::`.
+	Key string
+}
+
+// IsEnabled returns true if commits are signed.
+func (k SigningKey) IsEnabled() bool {
+	return k.Format != SigningFormatNone
+}
+
+// Validate returns ErrInvalidArgument if the Format is unknown or if an SSH key is configured without Key.
+func (k SigningKey) Validate() error {
+	if err := k.Format.Validate(); err != nil {
+		return err
+	}
+	if k.Format == SigningFormatSSH && k.Key == "" {
+		return fmt.Errorf("%w: signing with format '%s' requires a key", ErrInvalidArgument, k.Format)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigningKey_Validate(t *testing.T) {
+	tests := map[string]struct {
+		givenKey      SigningKey
+		expectedError string
+	}{
+		"GivenNoFormat_ThenExpectNoError": {
+			givenKey: SigningKey{},
+		},
+		"GivenGPGWithoutKey_ThenExpectNoError": {
+			givenKey: SigningKey{Format: SigningFormatGPG},
+		},
+		"GivenSSHWithKey_ThenExpectNoError": {
+			givenKey: SigningKey{Format: SigningFormatSSH, Key: "~/.ssh/id_ed25519.pub"},
+		},
+		"GivenSSHWithoutKey_ThenExpectError": {
+			givenKey:      SigningKey{Format: SigningFormatSSH},
+			expectedError: "invalid argument: signing with format 'ssh' requires a key",
+		},
+		"GivenUnknownFormat_ThenExpectError": {
+			givenKey:      SigningKey{Format: "x509"},
+			expectedError: "invalid argument: signing format 'x509' is not one of [gpg, ssh]",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.givenKey.Validate()
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -15,4 +15,13 @@ type ValueStore interface {
 	// FetchFilesToDelete returns a slice of Path that should be deleted in the Git repository.
 	// The paths are relative to the Git root directory.
 	FetchFilesToDelete(repository *GitRepository, templates []*Template) ([]Path, error)
+	// FetchPullRequestSettings returns the repository-specific PullRequestSettings.
+	// Settings that aren't configured for the repository are nil.
+	FetchPullRequestSettings(repository *GitRepository) (PullRequestSettings, error)
+	// FetchRepositorySettings returns the repository-specific RepositorySettings.
+	// Settings that aren't configured for the repository are nil.
+	FetchRepositorySettings(repository *GitRepository) (RepositorySettings, error)
+	// FetchCommitSettings returns the repository-specific CommitSettings.
+	// Settings that aren't configured for the repository are nil.
+	FetchCommitSettings(repository *GitRepository) (CommitSettings, error)
 }
@@ -10,6 +10,8 @@ const (
 	RepositoryValueKey = "Repository"
 	// TemplateValueKey is the key for the Template variable.
 	TemplateValueKey = "Template"
+	// ChangesValueKey is the key for the ChangeSummary variable.
+	ChangesValueKey = "Changes"
 	// ValuesKey is the key for user-defined variables.
 	ValuesKey = "Values"
 )
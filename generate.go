@@ -37,14 +37,42 @@ func createExampleConfig() {
 		flags.NewPRSubjectFlag(nil),
 		flags.NewPRTargetBranchFlag(nil),
 		flags.NewPRLabelsFlag(nil),
+		flags.NewPRLabelSyncModeFlag(nil),
+		flags.NewPRReviewersFlag(nil),
+		flags.NewPRTeamReviewersFlag(nil),
+		flags.NewPRAssigneesFlag(nil),
+		flags.NewPRDraftFlag(nil),
+		flags.NewPRAutoMergeFlag(nil),
+		flags.NewPRMergeMethodFlag(nil),
+		flags.NewPRCloseCommentFlag(nil),
+		flags.NewPRDeleteBranchFlag(nil),
+		flags.NewPRForkOwnerFlag(nil),
 
 		flags.NewGitRootDirFlag(nil),
 		flags.NewGitCommitMessageFlag(nil),
+		flags.NewGitBackendFlag(nil),
+		flags.NewGitCloneDepthFlag(nil),
+		flags.NewGitCloneFilterFlag(nil),
+		flags.NewGitSparseCheckoutFlag(nil),
+		flags.NewGitSigningFormatFlag(nil),
+		flags.NewGitSigningKeyFlag(nil),
+		flags.NewGitSignoffFlag(nil),
+		flags.NewGitAuthorNameFlag(nil),
+		flags.NewGitAuthorEmailFlag(nil),
+		flags.NewGitCommitterNameFlag(nil),
+		flags.NewGitCommitterEmailFlag(nil),
+		flags.NewGitTrailersFlag(nil),
 		flags.NewGitCommitBranchFlag(nil),
 		flags.NewGitDefaultNamespaceFlag(nil),
 		flags.NewGitForcePushFlag(nil),
 		flags.NewGitBaseURLFlag(nil),
 
+		flags.NewGitLabURLFlag(nil),
+		flags.NewGiteaURLFlag(nil),
+
+		flags.NewLabelsPruneFlag(nil),
+		flags.NewLabelsPruneKeepFlag(nil),
+
 		flags.NewShowDiffFlag(nil),
 		flags.NewShowLogFlag(nil),
 
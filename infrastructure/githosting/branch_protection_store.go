@@ -0,0 +1,44 @@
+package githosting
+
+import (
+	"github.com/ccremer/greposync/domain"
+)
+
+// BranchProtectionRemote is a Remote that supports managing branch protection rules.
+type BranchProtectionRemote interface {
+	Remote
+
+	// FetchBranchProtections returns the domain.BranchProtectionSet of the given repository.
+	// The same rules as domain.BranchProtectionStore:FetchBranchProtectionsForRepository applies.
+	FetchBranchProtections(repository *domain.GitRepository) (domain.BranchProtectionSet, error)
+
+	// EnsureBranchProtections creates or updates the given domain.BranchProtectionSet.
+	// The same rules as domain.BranchProtectionStore:EnsureBranchProtectionsForRepository applies.
+	EnsureBranchProtections(repository *domain.GitRepository, protections domain.BranchProtectionSet) error
+}
+
+type BranchProtectionStore struct {
+	providers ProviderMap
+}
+
+func NewBranchProtectionStore(providers ProviderMap) *BranchProtectionStore {
+	return &BranchProtectionStore{
+		providers: providers,
+	}
+}
+
+func (s *BranchProtectionStore) FetchBranchProtectionsForRepository(repository *domain.GitRepository) (domain.BranchProtectionSet, error) {
+	remote, err := s.providers.findRemoteWithCapability(repository, CapabilityBranchProtection)
+	if err != nil {
+		return nil, err
+	}
+	return remote.(BranchProtectionRemote).FetchBranchProtections(repository)
+}
+
+func (s *BranchProtectionStore) EnsureBranchProtectionsForRepository(repository *domain.GitRepository, protections domain.BranchProtectionSet) error {
+	remote, err := s.providers.findRemoteWithCapability(repository, CapabilityBranchProtection)
+	if err != nil {
+		return err
+	}
+	return remote.(BranchProtectionRemote).EnsureBranchProtections(repository, protections)
+}
@@ -0,0 +1,37 @@
+package githosting
+
+import (
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+)
+
+// HexColorConverter converts domain.Color to label colors in the `#ababab` format and vice-versa.
+// GitLab and Gitea use this format.
+type HexColorConverter struct{}
+
+// ConvertToEntity converts the given color to domain.Color.
+// Colors without prefix (`ababab`) are accepted as well, as older Gitea versions return them.
+// Returns an empty Color if the value is not a hexadecimal RGB color.
+func (HexColorConverter) ConvertToEntity(color string) domain.Color {
+	formatted := strings.ToUpper(color)
+	if !strings.HasPrefix(formatted, "#") {
+		formatted = "#" + formatted
+	}
+	converted := domain.Color(formatted)
+	err := converted.CheckValue()
+	if err != nil {
+		return ""
+	}
+	return converted
+}
+
+// ConvertFromEntity converts the given domain.Color to a lowercase color with prefix.
+// Returns an empty string if the color is invalid.
+func (HexColorConverter) ConvertFromEntity(color domain.Color) string {
+	err := color.CheckValue()
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(color.String())
+}
@@ -0,0 +1,67 @@
+package githosting
+
+import (
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHexColorConverter_ConvertToEntity(t *testing.T) {
+	tests := map[string]struct {
+		givenColor     string
+		expectedResult domain.Color
+	}{
+		"GivenEmptyString_ThenReturnEmpty": {
+			givenColor:     "",
+			expectedResult: "",
+		},
+		"GivenInvalidColor_ThenReturnEmpty": {
+			givenColor:     "invalid",
+			expectedResult: "",
+		},
+		"GivenValidColor_WhenLowerCaseWithPrefix_ThenReturnUppercaseWithPrefix": {
+			// This is how GitLab and newer Gitea versions return colors
+			givenColor:     "#ababab",
+			expectedResult: "#ABABAB",
+		},
+		"GivenValidColor_WhenWithoutPrefix_ThenReturnUppercaseWithPrefix": {
+			givenColor:     "ababab",
+			expectedResult: "#ABABAB",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			converter := HexColorConverter{}
+			result := converter.ConvertToEntity(tt.givenColor)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestHexColorConverter_ConvertFromEntity(t *testing.T) {
+	tests := map[string]struct {
+		givenColor     domain.Color
+		expectedResult string
+	}{
+		"GivenEmptyString_ThenReturnEmpty": {
+			givenColor:     "",
+			expectedResult: "",
+		},
+		"GivenInvalidColor_ThenReturnEmpty": {
+			givenColor:     "invalid",
+			expectedResult: "",
+		},
+		"GivenValidColor_ThenReturnLowercaseWithPrefix": {
+			givenColor:     "#ABABAB",
+			expectedResult: "#ababab",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			converter := HexColorConverter{}
+			result := converter.ConvertFromEntity(tt.givenColor)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package githosting
+
+import (
+	"github.com/ccremer/greposync/domain"
+)
+
+// ForkRemote is a Remote that supports forking repositories.
+type ForkRemote interface {
+	Remote
+
+	// EnsureFork ensures that a fork of the given repository exists under the given owner.
+	// The same rules as domain.ForkStore:EnsureForkForRepository applies.
+	EnsureFork(repository *domain.GitRepository, owner string) error
+}
+
+type ForkStore struct {
+	providers ProviderMap
+}
+
+func NewForkStore(providers ProviderMap) *ForkStore {
+	return &ForkStore{
+		providers: providers,
+	}
+}
+
+func (s *ForkStore) EnsureForkForRepository(repository *domain.GitRepository, owner string) error {
+	remote, err := s.providers.findRemoteWithCapability(repository, CapabilityFork)
+	if err != nil {
+		return err
+	}
+	return remote.(ForkRemote).EnsureFork(repository, owner)
+}
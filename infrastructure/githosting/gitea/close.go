@@ -0,0 +1,37 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+)
+
+// ClosePullRequest implements githosting.Remote.
+func (r *GtRemote) ClosePullRequest(repository *domain.GitRepository, pr *domain.PullRequest, comment string) error {
+	nr := pr.GetNumber().Int()
+	if nr == nil {
+		return nil
+	}
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	owner, repo := r.ownerAndName(repository.URL)
+	if comment != "" {
+		_, _, err = client.CreateIssueComment(owner, repo, int64(*nr), gitea.CreateIssueCommentOption{Body: comment})
+		if err != nil {
+			return err
+		}
+	}
+	state := gitea.StateClosed
+	// Gitea clears the body if it's not given.
+	closed, _, err := client.EditPullRequest(owner, repo, int64(*nr), gitea.EditPullRequestOption{
+		Body:  pr.GetBody(),
+		State: &state,
+	})
+	if err == nil {
+		r.m.Lock()
+		delete(r.prCache, repository.URL)
+		r.m.Unlock()
+	}
+	return r.instrumentation.prClosed(repository, closed, err)
+}
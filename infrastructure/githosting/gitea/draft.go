@@ -0,0 +1,30 @@
+package gitea
+
+import (
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+)
+
+// draftPrefix marks a pull request as work in progress in Gitea.
+const draftPrefix = "WIP: "
+
+// prTitle returns the title of the pull request, which is prefixed with draftPrefix if draft is true.
+func prTitle(title string, draft bool) string {
+	if draft {
+		return draftPrefix + title
+	}
+	return title
+}
+
+// isDraft returns true if the given title is prefixed with draftPrefix.
+func isDraft(title string) bool {
+	return strings.HasPrefix(title, draftPrefix)
+}
+
+// expectedTitle returns the title that the given existing pull request should have.
+// Pull requests that are ready for review are not converted back to draft.
+func expectedTitle(cached *gitea.PullRequest, pr *domain.PullRequest) string {
+	return prTitle(pr.GetTitle(), pr.IsDraft() && isDraft(cached.Title))
+}
@@ -0,0 +1,109 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging"
+)
+
+// GiteaInstrumentation is responsible for logging interactions with Gitea API.
+type GiteaInstrumentation struct {
+	factory logging.LoggerFactory
+}
+
+// NewGiteaInstrumentation returns a new instance.
+func NewGiteaInstrumentation(factory logging.LoggerFactory) *GiteaInstrumentation {
+	return &GiteaInstrumentation{
+		factory: factory,
+	}
+}
+
+func (i *GiteaInstrumentation) fetchedAllLabels(repository *domain.GitRepository, labels []*gitea.Label) {
+	log := i.factory.NewRepositoryLogger(repository).V(1)
+	if log.Enabled() {
+		labelArr := make([]string, len(labels))
+		for i, label := range labels {
+			labelArr[i] = label.Name
+		}
+		log.Info("Fetched labels", "labels", labelArr)
+	}
+}
+
+func (i *GiteaInstrumentation) createdLabel(repository *domain.GitRepository, label domain.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Created label", "label", label.Name)
+	}
+	return err
+}
+
+func (i *GiteaInstrumentation) updatedLabel(repository *domain.GitRepository, label domain.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Updated label", "label", label.Name)
+	}
+	return err
+}
+
+func (i *GiteaInstrumentation) renamedLabel(repository *domain.GitRepository, oldName string, label domain.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Renamed label", "from", oldName, "label", label.Name)
+	}
+	return err
+}
+
+func (i *GiteaInstrumentation) deletedLabel(repository *domain.GitRepository, label *gitea.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Deleted label", "label", label.Name)
+	}
+	return err
+}
+
+func (i *GiteaInstrumentation) prCreated(repository *domain.GitRepository, htmlUrl string) {
+	i.factory.NewRepositoryLogger(repository).Info("PR created", "url", htmlUrl)
+}
+
+func (i *GiteaInstrumentation) prNotCreatedBecauseNoCommits(repository *domain.GitRepository, pr *domain.PullRequest) error {
+	i.factory.NewRepositoryLogger(repository).Info("No pull request created as there are no commits between branches", "base", pr.BaseBranch, "head", pr.CommitBranch)
+	return nil
+}
+
+func (i *GiteaInstrumentation) noPrFound(repository *domain.GitRepository) error {
+	i.factory.NewRepositoryLogger(repository).V(1).Info("No PR found")
+	return nil
+}
+
+func (i *GiteaInstrumentation) prFound(repository *domain.GitRepository, pr *gitea.PullRequest) error {
+	i.factory.NewRepositoryLogger(repository).V(1).Info("Existing PR found", "url", pr.HTMLURL)
+	return nil
+}
+
+func (i *GiteaInstrumentation) prUpdated(repository *domain.GitRepository, pr *gitea.PullRequest, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Updated pull request", "url", pr.HTMLURL, "title", pr.Title)
+	}
+	return err
+}
+
+func (i *GiteaInstrumentation) prLabelsUpdated(repository *domain.GitRepository, pr *domain.PullRequest, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Updated pull request labels", "labels", pr.GetLabels().String())
+	}
+	return err
+}
+
+func (i *GiteaInstrumentation) prIsUpToDate(repository *domain.GitRepository, cached *gitea.PullRequest) error {
+	i.factory.NewRepositoryLogger(repository).Info("Pull request is up-to-date", "url", cached.HTMLURL)
+	return nil
+}
+
+func (i *GiteaInstrumentation) autoMergeNotSupported(repository *domain.GitRepository, pr *domain.PullRequest) {
+	if pr.IsAutoMergeEnabled() {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Auto-merge is not supported by Gitea, skipping")
+	}
+}
+
+func (i *GiteaInstrumentation) prClosed(repository *domain.GitRepository, pr *gitea.PullRequest, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Closed obsolete pull request", "url", pr.HTMLURL)
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+)
+
+// LabelConverter converts domain.Label to gitea.Label and vice-versa
+type LabelConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+func (LabelConverter) ConvertToEntity(label *gitea.Label) domain.Label {
+	if label == nil {
+		return domain.Label{}
+	}
+	entity := domain.Label{
+		Name:        label.Name,
+		Description: label.Description,
+	}
+	color := githosting.HexColorConverter{}.ConvertToEntity(label.Color)
+	// there's no non-colored label on Gitea
+	_ = entity.SetColor(color)
+	return entity
+}
+
+// ConvertFromEntity converts the given object to another.
+func (LabelConverter) ConvertFromEntity(label domain.Label) *gitea.Label {
+	converted := &gitea.Label{
+		Name:        label.Name,
+		Description: label.Description,
+		Color:       githosting.HexColorConverter{}.ConvertFromEntity(label.GetColor()),
+	}
+	return converted
+}
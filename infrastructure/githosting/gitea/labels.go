@@ -0,0 +1,235 @@
+package gitea
+
+import (
+	"net/http"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+)
+
+// pageSize is the maximum page size that Gitea supports by default.
+const pageSize = 50
+
+// FetchLabels implements githosting.Remote.
+func (r *GtRemote) FetchLabels(repository *domain.GitRepository) (domain.LabelSet, error) {
+	gtLabels, err := r.fetchAllLabels(repository)
+	if err == nil {
+		r.m.Lock()
+		r.labelCache[repository.URL] = gtLabels
+		r.m.Unlock()
+	}
+	return LabelSetConverter{}.ConvertToEntity(gtLabels), err
+}
+
+// EnsureLabels implements githosting.Remote.
+func (r *GtRemote) EnsureLabels(repository *domain.GitRepository, labels domain.LabelSet) error {
+	for _, label := range labels {
+		cached, exists := r.findCachedLabel(repository.URL, label.Name)
+		if exists {
+			if r.hasLabelChanged(cached, label) {
+				err := r.updateLabel(repository, cached, label)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if previous, found := r.findCachedPreviousLabel(repository.URL, label); found {
+			err := r.renameLabel(repository, previous, label)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		_, err := r.createLabel(repository, label)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteLabels implements githosting.Remote.
+// Labels are deleted by ID in Gitea, thus labels that aren't known from FetchLabels are ignored.
+func (r *GtRemote) DeleteLabels(repository *domain.GitRepository, labels domain.LabelSet) error {
+	for _, label := range labels {
+		cached, exists := r.findCachedLabel(repository.URL, label.Name)
+		if !exists {
+			continue
+		}
+		_, err := r.deleteLabel(repository, cached)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *GtRemote) findCachedLabel(url *domain.GitURL, name string) (*gitea.Label, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	cachedSet, exists := r.labelCache[url]
+	if !exists {
+		return nil, false
+	}
+	for _, cached := range cachedSet {
+		if cached.Name == name {
+			return cached, true
+		}
+	}
+	return nil, false
+}
+
+func (r *GtRemote) findCachedPreviousLabel(url *domain.GitURL, label domain.Label) (*gitea.Label, bool) {
+	for _, previous := range label.RenamedFrom {
+		if cached, exists := r.findCachedLabel(url, previous); exists {
+			return cached, true
+		}
+	}
+	return nil, false
+}
+
+func (r *GtRemote) updateLabelCache(url *domain.GitURL, label *gitea.Label) {
+	if label == nil {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	cachedSet, exists := r.labelCache[url]
+	if !exists {
+		r.labelCache[url] = []*gitea.Label{label}
+		return
+	}
+	for i, cached := range cachedSet {
+		if cached.ID == label.ID {
+			cachedSet[i] = label
+			return
+		}
+	}
+	cachedSet = append(cachedSet, label)
+	r.labelCache[url] = cachedSet
+}
+
+func (r *GtRemote) removeLabelFromCache(url *domain.GitURL, label *gitea.Label) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	cachedSet, exists := r.labelCache[url]
+	if !exists {
+		return
+	}
+	for i, cached := range cachedSet {
+		if cached.ID == label.ID {
+			// replace the existing index with the last element
+			cachedSet[i] = cachedSet[len(cachedSet)-1]
+			// remove the (duplicated) last element
+			newSet := cachedSet[:len(cachedSet)-1]
+			r.labelCache[url] = newSet
+			return
+		}
+	}
+}
+
+func (r *GtRemote) createLabel(repository *domain.GitRepository, label domain.Label) (*gitea.Label, error) {
+	client, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+	converted := LabelConverter{}.ConvertFromEntity(label)
+	owner, repo := r.ownerAndName(repository.URL)
+	newLabel, _, err := client.CreateLabel(owner, repo, gitea.CreateLabelOption{
+		Name:        converted.Name,
+		Color:       converted.Color,
+		Description: converted.Description,
+	})
+	if err == nil {
+		r.updateLabelCache(repository.URL, newLabel)
+	}
+	return newLabel, r.instrumentation.createdLabel(repository, label, err)
+}
+
+func (r *GtRemote) updateLabel(repository *domain.GitRepository, gtLabel *gitea.Label, label domain.Label) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	color := githosting.HexColorConverter{}.ConvertFromEntity(label.GetColor())
+	owner, repo := r.ownerAndName(repository.URL)
+	updatedLabel, _, err := client.EditLabel(owner, repo, gtLabel.ID, gitea.EditLabelOption{
+		Color:       &color,
+		Description: &label.Description,
+	})
+	if err == nil {
+		r.updateLabelCache(repository.URL, updatedLabel)
+	}
+	return r.instrumentation.updatedLabel(repository, label, err)
+}
+
+func (r *GtRemote) renameLabel(repository *domain.GitRepository, gtLabel *gitea.Label, label domain.Label) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	oldName := gtLabel.Name
+	color := githosting.HexColorConverter{}.ConvertFromEntity(label.GetColor())
+	owner, repo := r.ownerAndName(repository.URL)
+	renamedLabel, _, err := client.EditLabel(owner, repo, gtLabel.ID, gitea.EditLabelOption{
+		Name:        &label.Name,
+		Color:       &color,
+		Description: &label.Description,
+	})
+	if err == nil {
+		// Labels are cached by ID, the renamed label replaces the old one.
+		r.updateLabelCache(repository.URL, renamedLabel)
+	}
+	return r.instrumentation.renamedLabel(repository, oldName, label, err)
+}
+
+func (r *GtRemote) deleteLabel(repository *domain.GitRepository, label *gitea.Label) (bool, error) {
+	client, err := r.getClient()
+	if err != nil {
+		return false, err
+	}
+	owner, repo := r.ownerAndName(repository.URL)
+	resp, err := client.DeleteLabel(owner, repo, label.ID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// Not an error
+		return false, nil
+	}
+	if err == nil {
+		r.removeLabelFromCache(repository.URL, label)
+	}
+	return err == nil, r.instrumentation.deletedLabel(repository, label, err)
+}
+
+func (r *GtRemote) fetchAllLabels(repository *domain.GitRepository) ([]*gitea.Label, error) {
+	client, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+	var allLabels []*gitea.Label
+	owner, repo := r.ownerAndName(repository.URL)
+	for page := 1; ; page++ {
+		labels, _, err := client.ListRepoLabels(owner, repo, gitea.ListLabelsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: pageSize,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		allLabels = append(allLabels, labels...)
+		// Gitea doesn't return the next page, a partial page is the last one.
+		if len(labels) < pageSize {
+			break
+		}
+	}
+	r.instrumentation.fetchedAllLabels(repository, allLabels)
+	return allLabels, nil
+}
+
+func (r *GtRemote) hasLabelChanged(gtLabel *gitea.Label, repoLabel domain.Label) bool {
+	converted := githosting.HexColorConverter{}.ConvertToEntity(gtLabel.Color)
+	return gtLabel.Description != repoLabel.Description || converted != repoLabel.GetColor()
+}
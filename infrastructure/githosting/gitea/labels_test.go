@@ -0,0 +1,103 @@
+package gitea
+
+import (
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGtRemote_hasLabelChanged(t *testing.T) {
+	label := "label"
+	description := "description"
+	color := "#ababab"
+
+	tests := map[string]struct {
+		givenGtLabel   *gitea.Label
+		givenRepoLabel domain.Label
+		expectedResult bool
+	}{
+		"GivenSameLabel_ThenExpectFalse": {
+			givenGtLabel:   newGiteaLabel(label, description, color),
+			givenRepoLabel: newDomainLabel(t, label, description, color),
+			expectedResult: false,
+		},
+		"GivenDifferentDescription_ThenExpectTrue": {
+			givenGtLabel:   newGiteaLabel(label, description, color),
+			givenRepoLabel: newDomainLabel(t, label, "different", color),
+			expectedResult: true,
+		},
+		"GivenDifferentColor_ThenExpectTrue": {
+			givenGtLabel:   newGiteaLabel(label, description, color),
+			givenRepoLabel: newDomainLabel(t, label, description, "#FFFFFF"),
+			expectedResult: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &GtRemote{}
+			result := p.hasLabelChanged(tt.givenGtLabel, tt.givenRepoLabel)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestGtRemote_HasSupportFor(t *testing.T) {
+	tests := map[string]struct {
+		givenBaseURL   string
+		givenHost      string
+		expectedResult bool
+	}{
+		"GivenSameHost_ThenExpectTrue": {
+			givenBaseURL:   "https://codeberg.org",
+			givenHost:      "codeberg.org",
+			expectedResult: true,
+		},
+		"GivenSelfHostedInstance_WhenHostMatches_ThenExpectTrue": {
+			givenBaseURL:   "https://git.example.com/forgejo",
+			givenHost:      "git.example.com",
+			expectedResult: true,
+		},
+		"GivenDifferentHost_ThenExpectFalse": {
+			givenBaseURL:   "https://codeberg.org",
+			givenHost:      "github.com",
+			expectedResult: false,
+		},
+		"GivenEmptyBaseURL_ThenExpectFalse": {
+			givenBaseURL:   "",
+			givenHost:      "codeberg.org",
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := cfg.NewDefaultConfig()
+			config.Gitea.URL = tt.givenBaseURL
+			p := NewRemote(nil, config)
+			result := p.HasSupportFor(&domain.GitURL{Host: tt.givenHost})
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func newGiteaLabel(name, description, color string) *gitea.Label {
+	return &gitea.Label{
+		Name:        name,
+		Description: description,
+		Color:       color,
+	}
+}
+
+func newDomainLabel(t *testing.T, name, description, color string) domain.Label {
+	label := domain.Label{
+		Name:        name,
+		Description: description,
+	}
+	err := label.SetColor(githosting.HexColorConverter{}.ConvertToEntity(color))
+	require.NoError(t, err)
+	return label
+}
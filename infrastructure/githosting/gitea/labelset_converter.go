@@ -0,0 +1,35 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+)
+
+// LabelSetConverter converts domain.LabelSet to gitea.Label and vice-versa
+type LabelSetConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+// Returns a non-nil empty list if labels is empty or nil.
+func (LabelSetConverter) ConvertToEntity(labels []*gitea.Label) domain.LabelSet {
+	if labels == nil || len(labels) == 0 {
+		return domain.LabelSet{}
+	}
+	converted := make(domain.LabelSet, len(labels))
+	for i := range labels {
+		converted[i] = LabelConverter{}.ConvertToEntity(labels[i])
+	}
+	return converted
+}
+
+// ConvertFromEntity converts the given object to another.
+// Returns a non-nil empty list if labels is empty or nil.
+func (LabelSetConverter) ConvertFromEntity(labels domain.LabelSet) []*gitea.Label {
+	if labels == nil || len(labels) == 0 {
+		return []*gitea.Label{}
+	}
+	converted := make([]*gitea.Label, len(labels))
+	for i := range labels {
+		converted[i] = LabelConverter{}.ConvertFromEntity(labels[i])
+	}
+	return converted
+}
@@ -0,0 +1,189 @@
+package gitea
+
+import (
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+)
+
+// defaultLabelColor is the color of labels that are created because a pull request references them, but they don't exist yet.
+const defaultLabelColor = domain.Color("#EDEDED")
+
+// FindPullRequest implements githosting.Remote.
+func (r *GtRemote) FindPullRequest(repository *domain.GitRepository) (*domain.PullRequest, error) {
+	pr, err := r.findExistingPr(repository)
+	if err != nil {
+		return nil, err
+	}
+	r.m.Lock()
+	if pr != nil {
+		r.prCache[repository.URL] = pr
+	} else {
+		delete(r.prCache, repository.URL)
+	}
+	r.m.Unlock()
+	converted := PrConverter{}.ConvertToEntity(pr)
+	return converted, nil
+}
+
+func (r *GtRemote) findExistingPr(repository *domain.GitRepository) (*gitea.PullRequest, error) {
+	client, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+	// Gitea doesn't support filtering pull requests by head branch.
+	owner, repo := r.ownerAndName(repository.URL)
+	for page := 1; ; page++ {
+		list, _, err := client.ListRepoPullRequests(owner, repo, gitea.ListPullRequestsOptions{
+			ListOptions: gitea.ListOptions{
+				Page:     page,
+				PageSize: pageSize,
+			},
+			State: gitea.StateOpen,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range list {
+			if pr.Head != nil && pr.Head.Ref == repository.CommitBranch {
+				return pr, r.instrumentation.prFound(repository, pr)
+			}
+		}
+		if len(list) < pageSize {
+			break
+		}
+	}
+	return nil, r.instrumentation.noPrFound(repository)
+}
+
+// EnsurePullRequest implements githosting.Remote.
+func (r *GtRemote) EnsurePullRequest(repository *domain.GitRepository, pr *domain.PullRequest) error {
+	r.m.Lock()
+	cached, exists := r.prCache[repository.URL]
+	r.m.Unlock()
+	if !exists || pr.GetNumber() == nil {
+		return r.createNewPr(repository, pr)
+	}
+	return r.updateExistingPr(repository, cached, pr)
+}
+
+func (r *GtRemote) updateExistingPr(repository *domain.GitRepository, cached *gitea.PullRequest, pr *domain.PullRequest) error {
+	r.instrumentation.autoMergeNotSupported(repository, pr)
+	if r.canSkipDescriptionUpdate(cached, pr) && r.canSkipLabelUpdate(cached, pr) {
+		return r.instrumentation.prIsUpToDate(repository, cached)
+	}
+	err := r.updatePrDescription(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	return r.updatePrLabels(repository, cached, pr)
+}
+
+func (r *GtRemote) updatePrDescription(repository *domain.GitRepository, cached *gitea.PullRequest, pr *domain.PullRequest) error {
+	if r.canSkipDescriptionUpdate(cached, pr) {
+		return nil
+	}
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	owner, repo := r.ownerAndName(repository.URL)
+	updated, _, err := client.EditPullRequest(owner, repo, cached.Index, gitea.EditPullRequestOption{
+		Title: expectedTitle(cached, pr),
+		Body:  pr.GetBody(),
+	})
+	if err == nil {
+		cached.Title = updated.Title
+		cached.Body = updated.Body
+	}
+	return r.instrumentation.prUpdated(repository, updated, err)
+}
+
+func (r *GtRemote) updatePrLabels(repository *domain.GitRepository, cached *gitea.PullRequest, pr *domain.PullRequest) error {
+	if r.canSkipLabelUpdate(cached, pr) {
+		return nil
+	}
+	err := r.setLabelsToPr(repository, cached, pr.GetLabels())
+	return r.instrumentation.prLabelsUpdated(repository, pr, err)
+}
+
+func (r *GtRemote) canSkipDescriptionUpdate(cached *gitea.PullRequest, pr *domain.PullRequest) bool {
+	sameTitle := cached.Title == expectedTitle(cached, pr)
+	sameBody := cached.Body == pr.GetBody()
+	return sameTitle && sameBody
+}
+
+func (r *GtRemote) canSkipLabelUpdate(cached *gitea.PullRequest, pr *domain.PullRequest) bool {
+	converted := LabelSetConverter{}.ConvertToEntity(cached.Labels)
+	return converted.Equals(pr.GetLabels())
+}
+
+func (r *GtRemote) createNewPr(repository *domain.GitRepository, pr *domain.PullRequest) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	owner, repo := r.ownerAndName(repository.URL)
+	gtPr, _, err := client.CreatePullRequest(owner, repo, gitea.CreatePullRequestOption{
+		Title: prTitle(pr.GetTitle(), pr.IsDraft()),
+		Head:  pr.CommitBranch,
+		Base:  pr.BaseBranch,
+		Body:  pr.GetBody(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "There are no changes between the head and the base") {
+			return r.instrumentation.prNotCreatedBecauseNoCommits(repository, pr)
+		}
+		return err
+	}
+
+	if len(pr.GetLabels()) > 0 {
+		err := r.setLabelsToPr(repository, gtPr, pr.GetLabels())
+		if err != nil {
+			return err
+		}
+	}
+
+	r.instrumentation.prCreated(repository, gtPr.HTMLURL)
+	r.instrumentation.autoMergeNotSupported(repository, pr)
+	return nil
+}
+
+// setLabelsToPr replaces the labels of the given pull request.
+// Gitea references labels by ID, labels that don't exist yet in the repository are created.
+func (r *GtRemote) setLabelsToPr(repository *domain.GitRepository, gtPr *gitea.PullRequest, set domain.LabelSet) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	r.m.Lock()
+	_, cached := r.labelCache[repository.URL]
+	r.m.Unlock()
+	if !cached {
+		if _, err := r.FetchLabels(repository); err != nil {
+			return err
+		}
+	}
+	ids := make([]int64, len(set))
+	for i, label := range set {
+		gtLabel, exists := r.findCachedLabel(repository.URL, label.Name)
+		if !exists {
+			newLabel := domain.Label{Name: label.Name}
+			_ = newLabel.SetColor(defaultLabelColor)
+			gtLabel, err = r.createLabel(repository, newLabel)
+			if err != nil {
+				return err
+			}
+		}
+		ids[i] = gtLabel.ID
+	}
+	owner, repo := r.ownerAndName(repository.URL)
+	labels, _, err := client.ReplaceIssueLabels(owner, repo, gtPr.Index, gitea.IssueLabelsOption{
+		Labels: ids,
+	})
+	if err == nil {
+		gtPr.Labels = labels
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package gitea
+
+import (
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/domain"
+)
+
+// PrConverter converts domain.PullRequest to gitea.PullRequest and vice-versa.
+type PrConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+func (c PrConverter) ConvertToEntity(pr *gitea.PullRequest) *domain.PullRequest {
+	if pr == nil {
+		return nil
+	}
+
+	set := LabelSetConverter{}.ConvertToEntity(pr.Labels)
+	nr := int(pr.Index)
+	var head, base string
+	if pr.Head != nil {
+		head = pr.Head.Ref
+	}
+	if pr.Base != nil {
+		base = pr.Base.Ref
+	}
+
+	draft := isDraft(pr.Title)
+	entity, _ := domain.NewPullRequest(domain.NewPullRequestNumber(&nr), strings.TrimPrefix(pr.Title, draftPrefix), pr.Body, head, base, set)
+	_ = entity.SetDraft(draft)
+	return entity
+}
+
+// ConvertFromEntity converts the given object to another.
+func (c PrConverter) ConvertFromEntity(entity *domain.PullRequest) *gitea.PullRequest {
+	if entity == nil {
+		return nil
+	}
+	pr := &gitea.PullRequest{
+		Title:  prTitle(entity.GetTitle(), entity.IsDraft()),
+		Body:   entity.GetBody(),
+		Labels: LabelSetConverter{}.ConvertFromEntity(entity.GetLabels()),
+	}
+	if nr := entity.GetNumber().Int(); nr != nil {
+		pr.Index = int64(*nr)
+	}
+	return pr
+}
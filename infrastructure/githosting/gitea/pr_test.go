@@ -0,0 +1,94 @@
+package gitea
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGtRemote_FindPullRequest(t *testing.T) {
+	tests := map[string]struct {
+		givenPullRequests []*gitea.PullRequest
+		givenCommitBranch string
+		givenBasePath     string
+		expectedNumber    *int
+	}{
+		"GivenNoPullRequests_ThenExpectNil": {
+			givenPullRequests: []*gitea.PullRequest{},
+			givenCommitBranch: "greposync-update",
+		},
+		"GivenPullRequestWithOtherHead_ThenExpectNil": {
+			givenPullRequests: []*gitea.PullRequest{newGiteaPullRequest(1, "feature", "main")},
+			givenCommitBranch: "greposync-update",
+		},
+		"GivenPullRequestWithMatchingHead_ThenExpectPullRequest": {
+			givenPullRequests: []*gitea.PullRequest{
+				newGiteaPullRequest(1, "feature", "main"),
+				newGiteaPullRequest(2, "greposync-update", "main"),
+			},
+			givenCommitBranch: "greposync-update",
+			expectedNumber:    intPtr(2),
+		},
+		"GivenInstanceUnderSubPath_ThenExpectOwnerWithoutSubPath": {
+			givenPullRequests: []*gitea.PullRequest{newGiteaPullRequest(1, "greposync-update", "main")},
+			givenCommitBranch: "greposync-update",
+			givenBasePath:     "/forgejo",
+			expectedNumber:    intPtr(1),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.givenBasePath+"/api/v1/repos/owner/repository/pulls", r.URL.Path)
+				assert.Equal(t, "open", r.URL.Query().Get("state"))
+				w.Header().Set("Content-Type", "application/json")
+				require.NoError(t, json.NewEncoder(w).Encode(tt.givenPullRequests))
+			}))
+			defer server.Close()
+
+			config := cfg.NewDefaultConfig()
+			config.Gitea.URL = server.URL + tt.givenBasePath
+			p := NewRemote(NewGiteaInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+			repo := domain.NewGitRepository(newGitURL(t, server.URL+tt.givenBasePath+"/owner/repository.git"), "")
+			repo.CommitBranch = tt.givenCommitBranch
+
+			result, err := p.FindPullRequest(repo)
+			require.NoError(t, err)
+			if tt.expectedNumber == nil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expectedNumber, result.GetNumber().Int())
+			assert.Equal(t, tt.givenCommitBranch, result.CommitBranch)
+		})
+	}
+}
+
+func newGiteaPullRequest(index int64, head, base string) *gitea.PullRequest {
+	return &gitea.PullRequest{
+		Index: index,
+		Title: "title",
+		Head:  &gitea.PRBranchInfo{Ref: head},
+		Base:  &gitea.PRBranchInfo{Ref: base},
+	}
+}
+
+func newGitURL(t *testing.T, raw string) *domain.GitURL {
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	return domain.FromURL(u)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,95 @@
+package gitea
+
+import (
+	"net/url"
+	"os"
+	"sync"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+)
+
+type (
+	// GtRemote contains the methods and data to interact with the Gitea API.
+	// Forgejo is API-compatible with Gitea and supported as well.
+	GtRemote struct {
+		client          *gitea.Client
+		config          *cfg.Configuration
+		m               *sync.Mutex
+		prCache         map[*domain.GitURL]*gitea.PullRequest
+		labelCache      map[*domain.GitURL][]*gitea.Label
+		instrumentation *GiteaInstrumentation
+	}
+)
+
+// ProviderKey is the identifier for the Gitea githosting.RemoteProvider.
+const ProviderKey githosting.RemoteProvider = "gitea"
+
+// TokenEnvVarName is the name of the environment variable that contains the Gitea access token.
+const TokenEnvVarName = "GITEA_TOKEN"
+
+// NewRemote returns a new Gitea provider instance.
+// The API client is created lazily once the configuration has been parsed.
+func NewRemote(instrumentation *GiteaInstrumentation, config *cfg.Configuration) *GtRemote {
+	provider := &GtRemote{
+		m:               &sync.Mutex{},
+		config:          config,
+		prCache:         map[*domain.GitURL]*gitea.PullRequest{},
+		labelCache:      map[*domain.GitURL][]*gitea.Label{},
+		instrumentation: instrumentation,
+	}
+	return provider
+}
+
+// HasSupportFor implements githosting.Remote.
+// It returns true if the host of the given URL is the same as the host of the configured Gitea instance.
+func (r *GtRemote) HasSupportFor(url *domain.GitURL) bool {
+	baseURL, err := r.parseBaseURL()
+	if err != nil {
+		return false
+	}
+	return url.Host == baseURL.Host
+}
+
+// HasCapability implements githosting.Remote.
+// None of the optional capabilities are supported for Gitea yet.
+func (r *GtRemote) HasCapability(_ githosting.Capability) bool {
+	return false
+}
+
+func (r *GtRemote) parseBaseURL() (*url.URL, error) {
+	if r.config == nil || r.config.Gitea == nil || r.config.Gitea.URL == "" {
+		return nil, githosting.ErrProviderNotSupported
+	}
+	return url.Parse(r.config.Gitea.URL)
+}
+
+// getClient returns the Gitea API client.
+// The client is initialized on first invocation.
+func (r *GtRemote) getClient() (*gitea.Client, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.client != nil {
+		return r.client, nil
+	}
+	baseURL, err := r.parseBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	// Skip querying the server version, we don't use any version-dependent API.
+	client, err := gitea.NewClient(baseURL.String(), gitea.SetToken(os.Getenv(TokenEnvVarName)), gitea.SetGiteaVersion(""))
+	r.client = client
+	return client, err
+}
+
+// ownerAndName returns the owner and name of the given repository as expected by the Gitea API.
+// The path of the configured Gitea URL is not part of the owner, if the instance is served under a sub-path.
+func (r *GtRemote) ownerAndName(repositoryURL *domain.GitURL) (owner, name string) {
+	baseURL, err := r.parseBaseURL()
+	if err != nil {
+		return repositoryURL.GetNamespace(), repositoryURL.GetRepositoryName()
+	}
+	return githosting.RepositoryPath(repositoryURL, baseURL)
+}
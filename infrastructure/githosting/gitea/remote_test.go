@@ -0,0 +1,47 @@
+package gitea
+
+import (
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGtRemote_ownerAndName(t *testing.T) {
+	tests := map[string]struct {
+		givenBaseURL       string
+		givenRepositoryURL string
+		expectedOwner      string
+		expectedName       string
+	}{
+		"GivenInstanceWithoutPath_ThenExpectNamespace": {
+			givenBaseURL:       "https://gitea.com",
+			givenRepositoryURL: "https://gitea.com/org/repository.git",
+			expectedOwner:      "org",
+			expectedName:       "repository",
+		},
+		"GivenInstanceUnderSubPath_WhenHttpsURL_ThenExpectOwnerWithoutSubPath": {
+			givenBaseURL:       "https://git.example.com/forgejo",
+			givenRepositoryURL: "https://git.example.com/forgejo/org/repository.git",
+			expectedOwner:      "org",
+			expectedName:       "repository",
+		},
+		"GivenInstanceUnderSubPath_WhenSshURL_ThenExpectNamespace": {
+			givenBaseURL:       "https://git.example.com/forgejo/",
+			givenRepositoryURL: "ssh://git@git.example.com/org/repository.git",
+			expectedOwner:      "org",
+			expectedName:       "repository",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := cfg.NewDefaultConfig()
+			config.Gitea.URL = tt.givenBaseURL
+			r := NewRemote(NewGiteaInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+			owner, repo := r.ownerAndName(newGitURL(t, tt.givenRepositoryURL))
+			assert.Equal(t, tt.expectedOwner, owner)
+			assert.Equal(t, tt.expectedName, repo)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+	"golang.org/x/oauth2"
+)
+
+// jwtLifetime is the validity of a JWT issued for the GitHub App.
+// GitHub accepts at most 10 minutes.
+const jwtLifetime = 9 * time.Minute
+
+// appTransport is a http.RoundTripper that authenticates requests as a GitHub App using a JWT.
+// This is required to resolve installations and to create installation tokens.
+//
+// https://docs.github.com/en/developers/apps/building-github-apps/authenticating-with-github-apps#authenticating-as-a-github-app
+type appTransport struct {
+	base       http.RoundTripper
+	appID      int64
+	privateKey *rsa.PrivateKey
+	now        func() time.Time
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.signJWT()
+	if err != nil {
+		return nil, err
+	}
+	// The request must not be modified, see http.RoundTripper.
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(clone)
+}
+
+func (t *appTransport) signJWT() (string, error) {
+	// Backdate the issue time to allow for clock drift.
+	now := t.now().Add(-60 * time.Second)
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": t.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.privateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// installationTokenSource is an oauth2.TokenSource that creates installation access tokens for a GitHub App installation.
+// Wrapped in oauth2.ReuseTokenSource, a new token is created once the previous one expires.
+type installationTokenSource struct {
+	ctx            context.Context
+	appClient      *github.Client
+	installationID int64
+}
+
+// Token implements oauth2.TokenSource.
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	token, _, err := s.appClient.Apps.CreateInstallationToken(s.ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create installation token for installation %d: %w", s.installationID, err)
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		Expiry:      token.GetExpiresAt(),
+	}, nil
+}
+
+// newAppClient returns a client that authenticates as the GitHub App itself.
+// It's used to resolve installations and to create installation tokens.
+func newAppClient(hostConfig *cfg.GitHubHostConfig, httpClient *http.Client) (*github.Client, error) {
+	appConfig := hostConfig.App
+	privateKey, err := readPrivateKey(appConfig.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return newGitHubClient(hostConfig, &http.Client{Transport: &appTransport{
+		base:       base,
+		appID:      appConfig.ID,
+		privateKey: privateKey,
+		now:        time.Now,
+	}})
+}
+
+// findInstallationID returns the ID of the installation of the GitHub App that has access to the given repository.
+func findInstallationID(ctx context.Context, appClient *github.Client, appID int64, url *domain.GitURL) (int64, error) {
+	installation, _, err := appClient.Apps.FindRepositoryInstallation(ctx, url.GetNamespace(), url.GetRepositoryName())
+	if err != nil {
+		return 0, fmt.Errorf("cannot find installation of GitHub App %d for '%s': %w", appID, url.GetNamespace(), err)
+	}
+	return installation.GetID(), nil
+}
+
+// newInstallationTokenSource returns an oauth2.TokenSource that authenticates as the given installation of the GitHub App.
+func newInstallationTokenSource(ctx context.Context, appClient *github.Client, installationID int64) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, &installationTokenSource{
+		ctx:            ctx,
+		appClient:      appClient,
+		installationID: installationID,
+	})
+}
+
+// readPrivateKey reads a PEM-encoded RSA private key in PKCS#1 or PKCS#8 format.
+func readPrivateKey(file string) (*rsa.PrivateKey, error) {
+	if strings.TrimSpace(file) == "" {
+		return nil, errors.New("private key file of GitHub App is not configured")
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read private key of GitHub App: %w", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key file '%s'", file)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse private key file '%s': %w", file, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key in file '%s' is not an RSA key", file)
+	}
+	return key, nil
+}
@@ -0,0 +1,231 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppTransport_signJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	transport := &appTransport{appID: 1234, privateKey: key, now: func() time.Time { return now }}
+
+	token, err := transport.signJWT()
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	claims := map[string]int64{}
+	require.NoError(t, json.Unmarshal(rawClaims, &claims))
+	assert.Equal(t, int64(1234), claims["iss"])
+	assert.Equal(t, now.Add(-60*time.Second).Unix(), claims["iat"])
+	assert.Equal(t, now.Add(-60*time.Second).Add(jwtLifetime).Unix(), claims["exp"])
+}
+
+func TestReadPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		givenContent        []byte
+		expectedErrContains string
+	}{
+		"GivenPKCS1Key_ThenExpectKey": {
+			givenContent: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+		"GivenPKCS8Key_ThenExpectKey": {
+			givenContent: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		},
+		"GivenNoPEM_ThenExpectError": {
+			givenContent:        []byte("invalid"),
+			expectedErrContains: "no PEM data found",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "key.pem")
+			require.NoError(t, os.WriteFile(file, tt.givenContent, 0600))
+			result, err := readPrivateKey(file)
+			if tt.expectedErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, key.Equal(result))
+		})
+	}
+}
+
+func TestGhRemote_clientFor_WithGitHubApp(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+
+	tokensCreated := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		switch {
+		case r.URL.Path == "/api/v3/repos/first/repository/installation":
+			assert.True(t, strings.HasPrefix(auth, "Bearer "), "expected JWT authentication")
+			_, _ = fmt.Fprint(w, `{"id": 1}`)
+		case r.URL.Path == "/api/v3/repos/second/repository/installation":
+			assert.True(t, strings.HasPrefix(auth, "Bearer "), "expected JWT authentication")
+			_, _ = fmt.Fprint(w, `{"id": 2}`)
+		case r.URL.Path == "/api/v3/repos/third/repository/installation":
+			assert.True(t, strings.HasPrefix(auth, "Bearer "), "expected JWT authentication")
+			_, _ = fmt.Fprint(w, `{"id": 1}`)
+		case strings.HasPrefix(r.URL.Path, "/api/v3/app/installations/"):
+			assert.Equal(t, http.MethodPost, r.Method)
+			id := strings.Split(r.URL.Path, "/")[5]
+			tokensCreated[id]++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token": "token-%s", "expires_at": "%s"}`, id, time.Now().Add(time.Hour).Format(time.RFC3339))
+		case r.URL.Path == "/api/v3/repos/first/repository/labels", r.URL.Path == "/api/v3/repos/third/repository/labels":
+			assert.Equal(t, "Bearer token-1", auth)
+			_, _ = fmt.Fprint(w, `[]`)
+		case r.URL.Path == "/api/v3/repos/second/repository/labels":
+			assert.Equal(t, "Bearer token-2", auth)
+			_, _ = fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := cfg.NewDefaultConfig()
+	config.GitHub.Hosts = []*cfg.GitHubHostConfig{{
+		Host:   "github.example.com",
+		APIURL: server.URL,
+		App:    &cfg.GitHubAppConfig{ID: 1234, PrivateKeyFile: keyFile},
+	}}
+	r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+
+	for _, owner := range []string{"first", "second", "first", "third"} {
+		client, err := r.clientFor(newGitURL(t, fmt.Sprintf("https://github.example.com/%s/repository", owner)))
+		require.NoError(t, err)
+		_, _, err = client.Issues.ListLabels(r.ctx, owner, "repository", nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int{"1": 1, "2": 1}, tokensCreated, "expected one token per installation")
+}
+
+func TestGhRemote_clientFor_WithGitHubAppInstallationID(t *testing.T) {
+	keyFile := newPrivateKeyFile(t)
+	tokensCreated := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v3/app/installations/5/access_tokens":
+			tokensCreated++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token": "token-5", "expires_at": "%s"}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			assert.Equal(t, "Bearer token-5", r.Header.Get("Authorization"))
+			_, _ = fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := cfg.NewDefaultConfig()
+	config.GitHub.Hosts = []*cfg.GitHubHostConfig{{
+		Host:   "github.example.com",
+		APIURL: server.URL,
+		App:    &cfg.GitHubAppConfig{ID: 1234, InstallationID: 5, PrivateKeyFile: keyFile},
+	}}
+	r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+
+	var clients []*github.Client
+	for _, owner := range []string{"first", "second"} {
+		client, err := r.clientFor(newGitURL(t, fmt.Sprintf("https://github.example.com/%s/repository", owner)))
+		require.NoError(t, err)
+		_, _, err = client.Issues.ListLabels(r.ctx, owner, "repository", nil)
+		require.NoError(t, err)
+		clients = append(clients, client)
+	}
+	assert.Same(t, clients[0], clients[1], "expected one client per installation")
+	assert.Equal(t, 1, tokensCreated, "expected one token per installation")
+}
+
+func TestGhRemote_clientFor_WithSlowInstallationLookup(t *testing.T) {
+	keyFile := newPrivateKeyFile(t)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/slow/repository/installation":
+			<-release
+			_, _ = fmt.Fprint(w, `{"id": 1}`)
+		case "/api/v3/repos/fast/repository/installation":
+			_, _ = fmt.Fprint(w, `{"id": 2}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := cfg.NewDefaultConfig()
+	config.GitHub.Hosts = []*cfg.GitHubHostConfig{{
+		Host:   "github.example.com",
+		APIURL: server.URL,
+		App:    &cfg.GitHubAppConfig{ID: 1234, PrivateKeyFile: keyFile},
+	}}
+	r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+
+	go func() {
+		_, _ = r.clientFor(newGitURL(t, "https://github.example.com/slow/repository"))
+	}()
+	done := make(chan error)
+	go func() {
+		// Give the slow lookup a head start.
+		time.Sleep(50 * time.Millisecond)
+		_, err := r.clientFor(newGitURL(t, "https://github.example.com/fast/repository"))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("client of other owner is blocked by the installation lookup")
+	}
+}
+
+func newPrivateKeyFile(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+	return keyFile
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+)
+
+// canSkipDraftUpdate returns true if the draft state doesn't need to change.
+// Pull requests that are ready for review are not converted back to draft.
+func (r *GhRemote) canSkipDraftUpdate(cached *github.PullRequest, pr *domain.PullRequest) bool {
+	return !cached.GetDraft() || pr.IsDraft()
+}
+
+// canSkipAutoMergeUpdate returns true if auto-merge is already disabled, or already enabled with the same merge method.
+func (r *GhRemote) canSkipAutoMergeUpdate(cached *github.PullRequest, pr *domain.PullRequest) bool {
+	if !pr.IsAutoMergeEnabled() {
+		return cached.AutoMerge == nil
+	}
+	return cached.AutoMerge != nil && strings.EqualFold(cached.AutoMerge.GetMergeMethod(), pr.GetAutoMergeMethod().String())
+}
+
+func (r *GhRemote) markPrReady(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) error {
+	if r.canSkipDraftUpdate(cached, pr) {
+		return nil
+	}
+	err := r.mutate(repository.URL, markReadyForReviewMutation, map[string]interface{}{
+		"id": cached.GetNodeID(),
+	})
+	if err == nil {
+		cached.Draft = github.Bool(false)
+	}
+	return r.instrumentation.prMarkedReady(repository, cached, err)
+}
+
+// updateAutoMerge enables, changes or disables auto-merge on the given pull request.
+// If the merge method changes, auto-merge is disabled and enabled again with the new method.
+func (r *GhRemote) updateAutoMerge(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) error {
+	if r.canSkipAutoMergeUpdate(cached, pr) {
+		return nil
+	}
+	if cached.AutoMerge != nil {
+		if err := r.disableAutoMerge(repository, cached); err != nil || cached.AutoMerge != nil {
+			return err
+		}
+	}
+	return r.enableAutoMerge(repository, cached, pr)
+}
+
+// enableAutoMerge enables auto-merge on the given pull request.
+// Failures aren't fatal, since auto-merge depends on repository settings and on the state of the pull request, which we can't fix.
+func (r *GhRemote) enableAutoMerge(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) error {
+	if !pr.IsAutoMergeEnabled() || cached.AutoMerge != nil {
+		return nil
+	}
+	method := pr.GetAutoMergeMethod()
+	err := r.mutate(repository.URL, enableAutoMergeMutation, map[string]interface{}{
+		"id":     cached.GetNodeID(),
+		"method": strings.ToUpper(method.String()),
+	})
+	if err == nil {
+		cached.AutoMerge = &github.PullRequestAutoMerge{MergeMethod: github.String(method.String())}
+	}
+	return r.instrumentation.prAutoMergeEnabled(repository, cached, method, err)
+}
+
+// disableAutoMerge disables auto-merge on the given pull request.
+// Failures aren't fatal, the pull request could have been merged or closed in the meantime.
+func (r *GhRemote) disableAutoMerge(repository *domain.GitRepository, cached *github.PullRequest) error {
+	err := r.mutate(repository.URL, disableAutoMergeMutation, map[string]interface{}{
+		"id": cached.GetNodeID(),
+	})
+	if err == nil {
+		cached.AutoMerge = nil
+	}
+	return r.instrumentation.prAutoMergeDisabled(repository, cached, err)
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"github.com/ccremer/greposync/domain"
+)
+
+// Branch protection rules with patterns are only available through the GraphQL API.
+const (
+	branchProtectionRulesQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    id
+    defaultBranchRef { name }
+    branchProtectionRules(first: 100) {
+      nodes {
+        id
+        pattern
+        requiresStatusChecks
+        requiredStatusCheckContexts
+        requiresStrictStatusChecks
+        requiresApprovingReviews
+        requiredApprovingReviewCount
+        dismissesStaleReviews
+        requiresCodeOwnerReviews
+        requiresLinearHistory
+        isAdminEnforced
+      }
+    }
+  }
+}`
+	createBranchProtectionRuleMutation = `mutation($input: CreateBranchProtectionRuleInput!) {
+  createBranchProtectionRule(input: $input) { clientMutationId }
+}`
+	updateBranchProtectionRuleMutation = `mutation($input: UpdateBranchProtectionRuleInput!) {
+  updateBranchProtectionRule(input: $input) { clientMutationId }
+}`
+)
+
+type branchProtectionRulesData struct {
+	Repository ghRepositoryProtection `json:"repository"`
+}
+
+type ghRepositoryProtection struct {
+	ID               string `json:"id"`
+	DefaultBranchRef struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	BranchProtectionRules struct {
+		Nodes []ghBranchProtectionRule `json:"nodes"`
+	} `json:"branchProtectionRules"`
+}
+
+type ghBranchProtectionRule struct {
+	ID                           string   `json:"id"`
+	Pattern                      string   `json:"pattern"`
+	RequiresStatusChecks         bool     `json:"requiresStatusChecks"`
+	RequiredStatusCheckContexts  []string `json:"requiredStatusCheckContexts"`
+	RequiresStrictStatusChecks   bool     `json:"requiresStrictStatusChecks"`
+	RequiresApprovingReviews     bool     `json:"requiresApprovingReviews"`
+	RequiredApprovingReviewCount int      `json:"requiredApprovingReviewCount"`
+	DismissesStaleReviews        bool     `json:"dismissesStaleReviews"`
+	RequiresCodeOwnerReviews     bool     `json:"requiresCodeOwnerReviews"`
+	RequiresLinearHistory        bool     `json:"requiresLinearHistory"`
+	IsAdminEnforced              bool     `json:"isAdminEnforced"`
+}
+
+// FetchBranchProtections implements githosting.BranchProtectionRemote.
+func (r *GhRemote) FetchBranchProtections(repository *domain.GitRepository) (domain.BranchProtectionSet, error) {
+	data := &branchProtectionRulesData{}
+	err := r.query(repository.URL, branchProtectionRulesQuery, map[string]interface{}{
+		"owner": repository.URL.GetNamespace(),
+		"name":  repository.URL.GetRepositoryName(),
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+	r.m.Lock()
+	r.protectionCache[repository.URL] = &data.Repository
+	r.m.Unlock()
+	if repository.DefaultBranch == "" {
+		repository.DefaultBranch = data.Repository.DefaultBranchRef.Name
+	}
+	rules := data.Repository.BranchProtectionRules.Nodes
+	set := make(domain.BranchProtectionSet, len(rules))
+	for i, rule := range rules {
+		set[i] = r.convertBranchProtectionRule(rule)
+	}
+	return set, nil
+}
+
+// EnsureBranchProtections implements githosting.BranchProtectionRemote.
+// The existing rules are fetched first if FetchBranchProtections hasn't been called for the same repository.
+func (r *GhRemote) EnsureBranchProtections(repository *domain.GitRepository, protections domain.BranchProtectionSet) error {
+	r.m.Lock()
+	cached, exists := r.protectionCache[repository.URL]
+	r.m.Unlock()
+	if !exists {
+		if _, err := r.FetchBranchProtections(repository); err != nil {
+			return err
+		}
+		r.m.Lock()
+		cached = r.protectionCache[repository.URL]
+		r.m.Unlock()
+	}
+	for _, protection := range protections {
+		if rule, found := r.findBranchProtectionRule(cached, protection.Pattern); found {
+			if err := r.updateBranchProtection(repository, rule, protection); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.createBranchProtection(repository, cached, protection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *GhRemote) findBranchProtectionRule(cached *ghRepositoryProtection, pattern string) (ghBranchProtectionRule, bool) {
+	for _, rule := range cached.BranchProtectionRules.Nodes {
+		if rule.Pattern == pattern {
+			return rule, true
+		}
+	}
+	return ghBranchProtectionRule{}, false
+}
+
+func (r *GhRemote) createBranchProtection(repository *domain.GitRepository, cached *ghRepositoryProtection, protection domain.BranchProtection) error {
+	input := r.toBranchProtectionInput(protection)
+	input["repositoryId"] = cached.ID
+	err := r.mutate(repository.URL, createBranchProtectionRuleMutation, map[string]interface{}{"input": input})
+	return r.instrumentation.createdBranchProtection(repository, protection, err)
+}
+
+func (r *GhRemote) updateBranchProtection(repository *domain.GitRepository, rule ghBranchProtectionRule, protection domain.BranchProtection) error {
+	input := r.toBranchProtectionInput(protection)
+	input["branchProtectionRuleId"] = rule.ID
+	err := r.mutate(repository.URL, updateBranchProtectionRuleMutation, map[string]interface{}{"input": input})
+	return r.instrumentation.updatedBranchProtection(repository, protection, err)
+}
+
+func (r *GhRemote) toBranchProtectionInput(protection domain.BranchProtection) map[string]interface{} {
+	checks := protection.RequiredStatusChecks
+	if checks == nil {
+		checks = []string{}
+	}
+	return map[string]interface{}{
+		"pattern":                      protection.Pattern,
+		"requiresStatusChecks":         len(checks) > 0,
+		"requiredStatusCheckContexts":  checks,
+		"requiresStrictStatusChecks":   protection.StrictStatusChecks,
+		"requiresApprovingReviews":     protection.RequiredApprovingReviews > 0,
+		"requiredApprovingReviewCount": protection.RequiredApprovingReviews,
+		"dismissesStaleReviews":        protection.DismissStaleReviews,
+		"requiresCodeOwnerReviews":     protection.RequireCodeOwnerReviews,
+		"requiresLinearHistory":        protection.RequireLinearHistory,
+		"isAdminEnforced":              protection.EnforceAdmins,
+	}
+}
+
+func (r *GhRemote) convertBranchProtectionRule(rule ghBranchProtectionRule) domain.BranchProtection {
+	protection := domain.BranchProtection{
+		Pattern:                 rule.Pattern,
+		StrictStatusChecks:      rule.RequiresStrictStatusChecks,
+		DismissStaleReviews:     rule.DismissesStaleReviews,
+		RequireCodeOwnerReviews: rule.RequiresCodeOwnerReviews,
+		RequireLinearHistory:    rule.RequiresLinearHistory,
+		EnforceAdmins:           rule.IsAdminEnforced,
+	}
+	if rule.RequiresStatusChecks {
+		protection.RequiredStatusChecks = rule.RequiredStatusCheckContexts
+	}
+	if rule.RequiresApprovingReviews {
+		protection.RequiredApprovingReviews = rule.RequiredApprovingReviewCount
+	}
+	return protection
+}
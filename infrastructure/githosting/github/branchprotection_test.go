@@ -0,0 +1,88 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const branchProtectionRulesResponse = `{"data": {"repository": {
+  "id": "R_node",
+  "defaultBranchRef": {"name": "main"},
+  "branchProtectionRules": {"nodes": [{
+    "id": "BPR_node",
+    "pattern": "main",
+    "requiresStatusChecks": true,
+    "requiredStatusCheckContexts": ["build"],
+    "requiresStrictStatusChecks": true,
+    "requiresApprovingReviews": false,
+    "requiredApprovingReviewCount": 1,
+    "requiresLinearHistory": true
+  }]}
+}}}`
+
+func TestGhRemote_FetchBranchProtections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, branchProtectionRulesResponse)
+	}))
+	defer server.Close()
+
+	r := newBranchProtectionTestRemote(server)
+	repo := &domain.GitRepository{URL: newGitURL(t, "https://github.example.com/ccremer/greposync")}
+
+	result, err := r.FetchBranchProtections(repo)
+	require.NoError(t, err)
+	assert.Equal(t, "main", repo.DefaultBranch)
+	assert.Equal(t, domain.BranchProtectionSet{{
+		Pattern:              "main",
+		RequiredStatusChecks: []string{"build"},
+		StrictStatusChecks:   true,
+		RequireLinearHistory: true,
+	}}, result)
+}
+
+func TestGhRemote_EnsureBranchProtections(t *testing.T) {
+	var mutations []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := graphQLRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if strings.HasPrefix(req.Query, "query") {
+			_, _ = fmt.Fprint(w, branchProtectionRulesResponse)
+			return
+		}
+		mutations = append(mutations, req.Variables["input"].(map[string]interface{}))
+		_, _ = fmt.Fprint(w, `{"data": {}}`)
+	}))
+	defer server.Close()
+
+	r := newBranchProtectionTestRemote(server)
+	repo := &domain.GitRepository{URL: newGitURL(t, "https://github.example.com/ccremer/greposync")}
+
+	err := r.EnsureBranchProtections(repo, domain.BranchProtectionSet{
+		{Pattern: "main", RequiredApprovingReviews: 2},
+		{Pattern: "release/*", EnforceAdmins: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, mutations, 2)
+	assert.Equal(t, "BPR_node", mutations[0]["branchProtectionRuleId"])
+	assert.Equal(t, true, mutations[0]["requiresApprovingReviews"])
+	assert.Equal(t, float64(2), mutations[0]["requiredApprovingReviewCount"])
+	assert.Equal(t, "R_node", mutations[1]["repositoryId"])
+	assert.Equal(t, "release/*", mutations[1]["pattern"])
+	assert.Equal(t, true, mutations[1]["isAdminEnforced"])
+}
+
+func newBranchProtectionTestRemote(server *httptest.Server) *GhRemote {
+	config := cfg.NewDefaultConfig()
+	config.GitHub.Hosts = []*cfg.GitHubHostConfig{{Host: "github.example.com", APIURL: server.URL}}
+	return NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+}
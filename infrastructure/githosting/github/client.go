@@ -0,0 +1,226 @@
+package github
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/google/go-github/v39/github"
+	"golang.org/x/oauth2"
+)
+
+// DefaultHost is the host of the public GitHub instance.
+const DefaultHost = "github.com"
+
+// DefaultTokenEnvVarName is the name of the environment variable that contains the access token if a host doesn't configure one.
+const DefaultTokenEnvVarName = "GITHUB_TOKEN"
+
+// findHostConfig returns the host configuration for the given host name.
+// github.com is supported even if not configured explicitly.
+// Returns nil if the host isn't supported.
+func (r *GhRemote) findHostConfig(host string) *cfg.GitHubHostConfig {
+	if r.config != nil && r.config.GitHub != nil {
+		for _, hostConfig := range r.config.GitHub.Hosts {
+			if hostConfig != nil && hostConfig.Host == host {
+				return hostConfig
+			}
+		}
+	}
+	if host == DefaultHost {
+		return &cfg.GitHubHostConfig{Host: DefaultHost}
+	}
+	return nil
+}
+
+// clientCall is a client that is being created or has been created by GhRemote.loadClient.
+type clientCall struct {
+	wg     sync.WaitGroup
+	client *github.Client
+	err    error
+}
+
+// installationCall is an installation ID that is being resolved or has been resolved by GhRemote.installationFor.
+type installationCall struct {
+	wg  sync.WaitGroup
+	id  int64
+	err error
+}
+
+// clientFor returns the API client that is responsible for the given URL.
+// Clients are created on first invocation and reused for all repositories on the same host.
+// When authenticating as GitHub App, a client is created per installation, since each installation has its own token.
+func (r *GhRemote) clientFor(url *domain.GitURL) (*github.Client, error) {
+	hostConfig := r.findHostConfig(url.Host)
+	if hostConfig == nil {
+		return nil, fmt.Errorf("%s: %w", url.Host, githosting.ErrProviderNotSupported)
+	}
+	client, err := r.loadClient(hostConfig, url)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create GitHub client for host '%s': %w", url.Host, err)
+	}
+	return client, nil
+}
+
+func (r *GhRemote) loadClient(hostConfig *cfg.GitHubHostConfig, url *domain.GitURL) (*github.Client, error) {
+	if hostConfig.App == nil {
+		return r.loadOnce(hostConfig.Host, func() (*github.Client, error) {
+			return r.createClient(hostConfig, nil)
+		})
+	}
+	installationID, err := r.installationFor(hostConfig, url)
+	if err != nil {
+		return nil, err
+	}
+	return r.loadOnce(fmt.Sprintf("%s#%d", hostConfig.Host, installationID), func() (*github.Client, error) {
+		appClient, err := r.appClientFor(hostConfig)
+		if err != nil {
+			return nil, err
+		}
+		return r.createClient(hostConfig, newInstallationTokenSource(r.ctx, appClient, installationID))
+	})
+}
+
+// appClientFor returns the client that authenticates as the GitHub App of the given host.
+func (r *GhRemote) appClientFor(hostConfig *cfg.GitHubHostConfig) (*github.Client, error) {
+	return r.loadOnce(hostConfig.Host+"#app", func() (*github.Client, error) {
+		transport, err := r.newTransport(hostConfig)
+		if err != nil {
+			return nil, err
+		}
+		return newAppClient(hostConfig, &http.Client{Transport: transport})
+	})
+}
+
+// installationFor returns the ID of the GitHub App installation that has access to the given repository.
+// If no installation ID is configured, the installation is resolved once per repository owner.
+func (r *GhRemote) installationFor(hostConfig *cfg.GitHubHostConfig, url *domain.GitURL) (int64, error) {
+	if hostConfig.App.InstallationID != 0 {
+		return hostConfig.App.InstallationID, nil
+	}
+	key := path.Join(hostConfig.Host, url.GetNamespace())
+	r.clientsMutex.Lock()
+	if call, exists := r.installations[key]; exists {
+		r.clientsMutex.Unlock()
+		call.wg.Wait()
+		return call.id, call.err
+	}
+	call := &installationCall{}
+	call.wg.Add(1)
+	r.installations[key] = call
+	r.clientsMutex.Unlock()
+
+	appClient, err := r.appClientFor(hostConfig)
+	if err == nil {
+		call.id, err = findInstallationID(r.ctx, appClient, hostConfig.App.ID, url)
+	}
+	call.err = err
+	if err != nil {
+		r.clientsMutex.Lock()
+		delete(r.installations, key)
+		r.clientsMutex.Unlock()
+	}
+	call.wg.Done()
+	return call.id, call.err
+}
+
+// loadOnce returns the client stored under the given key, calling create if there is none yet.
+// Concurrent callers of the same key wait for the same creation, without blocking callers of other keys.
+// Failed creations are not stored, so that they are attempted again.
+func (r *GhRemote) loadOnce(key string, create func() (*github.Client, error)) (*github.Client, error) {
+	r.clientsMutex.Lock()
+	if call, exists := r.clients[key]; exists {
+		r.clientsMutex.Unlock()
+		call.wg.Wait()
+		return call.client, call.err
+	}
+	call := &clientCall{}
+	call.wg.Add(1)
+	r.clients[key] = call
+	r.clientsMutex.Unlock()
+
+	call.client, call.err = create()
+	if call.err != nil {
+		r.clientsMutex.Lock()
+		delete(r.clients, key)
+		r.clientsMutex.Unlock()
+	}
+	call.wg.Done()
+	return call.client, call.err
+}
+
+// createClient returns a client that authenticates with the given token source.
+// If ts is nil, the access token is read from the environment variable configured for the host.
+func (r *GhRemote) createClient(hostConfig *cfg.GitHubHostConfig, ts oauth2.TokenSource) (*github.Client, error) {
+	if ts == nil {
+		tokenEnvVar := hostConfig.TokenEnvVar
+		if tokenEnvVar == "" {
+			tokenEnvVar = DefaultTokenEnvVarName
+		}
+		ts = oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: os.Getenv(tokenEnvVar)},
+		)
+	}
+	transport, err := r.newTransport(hostConfig)
+	if err != nil {
+		return nil, err
+	}
+	baseClient := &http.Client{Transport: transport}
+	tc := oauth2.NewClient(context.WithValue(r.ctx, oauth2.HTTPClient, baseClient), ts)
+	return newGitHubClient(hostConfig, tc)
+}
+
+// newTransport returns the transport that schedules the requests of a client according to the rate limits.
+// Each client has its own scheduler, since rate limits apply per token.
+func (r *GhRemote) newTransport(hostConfig *cfg.GitHubHostConfig) (http.RoundTripper, error) {
+	httpClient, err := createHTTPClient(hostConfig.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	return newScheduler(httpClient.Transport, hostConfig.Host, r.instrumentation), nil
+}
+
+// newGitHubClient returns a client for the public GitHub API or for GitHub Enterprise Server, depending on the host.
+func newGitHubClient(hostConfig *cfg.GitHubHostConfig, httpClient *http.Client) (*github.Client, error) {
+	if hostConfig.Host == DefaultHost && hostConfig.APIURL == "" {
+		return github.NewClient(httpClient), nil
+	}
+	apiURL := hostConfig.APIURL
+	if apiURL == "" {
+		apiURL = fmt.Sprintf("https://%s/api/v3/", hostConfig.Host)
+	}
+	uploadURL := hostConfig.UploadURL
+	if uploadURL == "" {
+		uploadURL = fmt.Sprintf("https://%s/api/uploads/", hostConfig.Host)
+	}
+	return github.NewEnterpriseClient(apiURL, uploadURL, httpClient)
+}
+
+// createHTTPClient returns a HTTP client that trusts the certificates in the given CA file in addition to the system roots.
+// If caFile is empty, the default HTTP client is returned.
+func createHTTPClient(caFile string) (*http.Client, error) {
+	if caFile == "" {
+		return http.DefaultClient, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file '%s'", caFile)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport}, nil
+}
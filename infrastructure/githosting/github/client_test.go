@@ -0,0 +1,111 @@
+package github
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGhRemote_HasSupportFor(t *testing.T) {
+	tests := map[string]struct {
+		givenHosts     []*cfg.GitHubHostConfig
+		givenURL       string
+		expectedResult bool
+	}{
+		"GivenNoHosts_WhenGitHubCom_ThenExpectTrue": {
+			givenURL:       "https://github.com/ccremer/greposync",
+			expectedResult: true,
+		},
+		"GivenNoHosts_WhenOtherHost_ThenExpectFalse": {
+			givenURL:       "https://github.example.com/ccremer/greposync",
+			expectedResult: false,
+		},
+		"GivenEnterpriseHost_WhenSameHost_ThenExpectTrue": {
+			givenHosts:     []*cfg.GitHubHostConfig{{Host: "github.example.com"}},
+			givenURL:       "https://github.example.com/ccremer/greposync",
+			expectedResult: true,
+		},
+		"GivenEnterpriseHost_WhenGitHubCom_ThenExpectTrue": {
+			givenHosts:     []*cfg.GitHubHostConfig{{Host: "github.example.com"}},
+			givenURL:       "https://github.com/ccremer/greposync",
+			expectedResult: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := cfg.NewDefaultConfig()
+			config.GitHub.Hosts = tt.givenHosts
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+			result := r.HasSupportFor(newGitURL(t, tt.givenURL))
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestGhRemote_clientFor(t *testing.T) {
+	tests := map[string]struct {
+		givenHosts          []*cfg.GitHubHostConfig
+		givenURL            string
+		expectedBaseURL     string
+		expectedUploadURL   string
+		expectedErrContains string
+	}{
+		"GivenGitHubCom_ThenExpectPublicAPI": {
+			givenURL:          "https://github.com/ccremer/greposync",
+			expectedBaseURL:   "https://api.github.com/",
+			expectedUploadURL: "https://uploads.github.com/",
+		},
+		"GivenEnterpriseHost_WhenNoURLsConfigured_ThenExpectDefaultEnterpriseURLs": {
+			givenHosts:        []*cfg.GitHubHostConfig{{Host: "github.example.com"}},
+			givenURL:          "https://github.example.com/ccremer/greposync",
+			expectedBaseURL:   "https://github.example.com/api/v3/",
+			expectedUploadURL: "https://github.example.com/api/uploads/",
+		},
+		"GivenEnterpriseHost_WhenURLsConfigured_ThenExpectConfiguredURLs": {
+			givenHosts: []*cfg.GitHubHostConfig{{
+				Host:      "github.example.com",
+				APIURL:    "https://proxy.example.com/github/api/v3",
+				UploadURL: "https://proxy.example.com/github/api/uploads",
+			}},
+			givenURL:          "https://github.example.com/ccremer/greposync",
+			expectedBaseURL:   "https://proxy.example.com/github/api/v3/",
+			expectedUploadURL: "https://proxy.example.com/github/api/uploads/",
+		},
+		"GivenEnterpriseHost_WhenCAFileIsMissing_ThenExpectError": {
+			givenHosts:          []*cfg.GitHubHostConfig{{Host: "github.example.com", CAFile: "testdata/nonexisting.pem"}},
+			givenURL:            "https://github.example.com/ccremer/greposync",
+			expectedErrContains: "cannot read CA file",
+		},
+		"GivenUnknownHost_ThenExpectError": {
+			givenURL:            "https://github.example.com/ccremer/greposync",
+			expectedErrContains: "no remote provider found",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := cfg.NewDefaultConfig()
+			config.GitHub.Hosts = tt.givenHosts
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+			client, err := r.clientFor(newGitURL(t, tt.givenURL))
+			if tt.expectedErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBaseURL, client.BaseURL.String())
+			assert.Equal(t, tt.expectedUploadURL, client.UploadURL.String())
+		})
+	}
+}
+
+func newGitURL(t *testing.T, raw string) *domain.GitURL {
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	return domain.FromURL(u)
+}
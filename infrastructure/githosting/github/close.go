@@ -0,0 +1,33 @@
+package github
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+)
+
+// ClosePullRequest implements githosting.Remote.
+func (r *GhRemote) ClosePullRequest(repository *domain.GitRepository, pr *domain.PullRequest, comment string) error {
+	nr := pr.GetNumber().Int()
+	if nr == nil {
+		return nil
+	}
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
+	owner, repo := repository.URL.GetNamespace(), repository.URL.GetRepositoryName()
+	if comment != "" {
+		_, _, err = client.Issues.CreateComment(r.ctx, owner, repo, *nr, &github.IssueComment{Body: github.String(comment)})
+		if err != nil {
+			return err
+		}
+	}
+	closed, _, err := client.PullRequests.Edit(r.ctx, owner, repo, *nr, &github.PullRequest{State: github.String("closed")})
+	if err == nil {
+		r.m.Lock()
+		delete(r.prCache, repository.URL)
+		delete(r.reviewCache, repository.URL)
+		r.m.Unlock()
+	}
+	return r.instrumentation.prClosed(repository, closed, err)
+}
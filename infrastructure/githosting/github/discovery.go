@@ -0,0 +1,66 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+)
+
+// FindRepositories implements githosting.DiscoveryRemote.
+// The repositories are found with the search API, which returns at most 1000 results.
+func (r *GhRemote) FindRepositories(url *domain.GitURL, query domain.RepositoryQuery) ([]string, error) {
+	client, err := r.clientFor(url)
+	if err != nil {
+		return nil, err
+	}
+	searchQuery := r.toSearchQuery(query)
+	nextPage := 1
+	names := make([]string, 0)
+	for repeat := true; repeat; repeat = nextPage > 0 {
+		result, resp, err := client.Search.Repositories(r.ctx, searchQuery, &github.SearchOptions{
+			ListOptions: github.ListOptions{Page: nextPage, PerPage: 100},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range result.Repositories {
+			if r.isExcludedFromDiscovery(repo, query) {
+				continue
+			}
+			names = append(names, repo.GetFullName())
+		}
+		// On the last page, the NextPage is 0 again, we can use that to exit the loop
+		nextPage = resp.NextPage
+	}
+	r.instrumentation.discoveredRepositories(url, searchQuery, names)
+	return names, nil
+}
+
+// toSearchQuery converts the given query to the GitHub search syntax.
+func (r *GhRemote) toSearchQuery(query domain.RepositoryQuery) string {
+	qualifiers := make([]string, 0)
+	if query.Search != "" {
+		qualifiers = append(qualifiers, query.Search)
+	}
+	if query.Owner != "" {
+		qualifiers = append(qualifiers, "org:"+query.Owner)
+	}
+	if query.Topic != "" {
+		qualifiers = append(qualifiers, "topic:"+query.Topic)
+	}
+	if !query.IncludeArchived {
+		qualifiers = append(qualifiers, "archived:false")
+	}
+	if query.IncludeForks {
+		// Forks are excluded from search results by default.
+		qualifiers = append(qualifiers, "fork:true")
+	}
+	return strings.Join(qualifiers, " ")
+}
+
+// isExcludedFromDiscovery returns true if the repository is archived or a fork, unless the query includes them.
+// The search query may contain qualifiers that override the ones of the query.
+func (r *GhRemote) isExcludedFromDiscovery(repo *github.Repository, query domain.RepositoryQuery) bool {
+	return (repo.GetArchived() && !query.IncludeArchived) || (repo.GetFork() && !query.IncludeForks)
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGhRemote_toSearchQuery(t *testing.T) {
+	tests := map[string]struct {
+		givenQuery    domain.RepositoryQuery
+		expectedQuery string
+	}{
+		"GivenOwner_ThenExpectOrgWithoutArchived": {
+			givenQuery:    domain.RepositoryQuery{Owner: "ccremer"},
+			expectedQuery: "org:ccremer archived:false",
+		},
+		"GivenOwnerAndTopic_ThenExpectBoth": {
+			givenQuery:    domain.RepositoryQuery{Owner: "ccremer", Topic: "greposync"},
+			expectedQuery: "org:ccremer topic:greposync archived:false",
+		},
+		"GivenSearch_WhenIncludingArchivedAndForks_ThenExpectForks": {
+			givenQuery:    domain.RepositoryQuery{Search: "language:go", IncludeArchived: true, IncludeForks: true},
+			expectedQuery: "language:go fork:true",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &GhRemote{}
+			assert.Equal(t, tt.expectedQuery, r.toSearchQuery(tt.givenQuery))
+		})
+	}
+}
+
+func TestGhRemote_FindRepositories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/search/repositories", r.URL.Path)
+		assert.Equal(t, "org:ccremer archived:false", r.URL.Query().Get("q"))
+		_, _ = fmt.Fprint(w, `{"total_count": 3, "items": [
+  {"full_name": "ccremer/greposync"},
+  {"full_name": "ccremer/archived", "archived": true},
+  {"full_name": "ccremer/fork", "fork": true}
+]}`)
+	}))
+	defer server.Close()
+
+	config := cfg.NewDefaultConfig()
+	config.GitHub.Hosts = []*cfg.GitHubHostConfig{{Host: "github.example.com", APIURL: server.URL}}
+	r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+
+	// Archived repositories and forks may still be returned by the search, e.g. with custom qualifiers.
+	result, err := r.FindRepositories(newGitURL(t, "https://github.example.com/ccremer"), domain.RepositoryQuery{Owner: "ccremer"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ccremer/greposync"}, result)
+}
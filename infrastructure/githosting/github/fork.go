@@ -0,0 +1,106 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+)
+
+var (
+	// forkPollInterval is the delay between checks whether a newly created fork is ready.
+	forkPollInterval = 2 * time.Second
+	// forkPollAttempts is the number of checks until a newly created fork is considered not ready.
+	forkPollAttempts = 15
+)
+
+// EnsureFork implements githosting.ForkRemote.
+// GitHub creates forks asynchronously, so this method waits until a newly created fork is ready.
+func (r *GhRemote) EnsureFork(repository *domain.GitRepository, owner string) error {
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
+	fork, err := r.findFork(client, repository, owner)
+	if err != nil {
+		return err
+	}
+	if fork == nil {
+		fork, err = r.createFork(client, repository, owner)
+		if err != nil {
+			return r.instrumentation.createdFork(repository, nil, err)
+		}
+		if err := r.instrumentation.createdFork(repository, fork, r.waitForFork(client, fork)); err != nil {
+			return err
+		}
+	}
+	repository.ForkURL = toForkURL(repository.URL, fork)
+	return nil
+}
+
+// findFork returns the fork of the given repository with the same name under the given owner.
+// Returns nil if there is no such fork.
+func (r *GhRemote) findFork(client *github.Client, repository *domain.GitRepository, owner string) (*github.Repository, error) {
+	ghRepo, resp, err := client.Repositories.Get(r.ctx, owner, repository.URL.GetRepositoryName())
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ghRepo.GetFork() || !strings.EqualFold(ghRepo.GetParent().GetFullName(), repository.URL.GetNamespace()+"/"+repository.URL.GetRepositoryName()) {
+		return nil, nil
+	}
+	return ghRepo, nil
+}
+
+// createFork forks the given repository into the given owner.
+// If a fork exists already, GitHub returns the existing fork.
+func (r *GhRemote) createFork(client *github.Client, repository *domain.GitRepository, owner string) (*github.Repository, error) {
+	user, _, err := client.Users.Get(r.ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	opts := &github.RepositoryCreateForkOptions{}
+	if user.GetType() == "Organization" {
+		opts.Organization = owner
+	}
+	fork, _, err := client.Repositories.CreateFork(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), opts)
+	var accepted *github.AcceptedError
+	if errors.As(err, &accepted) {
+		// The fork is being created in the background.
+		return fork, nil
+	}
+	return fork, err
+}
+
+// waitForFork returns nil as soon as the given fork can be retrieved.
+func (r *GhRemote) waitForFork(client *github.Client, fork *github.Repository) error {
+	for attempt := 1; attempt <= forkPollAttempts; attempt++ {
+		_, resp, err := client.Repositories.Get(r.ctx, fork.GetOwner().GetLogin(), fork.GetName())
+		if err == nil {
+			return nil
+		}
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return err
+		}
+		time.Sleep(forkPollInterval)
+	}
+	return fmt.Errorf("fork %s is not ready after %d attempts", fork.GetFullName(), forkPollAttempts)
+}
+
+// toForkURL returns the URL of the given fork in the same format as the given origin URL.
+func toForkURL(origin *domain.GitURL, fork *github.Repository) *domain.GitURL {
+	u := origin.AsURL()
+	name := fork.GetName()
+	if strings.HasSuffix(origin.Path, ".git") {
+		name += ".git"
+	}
+	u.Path = path.Join(path.Dir(path.Dir(origin.Path)), fork.GetOwner().GetLogin(), name)
+	return domain.FromURL(u)
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGhRemote_EnsureFork(t *testing.T) {
+	defer func(interval time.Duration) { forkPollInterval = interval }(forkPollInterval)
+	forkPollInterval = 0
+	tests := map[string]struct {
+		givenForkResponse string
+		givenOwnerType    string
+		expectedForkURL   string
+		expectedRequests  []string
+	}{
+		"GivenExistingFork_ThenExpectForkURL": {
+			givenForkResponse: `{"name": "greposync", "fork": true, "owner": {"login": "bot"}, "parent": {"full_name": "ccremer/greposync"}}`,
+			expectedForkURL:   "https://github.example.com/bot/greposync.git",
+			expectedRequests:  []string{"GET /api/v3/repos/bot/greposync"},
+		},
+		"GivenNoFork_WhenOwnerIsUser_ThenCreateFork": {
+			givenOwnerType:  "User",
+			expectedForkURL: "https://github.example.com/bot/greposync-1.git",
+			expectedRequests: []string{
+				"GET /api/v3/repos/bot/greposync",
+				"GET /api/v3/users/bot",
+				"POST /api/v3/repos/ccremer/greposync/forks organization=",
+				"GET /api/v3/repos/bot/greposync-1",
+			},
+		},
+		"GivenNoFork_WhenOwnerIsOrganization_ThenCreateForkInOrganization": {
+			givenOwnerType:  "Organization",
+			expectedForkURL: "https://github.example.com/bot/greposync-1.git",
+			expectedRequests: []string{
+				"GET /api/v3/repos/bot/greposync",
+				"GET /api/v3/users/bot",
+				"POST /api/v3/repos/ccremer/greposync/forks organization=bot",
+				"GET /api/v3/repos/bot/greposync-1",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := make([]string, 0)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request := r.Method + " " + r.URL.Path
+				if r.Method == http.MethodPost {
+					request += " organization=" + r.URL.Query().Get("organization")
+				}
+				requests = append(requests, request)
+				switch r.Method + " " + r.URL.Path {
+				case "GET /api/v3/repos/bot/greposync":
+					if tt.givenForkResponse == "" {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = fmt.Fprint(w, `{"message": "Not Found"}`)
+						return
+					}
+					_, _ = fmt.Fprint(w, tt.givenForkResponse)
+				case "GET /api/v3/users/bot":
+					_, _ = fmt.Fprintf(w, `{"login": "bot", "type": %q}`, tt.givenOwnerType)
+				case "POST /api/v3/repos/ccremer/greposync/forks":
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = fmt.Fprint(w, `{"name": "greposync-1", "full_name": "bot/greposync-1", "owner": {"login": "bot"}}`)
+				case "GET /api/v3/repos/bot/greposync-1":
+					_, _ = fmt.Fprint(w, `{"name": "greposync-1"}`)
+				default:
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			config := cfg.NewDefaultConfig()
+			config.GitHub.Hosts = []*cfg.GitHubHostConfig{{Host: "github.example.com", APIURL: server.URL}}
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+			repo := &domain.GitRepository{URL: newGitURL(t, "https://github.example.com/ccremer/greposync.git")}
+
+			err := r.EnsureFork(repo, "bot")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRequests, requests)
+			assert.Equal(t, tt.expectedForkURL, repo.ForkURL.String())
+		})
+	}
+}
+
+func TestToForkURL(t *testing.T) {
+	tests := map[string]struct {
+		givenOrigin     string
+		expectedForkURL string
+	}{
+		"GivenHttpsURL_ThenExpectSameFormat": {
+			givenOrigin:     "https://github.com/ccremer/greposync",
+			expectedForkURL: "https://github.com/bot/greposync",
+		},
+		"GivenSshURLWithExtension_ThenExpectExtension": {
+			givenOrigin:     "ssh://git@github.com/ccremer/greposync.git",
+			expectedForkURL: "ssh://git@github.com/bot/greposync.git",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fork := &github.Repository{Name: github.String("greposync"), Owner: &github.User{Login: github.String("bot")}}
+			result := toForkURL(newGitURL(t, tt.givenOrigin), fork)
+			assert.Equal(t, tt.expectedForkURL, result.String())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+)
+
+// Some features like draft conversion and auto-merge are only available through the GraphQL API.
+const (
+	markReadyForReviewMutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) { clientMutationId }
+}`
+	enableAutoMergeMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId }
+}`
+	disableAutoMergeMutation = `mutation($id: ID!) {
+  disablePullRequestAutoMerge(input: {pullRequestId: $id}) { clientMutationId }
+}`
+)
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQLEndpoint returns the GraphQL endpoint that belongs to the given REST API base URL.
+// GitHub Enterprise Server serves the REST API under `/api/v3/` and the GraphQL API under `/api/graphql`.
+func graphQLEndpoint(baseURL *url.URL) string {
+	endpoint := *baseURL
+	if strings.HasSuffix(endpoint.Path, "/api/v3/") {
+		endpoint.Path = strings.TrimSuffix(endpoint.Path, "v3/") + "graphql"
+		return endpoint.String()
+	}
+	return endpoint.ResolveReference(&url.URL{Path: "graphql"}).String()
+}
+
+// mutate runs the given GraphQL mutation against the GitHub instance of the given URL.
+// Errors returned in the response body are converted to an error.
+func (r *GhRemote) mutate(url *domain.GitURL, query string, variables map[string]interface{}) error {
+	return r.query(url, query, variables, nil)
+}
+
+// query runs the given GraphQL query against the GitHub instance of the given URL and decodes the `data` of the response into data, if not nil.
+// Errors returned in the response body are converted to an error.
+func (r *GhRemote) query(url *domain.GitURL, query string, variables map[string]interface{}, data interface{}) error {
+	client, err := r.clientFor(url)
+	if err != nil {
+		return err
+	}
+	req, err := client.NewRequest(http.MethodPost, graphQLEndpoint(client.BaseURL), graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	result := &graphQLResponse{Data: data}
+	if _, err := client.Do(r.ctx, req, result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		messages := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("graphql: %s", strings.Join(messages, ", "))
+	}
+	return nil
+}
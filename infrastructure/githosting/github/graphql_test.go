@@ -0,0 +1,158 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		givenBaseURL     string
+		expectedEndpoint string
+	}{
+		"GivenGitHubCom_ThenExpectPublicEndpoint": {
+			givenBaseURL:     "https://api.github.com/",
+			expectedEndpoint: "https://api.github.com/graphql",
+		},
+		"GivenEnterpriseServer_ThenExpectEnterpriseEndpoint": {
+			givenBaseURL:     "https://github.example.com/api/v3/",
+			expectedEndpoint: "https://github.example.com/api/graphql",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(tt.givenBaseURL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEndpoint, graphQLEndpoint(u))
+		})
+	}
+}
+
+func TestGhRemote_enableAutoMerge(t *testing.T) {
+	tests := map[string]struct {
+		givenResponse       string
+		expectedMergeMethod *string
+	}{
+		"GivenSuccessfulResponse_ThenExpectAutoMergeEnabled": {
+			givenResponse:       `{"data": {"enablePullRequestAutoMerge": {"clientMutationId": null}}}`,
+			expectedMergeMethod: github.String("squash"),
+		},
+		"GivenErrorResponse_ThenExpectAutoMergeNotEnabledWithoutError": {
+			givenResponse: `{"errors": [{"message": "Pull request is in clean status"}]}`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/graphql", r.URL.Path)
+				req := graphQLRequest{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, map[string]interface{}{"id": "PR_node", "method": "SQUASH"}, req.Variables)
+				_, _ = fmt.Fprint(w, tt.givenResponse)
+			}))
+			defer server.Close()
+
+			config := cfg.NewDefaultConfig()
+			config.GitHub.Hosts = []*cfg.GitHubHostConfig{{Host: "github.example.com", APIURL: server.URL}}
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+			repo := &domain.GitRepository{URL: newGitURL(t, "https://github.example.com/ccremer/greposync")}
+			pr := &domain.PullRequest{}
+			require.NoError(t, pr.EnableAutoMerge(domain.MergeMethodSquash))
+			cached := &github.PullRequest{NodeID: github.String("PR_node")}
+
+			err := r.enableAutoMerge(repo, cached, pr)
+			require.NoError(t, err)
+			if tt.expectedMergeMethod == nil {
+				assert.Nil(t, cached.AutoMerge)
+				return
+			}
+			assert.Equal(t, tt.expectedMergeMethod, cached.AutoMerge.MergeMethod)
+		})
+	}
+}
+
+func TestGhRemote_updateAutoMerge(t *testing.T) {
+	tests := map[string]struct {
+		givenAutoMerge      *github.PullRequestAutoMerge
+		givenMergeMethod    domain.MergeMethod
+		givenFailure        bool
+		expectedMutations   []string
+		expectedMergeMethod *string
+	}{
+		"GivenAutoMergeDisabled_WhenDisabled_ThenExpectNoMutation": {},
+		"GivenAutoMergeEnabled_WhenSameMethod_ThenExpectNoMutation": {
+			givenAutoMerge:      &github.PullRequestAutoMerge{MergeMethod: github.String("squash")},
+			givenMergeMethod:    domain.MergeMethodSquash,
+			expectedMergeMethod: github.String("squash"),
+		},
+		"GivenAutoMergeEnabled_WhenDisabled_ThenExpectAutoMergeDisabled": {
+			givenAutoMerge:    &github.PullRequestAutoMerge{MergeMethod: github.String("squash")},
+			expectedMutations: []string{"disablePullRequestAutoMerge"},
+		},
+		"GivenAutoMergeEnabled_WhenOtherMethod_ThenExpectAutoMergeEnabledAgain": {
+			givenAutoMerge:      &github.PullRequestAutoMerge{MergeMethod: github.String("merge")},
+			givenMergeMethod:    domain.MergeMethodSquash,
+			expectedMutations:   []string{"disablePullRequestAutoMerge", "enablePullRequestAutoMerge"},
+			expectedMergeMethod: github.String("squash"),
+		},
+		"GivenAutoMergeEnabled_WhenDisablingFails_ThenExpectAutoMergeUnchangedWithoutError": {
+			givenAutoMerge:      &github.PullRequestAutoMerge{MergeMethod: github.String("merge")},
+			givenMergeMethod:    domain.MergeMethodSquash,
+			givenFailure:        true,
+			expectedMutations:   []string{"disablePullRequestAutoMerge"},
+			expectedMergeMethod: github.String("merge"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mutations []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req := graphQLRequest{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				for _, mutation := range []string{"disablePullRequestAutoMerge", "enablePullRequestAutoMerge"} {
+					if strings.Contains(req.Query, mutation) {
+						mutations = append(mutations, mutation)
+					}
+				}
+				if tt.givenFailure {
+					_, _ = fmt.Fprint(w, `{"errors": [{"message": "Pull request is closed"}]}`)
+					return
+				}
+				_, _ = fmt.Fprint(w, `{"data": {}}`)
+			}))
+			defer server.Close()
+
+			config := cfg.NewDefaultConfig()
+			config.GitHub.Hosts = []*cfg.GitHubHostConfig{{Host: "github.example.com", APIURL: server.URL}}
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+			repo := &domain.GitRepository{URL: newGitURL(t, "https://github.example.com/ccremer/greposync")}
+			pr := &domain.PullRequest{}
+			if tt.givenMergeMethod != "" {
+				require.NoError(t, pr.EnableAutoMerge(tt.givenMergeMethod))
+			}
+			cached := &github.PullRequest{NodeID: github.String("PR_node"), AutoMerge: tt.givenAutoMerge}
+
+			err := r.updateAutoMerge(repo, cached, pr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMutations, mutations)
+			if tt.expectedMergeMethod == nil {
+				assert.Nil(t, cached.AutoMerge)
+				return
+			}
+			require.NotNil(t, cached.AutoMerge)
+			assert.Equal(t, tt.expectedMergeMethod, cached.AutoMerge.MergeMethod)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package github
 
 import (
+	"time"
+
 	"github.com/ccremer/greposync/domain"
 	"github.com/ccremer/greposync/infrastructure/logging"
 	"github.com/google/go-github/v39/github"
@@ -43,6 +45,13 @@ func (i *GitHubInstrumentation) updatedLabel(repository *domain.GitRepository, l
 	return err
 }
 
+func (i *GitHubInstrumentation) renamedLabel(repository *domain.GitRepository, oldName string, label domain.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Renamed label", "from", oldName, "label", label.Name)
+	}
+	return err
+}
+
 func (i *GitHubInstrumentation) deletedLabel(repository *domain.GitRepository, label *github.Label, err error) error {
 	if err == nil {
 		i.factory.NewRepositoryLogger(repository).V(1).Info("Deleted label", "label", label.Name)
@@ -50,6 +59,38 @@ func (i *GitHubInstrumentation) deletedLabel(repository *domain.GitRepository, l
 	return err
 }
 
+func (i *GitHubInstrumentation) updatedSettings(repository *domain.GitRepository, settings domain.RepositorySettings, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Updated settings", "settings", settings.Fields())
+	}
+	return err
+}
+
+func (i *GitHubInstrumentation) createdBranchProtection(repository *domain.GitRepository, protection domain.BranchProtection, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Created branch protection", "pattern", protection.Pattern)
+	}
+	return err
+}
+
+func (i *GitHubInstrumentation) updatedBranchProtection(repository *domain.GitRepository, protection domain.BranchProtection, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Updated branch protection", "pattern", protection.Pattern)
+	}
+	return err
+}
+
+func (i *GitHubInstrumentation) discoveredRepositories(url *domain.GitURL, query string, names []string) {
+	i.factory.NewGenericLogger(url.Host).V(1).Info("Discovered repositories", "query", query, "count", len(names))
+}
+
+func (i *GitHubInstrumentation) createdFork(repository *domain.GitRepository, fork *github.Repository, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Created fork", "fork", fork.GetFullName())
+	}
+	return err
+}
+
 func (i *GitHubInstrumentation) prCreated(repository *domain.GitRepository, htmlUrl string) {
 	i.factory.NewRepositoryLogger(repository).Info("PR created", "url", htmlUrl)
 }
@@ -87,3 +128,57 @@ func (i *GitHubInstrumentation) prIsUpToDate(repository *domain.GitRepository, c
 	i.factory.NewRepositoryLogger(repository).Info("Pull request is up-to-date", "url", cached.GetHTMLURL())
 	return nil
 }
+
+func (i *GitHubInstrumentation) prReviewersRequested(repository *domain.GitRepository, users, teams []string, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Requested pull request reviews", "reviewers", users, "teamReviewers", teams)
+	}
+	return err
+}
+
+func (i *GitHubInstrumentation) prAssigneesAdded(repository *domain.GitRepository, assignees []string, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Added pull request assignees", "assignees", assignees)
+	}
+	return err
+}
+
+func (i *GitHubInstrumentation) prMarkedReady(repository *domain.GitRepository, pr *github.PullRequest, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Marked pull request as ready for review", "url", pr.GetHTMLURL())
+	}
+	return err
+}
+
+func (i *GitHubInstrumentation) prAutoMergeEnabled(repository *domain.GitRepository, pr *github.PullRequest, method domain.MergeMethod, err error) error {
+	if err != nil {
+		i.factory.NewRepositoryLogger(repository).Info("Could not enable auto-merge", "url", pr.GetHTMLURL(), "reason", err.Error())
+		return nil
+	}
+	i.factory.NewRepositoryLogger(repository).V(1).Info("Enabled auto-merge", "url", pr.GetHTMLURL(), "method", method)
+	return nil
+}
+
+func (i *GitHubInstrumentation) prAutoMergeDisabled(repository *domain.GitRepository, pr *github.PullRequest, err error) error {
+	if err != nil {
+		i.factory.NewRepositoryLogger(repository).Info("Could not disable auto-merge", "url", pr.GetHTMLURL(), "reason", err.Error())
+		return nil
+	}
+	i.factory.NewRepositoryLogger(repository).V(1).Info("Disabled auto-merge", "url", pr.GetHTMLURL())
+	return nil
+}
+
+func (i *GitHubInstrumentation) rateLimitQuota(host string, limit, remaining int, reset time.Time) {
+	i.factory.NewGenericLogger(host).V(2).Info("Rate limit quota", "remaining", remaining, "limit", limit, "reset", reset.Format(time.RFC3339))
+}
+
+func (i *GitHubInstrumentation) rateLimitExceeded(host string, wait time.Duration, attempt int) {
+	i.factory.NewGenericLogger(host).Info("Rate limit exceeded, waiting before retrying request", "wait", wait.String(), "attempt", attempt)
+}
+
+func (i *GitHubInstrumentation) prClosed(repository *domain.GitRepository, pr *github.PullRequest, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Closed obsolete pull request", "url", pr.GetHTMLURL())
+	}
+	return err
+}
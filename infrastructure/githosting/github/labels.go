@@ -9,7 +9,9 @@ import (
 func (r *GhRemote) FetchLabels(repository *domain.GitRepository) (domain.LabelSet, error) {
 	ghLabels, err := r.fetchAllLabels(repository)
 	if err == nil {
+		r.m.Lock()
 		r.labelCache[repository.URL] = ghLabels
+		r.m.Unlock()
 	}
 	return LabelSetConverter{}.ConvertToEntity(ghLabels), err
 }
@@ -27,6 +29,13 @@ func (r *GhRemote) EnsureLabels(repository *domain.GitRepository, labels domain.
 			}
 			continue
 		}
+		if previous, found := r.findCachedPreviousLabel(repository.URL, label); found {
+			err := r.renameLabel(repository, previous, label)
+			if err != nil {
+				return err
+			}
+			continue
+		}
 		err := r.createLabel(repository, label)
 		if err != nil {
 			return err
@@ -54,6 +63,8 @@ func (r *GhRemote) DeleteLabels(repository *domain.GitRepository, labels domain.
 }
 
 func (r *GhRemote) findCachedLabel(url *domain.GitURL, label domain.Label) (*github.Label, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
 	cachedSet, exists := r.labelCache[url]
 	if !exists {
 		return nil, false
@@ -66,7 +77,18 @@ func (r *GhRemote) findCachedLabel(url *domain.GitURL, label domain.Label) (*git
 	return nil, false
 }
 
+func (r *GhRemote) findCachedPreviousLabel(url *domain.GitURL, label domain.Label) (*github.Label, bool) {
+	for _, previous := range label.RenamedFrom {
+		if cached, exists := r.findCachedLabel(url, domain.Label{Name: previous}); exists {
+			return cached, true
+		}
+	}
+	return nil, false
+}
+
 func (r *GhRemote) updateLabelCache(url *domain.GitURL, label *github.Label) {
+	r.m.Lock()
+	defer r.m.Unlock()
 	cachedSet, exists := r.labelCache[url]
 	if !exists {
 		r.labelCache[url] = []*github.Label{label}
@@ -83,6 +105,8 @@ func (r *GhRemote) updateLabelCache(url *domain.GitURL, label *github.Label) {
 }
 
 func (r *GhRemote) removeLabelFromCache(url *domain.GitURL, label *github.Label) {
+	r.m.Lock()
+	defer r.m.Unlock()
 	cachedSet, exists := r.labelCache[url]
 	if !exists {
 		return
@@ -100,29 +124,50 @@ func (r *GhRemote) removeLabelFromCache(url *domain.GitURL, label *github.Label)
 }
 
 func (r *GhRemote) createLabel(repository *domain.GitRepository, label domain.Label) error {
-	r.m.Lock()
-	defer r.delayedUnlock()
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
 	converted := LabelConverter{}.ConvertFromEntity(label)
-	newLabel, _, err := r.client.Issues.CreateLabel(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), converted)
+	newLabel, _, err := client.Issues.CreateLabel(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), converted)
 	r.updateLabelCache(repository.URL, newLabel)
 	return r.instrumentation.createdLabel(repository, label, err)
 }
 
 func (r *GhRemote) updateLabel(repository *domain.GitRepository, ghLabel *github.Label, label domain.Label) error {
-	r.m.Lock()
-	defer r.delayedUnlock()
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
 	ghLabel.Description = &label.Description
 	color := ColorConverter{}.ConvertFromEntity(label.GetColor())
 	ghLabel.Color = &color
-	updatedLabel, _, err := r.client.Issues.EditLabel(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), label.Name, ghLabel)
+	updatedLabel, _, err := client.Issues.EditLabel(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), label.Name, ghLabel)
 	r.updateLabelCache(repository.URL, updatedLabel)
 	return r.instrumentation.updatedLabel(repository, label, err)
 }
 
+func (r *GhRemote) renameLabel(repository *domain.GitRepository, ghLabel *github.Label, label domain.Label) error {
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
+	oldName := ghLabel.GetName()
+	converted := LabelConverter{}.ConvertFromEntity(label)
+	renamedLabel, _, err := client.Issues.EditLabel(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), oldName, converted)
+	if err == nil {
+		r.removeLabelFromCache(repository.URL, ghLabel)
+		r.updateLabelCache(repository.URL, renamedLabel)
+	}
+	return r.instrumentation.renamedLabel(repository, oldName, label, err)
+}
+
 func (r *GhRemote) deleteLabel(repository *domain.GitRepository, label *github.Label) (bool, error) {
-	r.m.Lock()
-	defer r.delayedUnlock()
-	resp, err := r.client.Issues.DeleteLabel(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), label.GetName())
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.Issues.DeleteLabel(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), label.GetName())
 	if resp != nil && resp.StatusCode == 404 {
 		// Not an error
 		return false, nil
@@ -134,12 +179,14 @@ func (r *GhRemote) deleteLabel(repository *domain.GitRepository, label *github.L
 }
 
 func (r *GhRemote) fetchAllLabels(repository *domain.GitRepository) ([]*github.Label, error) {
-	r.m.Lock()
-	defer r.delayedUnlock()
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return nil, err
+	}
 	nextPage := 1
 	var allLabels []*github.Label
 	for repeat := true; repeat; repeat = nextPage > 0 {
-		labels, resp, err := r.client.Issues.ListLabels(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), &github.ListOptions{
+		labels, resp, err := client.Issues.ListLabels(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), &github.ListOptions{
 			Page:    nextPage,
 			PerPage: 100,
 		})
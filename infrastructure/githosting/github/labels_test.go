@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/ccremer/greposync/cfg"
 	"github.com/ccremer/greposync/domain"
 	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
 	"github.com/google/go-github/v39/github"
@@ -148,7 +149,7 @@ func TestGhRemote_updateLabelCache(t *testing.T) {
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()))
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), cfg.NewDefaultConfig())
 			r.labelCache = tt.givenLabelCache
 			r.updateLabelCache(gitUrl, givenLabelToUpdate)
 
@@ -200,7 +201,7 @@ func TestGhRemote_removeLabelFromCache(t *testing.T) {
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()))
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), cfg.NewDefaultConfig())
 			r.labelCache = tt.givenLabelCache
 			r.removeLabelFromCache(gitUrl, givenLabelToRemove)
 
@@ -210,3 +211,48 @@ func TestGhRemote_removeLabelFromCache(t *testing.T) {
 		})
 	}
 }
+
+func TestGhRemote_findCachedPreviousLabel(t *testing.T) {
+	u, err := url.Parse("https://github.com/ccremer/greposync")
+	require.NoError(t, err)
+	gitUrl := domain.FromURL(u)
+
+	tests := map[string]struct {
+		givenLabelCache map[*domain.GitURL][]*github.Label
+		givenLabel      domain.Label
+		expectedName    string
+		expectedFound   bool
+	}{
+		"GivenNoPreviousNames_ThenExpectNotFound": {
+			givenLabelCache: map[*domain.GitURL][]*github.Label{
+				gitUrl: {newGitHubLabel("bug", "", "ffffff")},
+			},
+			givenLabel: domain.Label{Name: "kind/bug"},
+		},
+		"GivenPreviousNameInCache_ThenExpectPreviousLabel": {
+			givenLabelCache: map[*domain.GitURL][]*github.Label{
+				gitUrl: {newGitHubLabel("bug", "", "ffffff")},
+			},
+			givenLabel:    domain.Label{Name: "kind/bug", RenamedFrom: []string{"defect", "bug"}},
+			expectedName:  "bug",
+			expectedFound: true,
+		},
+		"GivenPreviousNameNotInCache_ThenExpectNotFound": {
+			givenLabelCache: map[*domain.GitURL][]*github.Label{
+				gitUrl: {newGitHubLabel("feature", "", "ffffff")},
+			},
+			givenLabel: domain.Label{Name: "kind/bug", RenamedFrom: []string{"bug"}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewRemote(NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()), cfg.NewDefaultConfig())
+			r.labelCache = tt.givenLabelCache
+			result, found := r.findCachedPreviousLabel(gitUrl, tt.givenLabel)
+			assert.Equal(t, tt.expectedFound, found)
+			if tt.expectedFound {
+				assert.Equal(t, tt.expectedName, result.GetName())
+			}
+		})
+	}
+}
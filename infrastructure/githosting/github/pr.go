@@ -14,16 +14,25 @@ func (r *GhRemote) FindPullRequest(repository *domain.GitRepository) (*domain.Pu
 	if err != nil {
 		return nil, err
 	}
+	r.m.Lock()
 	if pr != nil {
-		r.prCache[*pr.Number] = pr
+		r.prCache[repository.URL] = pr
+	} else {
+		delete(r.prCache, repository.URL)
 	}
+	delete(r.reviewCache, repository.URL)
+	r.m.Unlock()
 	converted := PrConverter{}.ConvertToEntity(pr)
 	return converted, nil
 }
 
 func (r *GhRemote) findExistingPr(repository *domain.GitRepository) (*github.PullRequest, error) {
-	list, _, err := r.client.PullRequests.List(context.Background(), repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), &github.PullRequestListOptions{
-		Head: fmt.Sprintf("%s:%s", repository.URL.GetNamespace(), repository.CommitBranch),
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return nil, err
+	}
+	list, _, err := client.PullRequests.List(context.Background(), repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), &github.PullRequestListOptions{
+		Head: headRef(repository, repository.CommitBranch),
 	})
 	if err != nil {
 		return nil, err
@@ -34,33 +43,69 @@ func (r *GhRemote) findExistingPr(repository *domain.GitRepository) (*github.Pul
 	return nil, r.instrumentation.noPrFound(repository)
 }
 
+// headRef returns the given branch in the `owner:branch` format, where owner is the namespace of the fork if the repository is forked.
+func headRef(repository *domain.GitRepository, branch string) string {
+	owner := repository.URL.GetNamespace()
+	if repository.IsForked() {
+		owner = repository.ForkURL.GetNamespace()
+	}
+	return fmt.Sprintf("%s:%s", owner, branch)
+}
+
 func (r *GhRemote) EnsurePullRequest(repository *domain.GitRepository, pr *domain.PullRequest) error {
-	converted := PrConverter{}.ConvertFromEntity(pr)
-	cached, exists := r.prCache[converted.GetNumber()]
-	if !exists {
+	r.m.Lock()
+	cached, exists := r.prCache[repository.URL]
+	r.m.Unlock()
+	if !exists || pr.GetNumber() == nil {
 		return r.createNewPr(repository, pr)
 	}
 	return r.updateExistingPr(repository, cached, pr)
 }
 
 func (r *GhRemote) updateExistingPr(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) error {
-	if r.canSkipDescriptionUpdate(cached, pr) && r.canSkipLabelUpdate(cached, pr) {
+	skipReviewers, err := r.canSkipReviewersUpdate(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	if r.canSkipDescriptionUpdate(cached, pr) && r.canSkipLabelUpdate(cached, pr) &&
+		skipReviewers && r.canSkipAssigneesUpdate(cached, pr) &&
+		r.canSkipDraftUpdate(cached, pr) && r.canSkipAutoMergeUpdate(cached, pr) {
 		return r.instrumentation.prIsUpToDate(repository, cached)
 	}
-	err := r.updatePrDescription(repository, cached, pr)
+	err = r.updatePrDescription(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	err = r.updatePrLabels(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	err = r.updatePrReviewers(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	err = r.updatePrAssignees(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	err = r.markPrReady(repository, cached, pr)
 	if err != nil {
 		return err
 	}
-	return r.updatePrLabels(repository, cached, pr)
+	return r.updateAutoMerge(repository, cached, pr)
 }
 
 func (r *GhRemote) updatePrDescription(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) error {
 	if r.canSkipDescriptionUpdate(cached, pr) {
 		return nil
 	}
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
 	cached.Title = github.String(pr.GetTitle())
 	cached.Body = github.String(pr.GetBody())
-	ghPr, _, err := r.client.PullRequests.Edit(context.Background(), repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), *cached.Number, cached)
+	ghPr, _, err := client.PullRequests.Edit(context.Background(), repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), *cached.Number, cached)
 	return r.instrumentation.prUpdated(repository, ghPr, err)
 }
 
@@ -68,9 +113,7 @@ func (r *GhRemote) updatePrLabels(repository *domain.GitRepository, cached *gith
 	if r.canSkipLabelUpdate(cached, pr) {
 		return nil
 	}
-	current := LabelSetConverter{}.ConvertToEntity(cached.Labels)
-	merged := current.Merge(pr.GetLabels())
-	err := r.setLabelsToPr(repository.URL, cached, merged)
+	err := r.setLabelsToPr(repository.URL, cached, pr.GetLabels())
 	return r.instrumentation.prLabelsUpdated(repository, pr, err)
 }
 
@@ -82,8 +125,7 @@ func (r *GhRemote) canSkipDescriptionUpdate(cached *github.PullRequest, pr *doma
 
 func (r *GhRemote) canSkipLabelUpdate(cached *github.PullRequest, pr *domain.PullRequest) bool {
 	converted := LabelSetConverter{}.ConvertToEntity(cached.Labels)
-	diff := pr.GetLabels().Without(converted)
-	return len(diff) == 0
+	return converted.Equals(pr.GetLabels())
 }
 
 // createNewPr makes a new pull request in GitHub.
@@ -95,9 +137,20 @@ func (r *GhRemote) createNewPr(repository *domain.GitRepository, pr *domain.Pull
 		Base:                &pr.BaseBranch,
 		Body:                github.String(pr.GetBody()),
 		MaintainerCanModify: github.Bool(true),
+		Draft:               github.Bool(pr.IsDraft()),
+	}
+	if repository.IsForked() {
+		// Cross-repository pull requests need the owner of the fork in the head.
+		// Maintainer edits aren't supported for forks owned by organizations, so the default of GitHub applies.
+		newPR.Head = github.String(headRef(repository, pr.CommitBranch))
+		newPR.MaintainerCanModify = nil
 	}
 
-	ghPr, _, err := r.client.PullRequests.Create(context.Background(), repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), newPR)
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
+	ghPr, _, err := client.PullRequests.Create(context.Background(), repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), newPR)
 	if err != nil {
 		if strings.Contains(err.Error(), "No commits between") {
 			return r.instrumentation.prNotCreatedBecauseNoCommits(repository, pr)
@@ -112,16 +165,34 @@ func (r *GhRemote) createNewPr(repository *domain.GitRepository, pr *domain.Pull
 		}
 	}
 
+	// A new pull request has no reviews yet.
+	r.m.Lock()
+	r.reviewCache[repository.URL] = []string{}
+	r.m.Unlock()
+	if err := r.updatePrReviewers(repository, ghPr, pr); err != nil {
+		return err
+	}
+	if err := r.updatePrAssignees(repository, ghPr, pr); err != nil {
+		return err
+	}
+	if err := r.enableAutoMerge(repository, ghPr, pr); err != nil {
+		return err
+	}
+
 	r.instrumentation.prCreated(repository, ghPr.GetHTMLURL())
 	return nil
 }
 
 func (r *GhRemote) setLabelsToPr(url *domain.GitURL, ghPr *github.PullRequest, set domain.LabelSet) error {
+	client, err := r.clientFor(url)
+	if err != nil {
+		return err
+	}
 	var labelArr = make([]string, len(set))
 	for i := range set {
 		labelArr[i] = set[i].Name
 	}
-	labels, _, err := r.client.Issues.ReplaceLabelsForIssue(context.Background(), url.GetNamespace(), url.GetRepositoryName(), ghPr.GetNumber(), labelArr)
+	labels, _, err := client.Issues.ReplaceLabelsForIssue(context.Background(), url.GetNamespace(), url.GetRepositoryName(), ghPr.GetNumber(), labelArr)
 	ghPr.Labels = labels
 	return err
 }
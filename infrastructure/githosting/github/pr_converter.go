@@ -18,6 +18,16 @@ func (c PrConverter) ConvertToEntity(pr *github.PullRequest) *domain.PullRequest
 	// We don't expect invalid colors if coming from a repository, but that's just an assumption
 
 	entity, _ := domain.NewPullRequest(domain.NewPullRequestNumber(pr.Number), *pr.Title, *pr.Body, *pr.Head.Ref, *pr.Base.Ref, set)
+	teams := make([]string, len(pr.RequestedTeams))
+	for i, team := range pr.RequestedTeams {
+		teams[i] = team.GetSlug()
+	}
+	_ = entity.RequestReviews(userLogins(pr.RequestedReviewers), teams)
+	_ = entity.AssignTo(userLogins(pr.Assignees))
+	_ = entity.SetDraft(pr.GetDraft())
+	if pr.AutoMerge != nil {
+		_ = entity.EnableAutoMerge(domain.MergeMethod(pr.AutoMerge.GetMergeMethod()))
+	}
 
 	return entity
 }
@@ -33,6 +43,7 @@ func (c PrConverter) ConvertFromEntity(entity *domain.PullRequest) *github.PullR
 		Title:  github.String(entity.GetTitle()),
 		Body:   github.String(entity.GetBody()),
 		Labels: LabelSetConverter{}.ConvertFromEntity(entity.GetLabels()),
+		Draft:  github.Bool(entity.IsDraft()),
 	}
 	return pr
 }
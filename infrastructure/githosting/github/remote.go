@@ -2,24 +2,29 @@ package github
 
 import (
 	"context"
-	"os"
 	"sync"
-	"time"
 
+	"github.com/ccremer/greposync/cfg"
 	"github.com/ccremer/greposync/domain"
 	"github.com/ccremer/greposync/infrastructure/githosting"
 	"github.com/google/go-github/v39/github"
-	"golang.org/x/oauth2"
 )
 
 type (
 	// GhRemote contains the methods and data to interact with the GitHub API.
 	GhRemote struct {
-		client          *github.Client
-		ctx             context.Context
+		clients       map[string]*clientCall
+		installations map[string]*installationCall
+		// clientsMutex guards clients and installations.
+		clientsMutex *sync.Mutex
+		config       *cfg.Configuration
+		ctx          context.Context
+		// m guards the caches.
 		m               *sync.Mutex
-		prCache         map[int]*github.PullRequest
+		prCache         map[*domain.GitURL]*github.PullRequest
+		reviewCache     map[*domain.GitURL][]string
 		labelCache      map[*domain.GitURL][]*github.Label
+		protectionCache map[*domain.GitURL]*ghRepositoryProtection
 		instrumentation *GitHubInstrumentation
 	}
 )
@@ -28,38 +33,37 @@ type (
 const ProviderKey githosting.RemoteProvider = "github"
 
 // NewRemote returns a new GitHub provider instance.
-func NewRemote(instrumentation *GitHubInstrumentation) *GhRemote {
+// The API clients are created lazily per host once the configuration has been parsed.
+func NewRemote(instrumentation *GitHubInstrumentation, config *cfg.Configuration) *GhRemote {
 	ctx := context.Background()
 	provider := &GhRemote{
 		m:               &sync.Mutex{},
 		ctx:             ctx,
-		client:          createClient(os.Getenv("GITHUB_TOKEN"), ctx),
-		prCache:         map[int]*github.PullRequest{},
+		config:          config,
+		clients:         map[string]*clientCall{},
+		installations:   map[string]*installationCall{},
+		clientsMutex:    &sync.Mutex{},
+		prCache:         map[*domain.GitURL]*github.PullRequest{},
+		reviewCache:     map[*domain.GitURL][]string{},
 		labelCache:      map[*domain.GitURL][]*github.Label{},
+		protectionCache: map[*domain.GitURL]*ghRepositoryProtection{},
 		instrumentation: instrumentation,
 	}
 	return provider
 }
 
-func createClient(token string, ctx context.Context) *github.Client {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-
-	client := github.NewClient(tc)
-	return client
-}
-
+// HasSupportFor implements githosting.Remote.
+// It returns true if the host of the given URL is github.com or one of the configured GitHub Enterprise Server hosts.
 func (r *GhRemote) HasSupportFor(url *domain.GitURL) bool {
-	return url.Host == "github.com"
+	return r.findHostConfig(url.Host) != nil
 }
 
-// delayedUnlock sleeps one second for abuse rate limit best-practice and releases the lock.
-//
-// https://docs.github.com/en/rest/guides/best-practices-for-integrators#dealing-with-abuse-rate-limits
-// "If you're making a large number of POST, PATCH, PUT, or DELETE requests for a single user or client ID, wait at least one second between each request."
-func (r *GhRemote) delayedUnlock() {
-	time.Sleep(1 * time.Second)
-	r.m.Unlock()
+// HasCapability implements githosting.Remote.
+// All optional capabilities are supported for GitHub.
+func (r *GhRemote) HasCapability(capability githosting.Capability) bool {
+	switch capability {
+	case githosting.CapabilityRepositorySettings, githosting.CapabilityBranchProtection, githosting.CapabilityRepositoryDiscovery, githosting.CapabilityFork:
+		return true
+	}
+	return false
 }
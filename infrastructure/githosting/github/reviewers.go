@@ -0,0 +1,143 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+)
+
+func (r *GhRemote) canSkipReviewersUpdate(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) (bool, error) {
+	users, teams, err := r.findMissingReviewers(repository, cached, pr)
+	return len(users) == 0 && len(teams) == 0, err
+}
+
+func (r *GhRemote) canSkipAssigneesUpdate(cached *github.PullRequest, pr *domain.PullRequest) bool {
+	return len(missingNames(pr.GetAssignees(), userLogins(cached.Assignees))) == 0
+}
+
+// findMissingReviewers returns the reviewers and team reviewers that aren't requested yet.
+// The author of the pull request cannot review its own pull request and is ignored.
+// GitHub removes users from the requested reviewers once they submitted a review, those users are not requested again.
+func (r *GhRemote) findMissingReviewers(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) (users []string, teams []string, err error) {
+	requested := append(userLogins(cached.RequestedReviewers), cached.GetUser().GetLogin())
+	requestedTeams := make([]string, len(cached.RequestedTeams))
+	for i, team := range cached.RequestedTeams {
+		requestedTeams[i] = team.GetSlug()
+	}
+	users, teams = missingNames(pr.GetReviewers(), requested), missingNames(pr.GetTeamReviewers(), requestedTeams)
+	if len(users) > 0 && cached.GetNumber() > 0 {
+		var reviewed []string
+		reviewed, err = r.fetchReviewers(repository, cached)
+		if err != nil {
+			return nil, nil, err
+		}
+		users = missingNames(users, reviewed)
+	}
+	return users, teams, nil
+}
+
+// updatePrReviewers requests the reviews that aren't requested yet.
+func (r *GhRemote) updatePrReviewers(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) error {
+	users, teams, err := r.findMissingReviewers(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	return r.requestReviewers(repository, cached, users, teams)
+}
+
+func (r *GhRemote) requestReviewers(repository *domain.GitRepository, ghPr *github.PullRequest, users, teams []string) error {
+	if len(users) == 0 && len(teams) == 0 {
+		return nil
+	}
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
+	updated, _, err := client.PullRequests.RequestReviewers(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), ghPr.GetNumber(), github.ReviewersRequest{
+		Reviewers:     users,
+		TeamReviewers: teams,
+	})
+	if err == nil {
+		ghPr.RequestedReviewers = updated.RequestedReviewers
+		ghPr.RequestedTeams = updated.RequestedTeams
+	}
+	return r.instrumentation.prReviewersRequested(repository, users, teams, err)
+}
+
+// fetchReviewers returns the logins of the users that submitted a review to the given pull request.
+// The reviews are fetched once per pull request and cached until the pull request is looked up again.
+func (r *GhRemote) fetchReviewers(repository *domain.GitRepository, ghPr *github.PullRequest) ([]string, error) {
+	r.m.Lock()
+	cachedLogins, exists := r.reviewCache[repository.URL]
+	r.m.Unlock()
+	if exists {
+		return cachedLogins, nil
+	}
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return nil, err
+	}
+	nextPage := 1
+	var logins []string
+	for repeat := true; repeat; repeat = nextPage > 0 {
+		reviews, resp, err := client.PullRequests.ListReviews(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), ghPr.GetNumber(), &github.ListOptions{
+			Page:    nextPage,
+			PerPage: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range reviews {
+			logins = append(logins, review.GetUser().GetLogin())
+		}
+		nextPage = resp.NextPage
+	}
+	r.m.Lock()
+	r.reviewCache[repository.URL] = logins
+	r.m.Unlock()
+	return logins, nil
+}
+
+func (r *GhRemote) updatePrAssignees(repository *domain.GitRepository, cached *github.PullRequest, pr *domain.PullRequest) error {
+	missing := missingNames(pr.GetAssignees(), userLogins(cached.Assignees))
+	if len(missing) == 0 {
+		return nil
+	}
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
+	issue, _, err := client.Issues.AddAssignees(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), cached.GetNumber(), missing)
+	if err == nil {
+		cached.Assignees = issue.Assignees
+	}
+	return r.instrumentation.prAssigneesAdded(repository, missing, err)
+}
+
+func userLogins(users []*github.User) []string {
+	logins := make([]string, len(users))
+	for i, user := range users {
+		logins[i] = user.GetLogin()
+	}
+	return logins
+}
+
+// missingNames returns the names of desired that aren't in existing.
+// Logins and slugs in GitHub are case-insensitive.
+func missingNames(desired, existing []string) []string {
+	missing := make([]string, 0)
+	for _, name := range desired {
+		found := false
+		for _, other := range existing {
+			if strings.EqualFold(name, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGhRemote_findMissingReviewers(t *testing.T) {
+	tests := map[string]struct {
+		givenPr           *github.PullRequest
+		givenReviewed     []string
+		givenReviewers    []string
+		givenTeams        []string
+		expectedReviewers []string
+		expectedTeams     []string
+	}{
+		"GivenNoReviewers_ThenExpectEmpty": {
+			givenPr:           &github.PullRequest{},
+			expectedReviewers: []string{},
+			expectedTeams:     []string{},
+		},
+		"GivenRequestedReviewers_WhenDifferentCase_ThenExpectEmpty": {
+			givenPr: &github.PullRequest{
+				RequestedReviewers: []*github.User{{Login: github.String("Alice")}},
+				RequestedTeams:     []*github.Team{{Slug: github.String("devs")}},
+			},
+			givenReviewers:    []string{"alice"},
+			givenTeams:        []string{"Devs"},
+			expectedReviewers: []string{},
+			expectedTeams:     []string{},
+		},
+		"GivenAuthorAsReviewer_ThenExpectAuthorIgnored": {
+			givenPr: &github.PullRequest{
+				User: &github.User{Login: github.String("bot")},
+			},
+			givenReviewers:    []string{"bot", "bob"},
+			givenTeams:        []string{"ops"},
+			expectedReviewers: []string{"bob"},
+			expectedTeams:     []string{"ops"},
+		},
+		"GivenRequestedUserAlreadyReviewed_ThenExpectEmpty": {
+			givenPr: &github.PullRequest{
+				Number: github.Int(1),
+			},
+			givenReviewed:     []string{"Alice"},
+			givenReviewers:    []string{"alice"},
+			expectedReviewers: []string{},
+			expectedTeams:     []string{},
+		},
+		"GivenOtherUserReviewed_ThenExpectMissingReviewer": {
+			givenPr: &github.PullRequest{
+				Number: github.Int(1),
+			},
+			givenReviewed:     []string{"alice"},
+			givenReviewers:    []string{"alice", "bob"},
+			expectedReviewers: []string{"bob"},
+			expectedTeams:     []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pr, err := domain.NewPullRequest(nil, "title", "", "commit", "base", nil)
+			require.NoError(t, err)
+			require.NoError(t, pr.RequestReviews(tt.givenReviewers, tt.givenTeams))
+			repository := domain.NewGitRepository(&domain.GitURL{Scheme: "https", Host: "github.com", Path: "/owner/repository"}, "")
+
+			r := &GhRemote{
+				m:           &sync.Mutex{},
+				reviewCache: map[*domain.GitURL][]string{repository.URL: tt.givenReviewed},
+			}
+			users, teams, err := r.findMissingReviewers(repository, tt.givenPr, pr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedReviewers, users)
+			assert.Equal(t, tt.expectedTeams, teams)
+		})
+	}
+}
+
+func TestGhRemote_canSkipReviewersUpdate(t *testing.T) {
+	pr, err := domain.NewPullRequest(nil, "title", "", "commit", "base", nil)
+	require.NoError(t, err)
+	require.NoError(t, pr.RequestReviews([]string{"alice"}, nil))
+	repository := domain.NewGitRepository(&domain.GitURL{Scheme: "https", Host: "github.com", Path: "/owner/repository"}, "")
+	r := &GhRemote{
+		m:           &sync.Mutex{},
+		reviewCache: map[*domain.GitURL][]string{repository.URL: {"alice"}},
+	}
+
+	// GitHub removed alice from the requested reviewers after submitting the review.
+	skip, err := r.canSkipReviewersUpdate(repository, &github.PullRequest{Number: github.Int(1)}, pr)
+	require.NoError(t, err)
+	assert.True(t, skip)
+}
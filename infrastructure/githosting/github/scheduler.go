@@ -0,0 +1,231 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// mutationInterval is the minimum duration between two mutating requests to the same repository.
+	//
+	// https://docs.github.com/en/rest/guides/best-practices-for-integrators#dealing-with-secondary-rate-limits
+	// "If you're making a large number of POST, PATCH, PUT, or DELETE requests for a single user or client ID, wait at least one second between each request."
+	mutationInterval = 1 * time.Second
+	// secondaryLimitBackoff is the initial waiting duration if a secondary rate limit is hit without a Retry-After header.
+	// It is doubled with each retry.
+	secondaryLimitBackoff = 1 * time.Minute
+	// maxRetries is the number of retries for a request that has been rejected due to rate limiting.
+	maxRetries = 3
+)
+
+// scheduler is a http.RoundTripper that schedules requests according to GitHub's rate limits.
+//
+// Mutating requests are spaced per repository, so that independent repositories proceed in parallel.
+// If the primary rate limit is exhausted or a secondary rate limit is hit, all requests of the client are paused until the limit resets and rejected requests are retried.
+type scheduler struct {
+	base            http.RoundTripper
+	host            string
+	instrumentation *GitHubInstrumentation
+
+	m            *sync.Mutex
+	blockedUntil time.Time
+	nextMutation map[string]time.Time
+
+	mutationInterval time.Duration
+	backoff          time.Duration
+	maxRetries       int
+	now              func() time.Time
+}
+
+func newScheduler(base http.RoundTripper, host string, instrumentation *GitHubInstrumentation) *scheduler {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &scheduler{
+		base:             base,
+		host:             host,
+		instrumentation:  instrumentation,
+		m:                &sync.Mutex{},
+		nextMutation:     map[string]time.Time{},
+		mutationInterval: mutationInterval,
+		backoff:          secondaryLimitBackoff,
+		maxRetries:       maxRetries,
+		now:              time.Now,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (s *scheduler) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := s.sleep(req.Context(), s.reserve(req)); err != nil {
+			return nil, err
+		}
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.base.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+		s.updateQuota(resp)
+		wait, limited := s.retryAfter(resp, attempt)
+		if !limited || attempt >= s.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			hideQuotaReset(resp)
+			return resp, nil
+		}
+		// Discard the rejected response before retrying.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		s.block(wait)
+		s.instrumentation.rateLimitExceeded(s.host, wait, attempt+1)
+	}
+}
+
+// reserve returns the duration that the given request has to wait before it can be sent.
+// For mutating requests, it reserves the next free slot of the repository.
+func (s *scheduler) reserve(req *http.Request) time.Duration {
+	s.m.Lock()
+	defer s.m.Unlock()
+	now := s.now()
+	start := now
+	if s.blockedUntil.After(start) {
+		start = s.blockedUntil
+	}
+	if isMutation(req.Method) {
+		key := repositoryKey(req.URL.Path)
+		if next := s.nextMutation[key]; next.After(start) {
+			start = next
+		}
+		s.nextMutation[key] = start.Add(s.mutationInterval)
+	}
+	return start.Sub(now)
+}
+
+// block pauses all requests for the given duration.
+func (s *scheduler) block(d time.Duration) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	until := s.now().Add(d)
+	if until.After(s.blockedUntil) {
+		s.blockedUntil = until
+	}
+}
+
+// updateQuota reads the primary rate limit headers.
+// If the quota is exhausted, all further requests are paused until the quota resets.
+func (s *scheduler) updateQuota(resp *http.Response) {
+	limit, errLimit := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	remaining, errRemaining := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	reset, errReset := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if errLimit != nil || errRemaining != nil || errReset != nil {
+		return
+	}
+	resetTime := time.Unix(reset, 0)
+	s.instrumentation.rateLimitQuota(s.host, limit, remaining, resetTime)
+	if remaining == 0 {
+		s.block(resetTime.Sub(s.now()))
+	}
+}
+
+// hideQuotaReset removes the reset time from the response if the primary rate limit is exhausted.
+// Otherwise go-github rejects all further requests with a github.RateLimitError until the reset, without passing them to the scheduler.
+// The scheduler pauses these requests until the reset instead, see updateQuota.
+func hideQuotaReset(resp *http.Response) {
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		resp.Header.Del("X-RateLimit-Reset")
+	}
+}
+
+// retryAfter returns the duration to wait before retrying and true, if the response has been rejected due to rate limiting.
+func (s *scheduler) retryAfter(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			wait := time.Unix(reset, 0).Sub(s.now())
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+	if isSecondaryRateLimit(resp) {
+		return s.backoff << attempt, true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit returns true if the response body indicates a secondary rate limit.
+// The body is buffered so that it can still be read by the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	msg := strings.ToLower(string(body))
+	return strings.Contains(msg, "secondary rate limit") || strings.Contains(msg, "abuse detection")
+}
+
+func (s *scheduler) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rewindRequest returns a copy of the request with a fresh body for retries.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// repositoryKey returns the `owner/repo` part of a REST API path like `/api/v3/repos/owner/repo/labels`.
+// Returns an empty string if the path isn't repository-specific.
+func repositoryKey(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+2 < len(segments); i++ {
+		if segments[i] == "repos" {
+			return segments[i+1] + "/" + segments[i+2]
+		}
+	}
+	return ""
+}
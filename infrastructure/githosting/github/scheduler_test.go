@@ -0,0 +1,262 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_RoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		givenResponses    []func(w http.ResponseWriter)
+		expectedCalls     int
+		expectedStatus    int
+		expectedBody      string
+		expectedRetryBody string
+	}{
+		"GivenSuccessfulResponse_ThenExpectSingleCall": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWith(http.StatusOK, nil, "ok"),
+			},
+			expectedCalls:  1,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		"GivenRetryAfterHeader_ThenExpectRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWith(http.StatusForbidden, map[string]string{"Retry-After": "0"}, "limited"),
+				respondWith(http.StatusOK, nil, "ok"),
+			},
+			expectedCalls:  2,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		"GivenSecondaryRateLimit_WhenNoRetryAfterHeader_ThenExpectRetryWithBackoff": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWith(http.StatusForbidden, nil, `{"message": "You have exceeded a secondary rate limit."}`),
+				respondWith(http.StatusCreated, nil, "ok"),
+			},
+			expectedCalls:  2,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   "ok",
+		},
+		"GivenPrimaryRateLimitExhausted_ThenExpectRetryAfterReset": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWith(http.StatusForbidden, map[string]string{
+					"X-RateLimit-Limit":     "5000",
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Unix(), 10),
+				}, "limited"),
+				respondWith(http.StatusOK, nil, "ok"),
+			},
+			expectedCalls:  2,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		"GivenPersistentSecondaryRateLimit_ThenExpectLastResponseAfterMaxRetries": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWith(http.StatusForbidden, nil, "secondary rate limit"),
+				respondWith(http.StatusForbidden, nil, "secondary rate limit"),
+				respondWith(http.StatusForbidden, nil, "secondary rate limit"),
+				respondWith(http.StatusForbidden, nil, "secondary rate limit"),
+			},
+			expectedCalls:  4,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "secondary rate limit",
+		},
+		"GivenForbidden_WhenNotRateLimited_ThenExpectNoRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWith(http.StatusForbidden, nil, "Resource not accessible by integration"),
+			},
+			expectedCalls:  1,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "Resource not accessible by integration",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "payload", string(body), "expected request body in each attempt")
+				require.Less(t, calls, len(tt.givenResponses), "unexpected request")
+				tt.givenResponses[calls](w)
+				calls++
+			}))
+			defer server.Close()
+
+			s := newScheduler(nil, "github.com", NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()))
+			s.backoff = time.Millisecond
+			s.mutationInterval = 0
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/repos/owner/repo/labels", strings.NewReader("payload"))
+			require.NoError(t, err)
+
+			resp, err := s.RoundTrip(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedBody, string(body))
+		})
+	}
+}
+
+func TestScheduler_reserve(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		givenPreviousRequests []string
+		givenBlockedUntil     time.Time
+		givenRequest          string
+		expectedWait          time.Duration
+	}{
+		"GivenNoPreviousRequest_ThenExpectNoWait": {
+			givenRequest: "POST /repos/owner/repo/labels",
+			expectedWait: 0,
+		},
+		"GivenPreviousMutation_WhenSameRepository_ThenExpectWait": {
+			givenPreviousRequests: []string{"POST /repos/owner/repo/labels"},
+			givenRequest:          "PATCH /repos/owner/repo/labels/bug",
+			expectedWait:          time.Second,
+		},
+		"GivenPreviousMutations_WhenSameRepository_ThenExpectQueuedWait": {
+			givenPreviousRequests: []string{"POST /repos/owner/repo/labels", "POST /repos/owner/repo/labels"},
+			givenRequest:          "DELETE /repos/owner/repo/labels/bug",
+			expectedWait:          2 * time.Second,
+		},
+		"GivenPreviousMutation_WhenOtherRepository_ThenExpectNoWait": {
+			givenPreviousRequests: []string{"POST /repos/owner/repo/labels"},
+			givenRequest:          "POST /repos/owner/other/labels",
+			expectedWait:          0,
+		},
+		"GivenPreviousMutation_WhenReadRequest_ThenExpectNoWait": {
+			givenPreviousRequests: []string{"POST /repos/owner/repo/labels"},
+			givenRequest:          "GET /repos/owner/repo/labels",
+			expectedWait:          0,
+		},
+		"GivenBlocked_WhenReadRequest_ThenExpectWaitUntilUnblocked": {
+			givenBlockedUntil: now.Add(time.Minute),
+			givenRequest:      "GET /repos/owner/repo/labels",
+			expectedWait:      time.Minute,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScheduler(nil, "github.com", NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()))
+			s.now = func() time.Time { return now }
+			s.blockedUntil = tt.givenBlockedUntil
+			for _, previous := range tt.givenPreviousRequests {
+				s.reserve(newRequest(t, previous))
+			}
+			result := s.reserve(newRequest(t, tt.givenRequest))
+			assert.Equal(t, tt.expectedWait, result)
+		})
+	}
+}
+
+func TestScheduler_updateQuota(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		givenRemaining      string
+		expectedBlockedTill time.Time
+	}{
+		"GivenRemainingQuota_ThenExpectNotBlocked": {
+			givenRemaining: "10",
+		},
+		"GivenExhaustedQuota_ThenExpectBlockedUntilReset": {
+			givenRemaining:      "0",
+			expectedBlockedTill: now.Add(10 * time.Minute),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScheduler(nil, "github.com", NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()))
+			s.now = func() time.Time { return now }
+			resp := &http.Response{Header: http.Header{}}
+			resp.Header.Set("X-RateLimit-Limit", "5000")
+			resp.Header.Set("X-RateLimit-Remaining", tt.givenRemaining)
+			resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10))
+			s.updateQuota(resp)
+			assert.Equal(t, tt.expectedBlockedTill.Unix(), s.blockedUntil.Unix())
+		})
+	}
+}
+
+func TestScheduler_WithGitHubClient(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(1-calls))
+		// The quota resets within the next second.
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix()+1, 10))
+		_, _ = fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+	s := newScheduler(nil, "github.example.com", NewGitHubInstrumentation(loggingtest.NewDiscardLoggerFactory()))
+	client, err := github.NewEnterpriseClient(server.URL, server.URL, &http.Client{Transport: s})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.Issues.ListLabels(context.Background(), "owner", "repository", nil)
+		require.NoError(t, err, "request %d", i+1)
+	}
+	assert.Equal(t, 2, calls, "expected the second request to wait for the reset instead of failing")
+	assert.False(t, s.blockedUntil.IsZero(), "expected the scheduler to pause until the reset")
+}
+
+func TestRepositoryKey(t *testing.T) {
+	tests := map[string]struct {
+		givenPath      string
+		expectedResult string
+	}{
+		"GivenPublicAPIPath_ThenExpectOwnerAndRepo": {
+			givenPath:      "/repos/owner/repo/labels",
+			expectedResult: "owner/repo",
+		},
+		"GivenEnterprisePath_ThenExpectOwnerAndRepo": {
+			givenPath:      "/api/v3/repos/owner/repo/pulls/1",
+			expectedResult: "owner/repo",
+		},
+		"GivenNonRepositoryPath_ThenExpectEmpty": {
+			givenPath:      "/app/installations/1/access_tokens",
+			expectedResult: "",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := repositoryKey(tt.givenPath)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func respondWith(status int, headers map[string]string, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for key, value := range headers {
+			w.Header().Set(key, value)
+		}
+		w.WriteHeader(status)
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+func newRequest(t *testing.T, methodAndPath string) *http.Request {
+	parts := strings.SplitN(methodAndPath, " ", 2)
+	req, err := http.NewRequest(parts[0], "https://api.github.com"+parts[1], nil)
+	require.NoError(t, err)
+	return req
+}
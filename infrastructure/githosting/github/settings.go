@@ -0,0 +1,66 @@
+package github
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+)
+
+// FetchSettings implements githosting.SettingsRemote.
+func (r *GhRemote) FetchSettings(repository *domain.GitRepository) (domain.RepositorySettings, error) {
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return domain.RepositorySettings{}, err
+	}
+	ghRepo, _, err := client.Repositories.Get(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName())
+	if err != nil {
+		return domain.RepositorySettings{}, err
+	}
+	return r.convertSettings(ghRepo), nil
+}
+
+// UpdateSettings implements githosting.SettingsRemote.
+func (r *GhRemote) UpdateSettings(repository *domain.GitRepository, settings domain.RepositorySettings) error {
+	client, err := r.clientFor(repository.URL)
+	if err != nil {
+		return err
+	}
+	edit := &github.Repository{
+		Description:         settings.Description,
+		Homepage:            settings.Homepage,
+		AllowMergeCommit:    settings.AllowMergeCommit,
+		AllowSquashMerge:    settings.AllowSquashMerge,
+		AllowRebaseMerge:    settings.AllowRebaseMerge,
+		DeleteBranchOnMerge: settings.DeleteBranchOnMerge,
+		HasWiki:             settings.HasWiki,
+		HasProjects:         settings.HasProjects,
+	}
+	if settings.Topics == nil || len(settings.Fields()) > 1 {
+		_, _, err = client.Repositories.Edit(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), edit)
+		if err != nil {
+			return err
+		}
+	}
+	if settings.Topics != nil {
+		// Topics can't be changed by editing the repository.
+		_, _, err = client.Repositories.ReplaceAllTopics(r.ctx, repository.URL.GetNamespace(), repository.URL.GetRepositoryName(), settings.Topics)
+	}
+	return r.instrumentation.updatedSettings(repository, settings, err)
+}
+
+func (r *GhRemote) convertSettings(ghRepo *github.Repository) domain.RepositorySettings {
+	topics := ghRepo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return domain.RepositorySettings{
+		Description:         github.String(ghRepo.GetDescription()),
+		Homepage:            github.String(ghRepo.GetHomepage()),
+		Topics:              topics,
+		AllowMergeCommit:    github.Bool(ghRepo.GetAllowMergeCommit()),
+		AllowSquashMerge:    github.Bool(ghRepo.GetAllowSquashMerge()),
+		AllowRebaseMerge:    github.Bool(ghRepo.GetAllowRebaseMerge()),
+		DeleteBranchOnMerge: github.Bool(ghRepo.GetDeleteBranchOnMerge()),
+		HasWiki:             github.Bool(ghRepo.GetHasWiki()),
+		HasProjects:         github.Bool(ghRepo.GetHasProjects()),
+	}
+}
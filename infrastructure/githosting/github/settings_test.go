@@ -0,0 +1,58 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/google/go-github/v39/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGhRemote_convertSettings(t *testing.T) {
+	tests := map[string]struct {
+		givenRepository  *github.Repository
+		expectedSettings domain.RepositorySettings
+	}{
+		"GivenEmptyRepository_ThenExpectAllSettingsSet": {
+			givenRepository: &github.Repository{},
+			expectedSettings: domain.RepositorySettings{
+				Description:         github.String(""),
+				Homepage:            github.String(""),
+				Topics:              []string{},
+				AllowMergeCommit:    github.Bool(false),
+				AllowSquashMerge:    github.Bool(false),
+				AllowRebaseMerge:    github.Bool(false),
+				DeleteBranchOnMerge: github.Bool(false),
+				HasWiki:             github.Bool(false),
+				HasProjects:         github.Bool(false),
+			},
+		},
+		"GivenRepository_ThenExpectSettings": {
+			givenRepository: &github.Repository{
+				Description:      github.String("description"),
+				Homepage:         github.String("https://github.com"),
+				Topics:           []string{"go"},
+				AllowMergeCommit: github.Bool(true),
+				HasWiki:          github.Bool(true),
+			},
+			expectedSettings: domain.RepositorySettings{
+				Description:         github.String("description"),
+				Homepage:            github.String("https://github.com"),
+				Topics:              []string{"go"},
+				AllowMergeCommit:    github.Bool(true),
+				AllowSquashMerge:    github.Bool(false),
+				AllowRebaseMerge:    github.Bool(false),
+				DeleteBranchOnMerge: github.Bool(false),
+				HasWiki:             github.Bool(true),
+				HasProjects:         github.Bool(false),
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &GhRemote{}
+			result := r.convertSettings(tt.givenRepository)
+			assert.Equal(t, tt.expectedSettings, result)
+		})
+	}
+}
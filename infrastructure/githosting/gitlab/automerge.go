@@ -0,0 +1,103 @@
+package gitlab
+
+import (
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/xanzy/go-gitlab"
+)
+
+// draftPrefix marks a merge request as draft in GitLab.
+const draftPrefix = "Draft: "
+
+// mrTitle returns the title of the merge request, which is prefixed with draftPrefix if draft is true.
+func mrTitle(title string, draft bool) string {
+	if draft {
+		return draftPrefix + title
+	}
+	return title
+}
+
+// expectedTitle returns the title that the given existing merge request should have.
+// Merge requests that are ready for review are not converted back to draft.
+func expectedTitle(cached *gitlab.MergeRequest, pr *domain.PullRequest) string {
+	return mrTitle(pr.GetTitle(), pr.IsDraft() && cached.Draft)
+}
+
+// stripDraftPrefix removes the draft prefix from the given title.
+func stripDraftPrefix(title string) string {
+	return strings.TrimPrefix(title, draftPrefix)
+}
+
+// canSkipAutoMergeUpdate returns true if auto-merge is already disabled, or already enabled with the same merge method.
+func (r *GlRemote) canSkipAutoMergeUpdate(cached *gitlab.MergeRequest, pr *domain.PullRequest) bool {
+	if !pr.IsAutoMergeEnabled() {
+		return !cached.MergeWhenPipelineSucceeds
+	}
+	return cached.MergeWhenPipelineSucceeds && cached.Squash == (pr.GetAutoMergeMethod() == domain.MergeMethodSquash)
+}
+
+// updateAutoMerge enables, changes or disables merging the merge request when the pipeline succeeds.
+// If the merge method changes, it is cancelled and enabled again with the new method.
+func (r *GlRemote) updateAutoMerge(repository *domain.GitRepository, cached *gitlab.MergeRequest, pr *domain.PullRequest) error {
+	if r.canSkipAutoMergeUpdate(cached, pr) {
+		return nil
+	}
+	if cached.MergeWhenPipelineSucceeds {
+		if err := r.disableAutoMerge(repository, cached); err != nil || cached.MergeWhenPipelineSucceeds {
+			return err
+		}
+	}
+	return r.enableAutoMerge(repository, cached, pr)
+}
+
+// enableAutoMerge sets the merge request to be merged when the pipeline succeeds.
+// The merge request is not accepted if it has no pipeline, as it would be merged immediately.
+// In that case it's attempted again in the next run.
+// GitLab can only squash the commits, other merge methods use the merge method configured in the project.
+// Failures aren't fatal, since auto-merge depends on project settings and on the state of the merge request, which we can't fix.
+func (r *GlRemote) enableAutoMerge(repository *domain.GitRepository, cached *gitlab.MergeRequest, pr *domain.PullRequest) error {
+	if !pr.IsAutoMergeEnabled() || cached.MergeWhenPipelineSucceeds {
+		return nil
+	}
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	// Merge requests in lists don't contain the pipeline.
+	mr, _, err := client.MergeRequests.GetMergeRequest(r.projectID(repository.URL), cached.IID, nil)
+	if err != nil {
+		return r.instrumentation.mrAutoMergeEnabled(repository, cached, err)
+	}
+	if mr.HeadPipeline == nil {
+		return r.instrumentation.mrAutoMergeSkippedWithoutPipeline(repository, cached)
+	}
+	if pr.GetAutoMergeMethod() == domain.MergeMethodRebase {
+		r.instrumentation.mrAutoMergeMethodNotSupported(repository, cached, pr.GetAutoMergeMethod())
+	}
+	opts := &gitlab.AcceptMergeRequestOptions{
+		MergeWhenPipelineSucceeds: gitlab.Bool(true),
+		SHA:                       gitlab.String(mr.SHA),
+		Squash:                    gitlab.Bool(pr.GetAutoMergeMethod() == domain.MergeMethodSquash),
+	}
+	accepted, _, err := client.MergeRequests.AcceptMergeRequest(r.projectID(repository.URL), cached.IID, opts)
+	if err == nil {
+		cached.MergeWhenPipelineSucceeds = accepted.MergeWhenPipelineSucceeds
+		cached.Squash = accepted.Squash
+	}
+	return r.instrumentation.mrAutoMergeEnabled(repository, cached, err)
+}
+
+// disableAutoMerge cancels merging the merge request when the pipeline succeeds.
+// Failures aren't fatal, the merge request could have been merged or closed in the meantime.
+func (r *GlRemote) disableAutoMerge(repository *domain.GitRepository, cached *gitlab.MergeRequest) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	cancelled, _, err := client.MergeRequests.CancelMergeWhenPipelineSucceeds(r.projectID(repository.URL), cached.IID)
+	if err == nil {
+		cached.MergeWhenPipelineSucceeds = cancelled.MergeWhenPipelineSucceeds
+	}
+	return r.instrumentation.mrAutoMergeDisabled(repository, cached, err)
+}
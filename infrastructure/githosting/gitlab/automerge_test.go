@@ -0,0 +1,138 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestExpectedTitle(t *testing.T) {
+	tests := map[string]struct {
+		givenMr       *gitlab.MergeRequest
+		givenDraft    bool
+		expectedTitle string
+	}{
+		"GivenDraftMr_WhenDraft_ThenExpectDraftPrefix": {
+			givenMr:       &gitlab.MergeRequest{Draft: true},
+			givenDraft:    true,
+			expectedTitle: "Draft: title",
+		},
+		"GivenDraftMr_WhenReady_ThenExpectTitleWithoutPrefix": {
+			givenMr:       &gitlab.MergeRequest{Draft: true},
+			givenDraft:    false,
+			expectedTitle: "title",
+		},
+		"GivenReadyMr_WhenDraft_ThenExpectTitleWithoutPrefix": {
+			givenMr:       &gitlab.MergeRequest{},
+			givenDraft:    true,
+			expectedTitle: "title",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pr, err := domain.NewPullRequest(nil, "title", "", "commit", "base", nil)
+			require.NoError(t, err)
+			require.NoError(t, pr.SetDraft(tt.givenDraft))
+			assert.Equal(t, tt.expectedTitle, expectedTitle(tt.givenMr, pr))
+		})
+	}
+}
+
+func TestGlRemote_updateAutoMerge(t *testing.T) {
+	tests := map[string]struct {
+		givenMr           *gitlab.MergeRequest
+		givenMergeMethod  domain.MergeMethod
+		expectedRequests  []string
+		expectedAutoMerge bool
+		expectedSquash    bool
+	}{
+		"GivenAutoMergeDisabled_WhenDisabled_ThenExpectNoRequest": {
+			givenMr: &gitlab.MergeRequest{IID: 1},
+		},
+		"GivenAutoMergeDisabled_WhenSquash_ThenExpectAccepted": {
+			givenMr:          &gitlab.MergeRequest{IID: 1},
+			givenMergeMethod: domain.MergeMethodSquash,
+			expectedRequests: []string{
+				"GET " + mrPath + "/1",
+				"PUT " + mrPath + "/1/merge",
+			},
+			expectedAutoMerge: true,
+			expectedSquash:    true,
+		},
+		"GivenAutoMergeDisabled_WhenRebase_ThenExpectAcceptedWithoutSquash": {
+			givenMr:          &gitlab.MergeRequest{IID: 1, Squash: true},
+			givenMergeMethod: domain.MergeMethodRebase,
+			expectedRequests: []string{
+				"GET " + mrPath + "/1",
+				"PUT " + mrPath + "/1/merge",
+			},
+			expectedAutoMerge: true,
+		},
+		"GivenAutoMergeEnabledWithoutSquash_WhenRebase_ThenExpectNoRequest": {
+			givenMr:           &gitlab.MergeRequest{IID: 1, MergeWhenPipelineSucceeds: true},
+			givenMergeMethod:  domain.MergeMethodRebase,
+			expectedAutoMerge: true,
+		},
+		"GivenAutoMergeEnabled_WhenSameMethod_ThenExpectNoRequest": {
+			givenMr:           &gitlab.MergeRequest{IID: 1, MergeWhenPipelineSucceeds: true, Squash: true},
+			givenMergeMethod:  domain.MergeMethodSquash,
+			expectedAutoMerge: true,
+			expectedSquash:    true,
+		},
+		"GivenAutoMergeEnabled_WhenDisabled_ThenExpectCancelled": {
+			givenMr: &gitlab.MergeRequest{IID: 1, MergeWhenPipelineSucceeds: true},
+			expectedRequests: []string{
+				"POST " + mrPath + "/1/cancel_merge_when_pipeline_succeeds",
+			},
+		},
+		"GivenAutoMergeEnabled_WhenOtherMethod_ThenExpectCancelledAndAcceptedAgain": {
+			givenMr:          &gitlab.MergeRequest{IID: 1, MergeWhenPipelineSucceeds: true, Squash: true},
+			givenMergeMethod: domain.MergeMethodMerge,
+			expectedRequests: []string{
+				"POST " + mrPath + "/1/cancel_merge_when_pipeline_succeeds",
+				"GET " + mrPath + "/1",
+				"PUT " + mrPath + "/1/merge",
+			},
+			expectedAutoMerge: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = fmt.Fprint(w, `{"iid": 1, "sha": "abc", "head_pipeline": {"id": 1}}`)
+				case http.MethodPut:
+					body := map[string]interface{}{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "abc", body["sha"])
+					_, _ = fmt.Fprintf(w, `{"iid": 1, "merge_when_pipeline_succeeds": true, "squash": %t}`, body["squash"])
+				default:
+					_, _ = fmt.Fprint(w, `{"iid": 1, "merge_when_pipeline_succeeds": false}`)
+				}
+			})
+			defer server.Close()
+			r, repo := newTestRemote(t, server.URL)
+			pr, err := domain.NewPullRequest(nil, "title", "", "commit", "base", nil)
+			require.NoError(t, err)
+			if tt.givenMergeMethod != "" {
+				require.NoError(t, pr.EnableAutoMerge(tt.givenMergeMethod))
+			}
+
+			err = r.updateAutoMerge(repo, tt.givenMr, pr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRequests, requests)
+			assert.Equal(t, tt.expectedAutoMerge, tt.givenMr.MergeWhenPipelineSucceeds)
+			assert.Equal(t, tt.expectedSquash, tt.givenMr.Squash)
+		})
+	}
+}
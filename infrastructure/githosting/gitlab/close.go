@@ -0,0 +1,33 @@
+package gitlab
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/xanzy/go-gitlab"
+)
+
+// ClosePullRequest implements githosting.Remote.
+func (r *GlRemote) ClosePullRequest(repository *domain.GitRepository, pr *domain.PullRequest, comment string) error {
+	iid := pr.GetNumber().Int()
+	if iid == nil {
+		return nil
+	}
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	if comment != "" {
+		_, _, err = client.Notes.CreateMergeRequestNote(r.projectID(repository.URL), *iid, &gitlab.CreateMergeRequestNoteOptions{Body: gitlab.String(comment)})
+		if err != nil {
+			return err
+		}
+	}
+	closed, _, err := client.MergeRequests.UpdateMergeRequest(r.projectID(repository.URL), *iid, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.String("close"),
+	})
+	if err == nil {
+		r.m.Lock()
+		delete(r.mrCache, repository.URL)
+		r.m.Unlock()
+	}
+	return r.instrumentation.mrClosed(repository, closed, err)
+}
@@ -0,0 +1,118 @@
+package gitlab
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging"
+	"github.com/xanzy/go-gitlab"
+)
+
+// GitLabInstrumentation is responsible for logging interactions with GitLab API.
+type GitLabInstrumentation struct {
+	factory logging.LoggerFactory
+}
+
+// NewGitLabInstrumentation returns a new instance.
+func NewGitLabInstrumentation(factory logging.LoggerFactory) *GitLabInstrumentation {
+	return &GitLabInstrumentation{
+		factory: factory,
+	}
+}
+
+func (i *GitLabInstrumentation) fetchedAllLabels(repository *domain.GitRepository, labels []*gitlab.Label) {
+	log := i.factory.NewRepositoryLogger(repository).V(1)
+	if log.Enabled() {
+		labelArr := make([]string, len(labels))
+		for i, label := range labels {
+			labelArr[i] = label.Name
+		}
+		log.Info("Fetched labels", "labels", labelArr)
+	}
+}
+
+func (i *GitLabInstrumentation) createdLabel(repository *domain.GitRepository, label domain.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Created label", "label", label.Name)
+	}
+	return err
+}
+
+func (i *GitLabInstrumentation) updatedLabel(repository *domain.GitRepository, label domain.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Updated label", "label", label.Name)
+	}
+	return err
+}
+
+func (i *GitLabInstrumentation) renamedLabel(repository *domain.GitRepository, oldName string, label domain.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Renamed label", "from", oldName, "label", label.Name)
+	}
+	return err
+}
+
+func (i *GitLabInstrumentation) deletedLabel(repository *domain.GitRepository, label *gitlab.Label, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).V(1).Info("Deleted label", "label", label.Name)
+	}
+	return err
+}
+
+func (i *GitLabInstrumentation) mrCreated(repository *domain.GitRepository, webUrl string) {
+	i.factory.NewRepositoryLogger(repository).Info("MR created", "url", webUrl)
+}
+
+func (i *GitLabInstrumentation) noMrFound(repository *domain.GitRepository) error {
+	i.factory.NewRepositoryLogger(repository).V(1).Info("No MR found")
+	return nil
+}
+
+func (i *GitLabInstrumentation) mrFound(repository *domain.GitRepository, mr *gitlab.MergeRequest) error {
+	i.factory.NewRepositoryLogger(repository).V(1).Info("Existing MR found", "url", mr.WebURL)
+	return nil
+}
+
+func (i *GitLabInstrumentation) mrUpdated(repository *domain.GitRepository, mr *gitlab.MergeRequest, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Updated merge request", "url", mr.WebURL, "title", mr.Title)
+	}
+	return err
+}
+
+func (i *GitLabInstrumentation) mrIsUpToDate(repository *domain.GitRepository, cached *gitlab.MergeRequest) error {
+	i.factory.NewRepositoryLogger(repository).Info("Merge request is up-to-date", "url", cached.WebURL)
+	return nil
+}
+
+func (i *GitLabInstrumentation) mrAutoMergeEnabled(repository *domain.GitRepository, mr *gitlab.MergeRequest, err error) error {
+	if err != nil {
+		i.factory.NewRepositoryLogger(repository).Info("Could not enable auto-merge", "url", mr.WebURL, "reason", err.Error())
+		return nil
+	}
+	i.factory.NewRepositoryLogger(repository).V(1).Info("Enabled merge when pipeline succeeds", "url", mr.WebURL)
+	return nil
+}
+
+func (i *GitLabInstrumentation) mrAutoMergeMethodNotSupported(repository *domain.GitRepository, mr *gitlab.MergeRequest, method domain.MergeMethod) {
+	i.factory.NewRepositoryLogger(repository).Info("Merge method is not supported by GitLab, the merge method of the project is used instead", "url", mr.WebURL, "method", method)
+}
+
+func (i *GitLabInstrumentation) mrAutoMergeDisabled(repository *domain.GitRepository, mr *gitlab.MergeRequest, err error) error {
+	if err != nil {
+		i.factory.NewRepositoryLogger(repository).Info("Could not cancel merge when pipeline succeeds", "url", mr.WebURL, "reason", err.Error())
+		return nil
+	}
+	i.factory.NewRepositoryLogger(repository).V(1).Info("Cancelled merge when pipeline succeeds", "url", mr.WebURL)
+	return nil
+}
+
+func (i *GitLabInstrumentation) mrAutoMergeSkippedWithoutPipeline(repository *domain.GitRepository, mr *gitlab.MergeRequest) error {
+	i.factory.NewRepositoryLogger(repository).Info("Auto-merge not enabled as the merge request has no pipeline yet", "url", mr.WebURL)
+	return nil
+}
+
+func (i *GitLabInstrumentation) mrClosed(repository *domain.GitRepository, mr *gitlab.MergeRequest, err error) error {
+	if err == nil {
+		i.factory.NewRepositoryLogger(repository).Info("Closed obsolete merge request", "url", mr.WebURL)
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package gitlab
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/xanzy/go-gitlab"
+)
+
+// LabelConverter converts domain.Label to gitlab.Label and vice-versa
+type LabelConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+func (LabelConverter) ConvertToEntity(label *gitlab.Label) domain.Label {
+	if label == nil {
+		return domain.Label{}
+	}
+	entity := domain.Label{
+		Name:        label.Name,
+		Description: label.Description,
+	}
+	color := githosting.HexColorConverter{}.ConvertToEntity(label.Color)
+	// there's no non-colored label on GitLab
+	_ = entity.SetColor(color)
+	return entity
+}
+
+// ConvertFromEntity converts the given object to another.
+func (LabelConverter) ConvertFromEntity(label domain.Label) *gitlab.Label {
+	converted := &gitlab.Label{
+		Name:        label.Name,
+		Description: label.Description,
+		Color:       githosting.HexColorConverter{}.ConvertFromEntity(label.GetColor()),
+	}
+	return converted
+}
@@ -0,0 +1,227 @@
+package gitlab
+
+import (
+	"net/http"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/xanzy/go-gitlab"
+)
+
+// FetchLabels implements githosting.Remote.
+func (r *GlRemote) FetchLabels(repository *domain.GitRepository) (domain.LabelSet, error) {
+	glLabels, err := r.fetchAllLabels(repository)
+	if err == nil {
+		r.m.Lock()
+		r.labelCache[repository.URL] = glLabels
+		r.m.Unlock()
+	}
+	return LabelSetConverter{}.ConvertToEntity(glLabels), err
+}
+
+// EnsureLabels implements githosting.Remote.
+func (r *GlRemote) EnsureLabels(repository *domain.GitRepository, labels domain.LabelSet) error {
+	for _, label := range labels {
+		cached, exists := r.findCachedLabel(repository.URL, label)
+		if exists {
+			if r.hasLabelChanged(cached, label) {
+				err := r.updateLabel(repository, label)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if previous, found := r.findCachedPreviousLabel(repository.URL, label); found {
+			err := r.renameLabel(repository, previous, label)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err := r.createLabel(repository, label)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteLabels implements githosting.Remote.
+func (r *GlRemote) DeleteLabels(repository *domain.GitRepository, labels domain.LabelSet) error {
+	for _, label := range labels {
+		var converted *gitlab.Label
+		cached, exists := r.findCachedLabel(repository.URL, label)
+		if exists {
+			converted = cached
+		} else {
+			converted = LabelConverter{}.ConvertFromEntity(label)
+		}
+		_, err := r.deleteLabel(repository, converted)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *GlRemote) findCachedLabel(url *domain.GitURL, label domain.Label) (*gitlab.Label, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	cachedSet, exists := r.labelCache[url]
+	if !exists {
+		return nil, false
+	}
+	for _, cached := range cachedSet {
+		if cached.Name == label.Name {
+			return cached, true
+		}
+	}
+	return nil, false
+}
+
+func (r *GlRemote) findCachedPreviousLabel(url *domain.GitURL, label domain.Label) (*gitlab.Label, bool) {
+	for _, previous := range label.RenamedFrom {
+		if cached, exists := r.findCachedLabel(url, domain.Label{Name: previous}); exists {
+			return cached, true
+		}
+	}
+	return nil, false
+}
+
+func (r *GlRemote) updateLabelCache(url *domain.GitURL, label *gitlab.Label) {
+	if label == nil {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	cachedSet, exists := r.labelCache[url]
+	if !exists {
+		r.labelCache[url] = []*gitlab.Label{label}
+		return
+	}
+	for i, cached := range cachedSet {
+		if cached.Name == label.Name {
+			cachedSet[i] = label
+			return
+		}
+	}
+	cachedSet = append(cachedSet, label)
+	r.labelCache[url] = cachedSet
+}
+
+func (r *GlRemote) removeLabelFromCache(url *domain.GitURL, label *gitlab.Label) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	cachedSet, exists := r.labelCache[url]
+	if !exists {
+		return
+	}
+	for i, cached := range cachedSet {
+		if cached.Name == label.Name {
+			// replace the existing index with the last element
+			cachedSet[i] = cachedSet[len(cachedSet)-1]
+			// remove the (duplicated) last element
+			newSet := cachedSet[:len(cachedSet)-1]
+			r.labelCache[url] = newSet
+			return
+		}
+	}
+}
+
+func (r *GlRemote) createLabel(repository *domain.GitRepository, label domain.Label) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	converted := LabelConverter{}.ConvertFromEntity(label)
+	newLabel, _, err := client.Labels.CreateLabel(r.projectID(repository.URL), &gitlab.CreateLabelOptions{
+		Name:        gitlab.String(converted.Name),
+		Color:       gitlab.String(converted.Color),
+		Description: gitlab.String(converted.Description),
+	})
+	r.updateLabelCache(repository.URL, newLabel)
+	return r.instrumentation.createdLabel(repository, label, err)
+}
+
+func (r *GlRemote) updateLabel(repository *domain.GitRepository, label domain.Label) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	converted := LabelConverter{}.ConvertFromEntity(label)
+	updatedLabel, _, err := client.Labels.UpdateLabel(r.projectID(repository.URL), &gitlab.UpdateLabelOptions{
+		Name:        gitlab.String(converted.Name),
+		Color:       gitlab.String(converted.Color),
+		Description: gitlab.String(converted.Description),
+	})
+	r.updateLabelCache(repository.URL, updatedLabel)
+	return r.instrumentation.updatedLabel(repository, label, err)
+}
+
+func (r *GlRemote) renameLabel(repository *domain.GitRepository, glLabel *gitlab.Label, label domain.Label) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	converted := LabelConverter{}.ConvertFromEntity(label)
+	renamedLabel, _, err := client.Labels.UpdateLabel(r.projectID(repository.URL), &gitlab.UpdateLabelOptions{
+		Name:        gitlab.String(glLabel.Name),
+		NewName:     gitlab.String(converted.Name),
+		Color:       gitlab.String(converted.Color),
+		Description: gitlab.String(converted.Description),
+	})
+	if err == nil {
+		r.removeLabelFromCache(repository.URL, glLabel)
+		r.updateLabelCache(repository.URL, renamedLabel)
+	}
+	return r.instrumentation.renamedLabel(repository, glLabel.Name, label, err)
+}
+
+func (r *GlRemote) deleteLabel(repository *domain.GitRepository, label *gitlab.Label) (bool, error) {
+	client, err := r.getClient()
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.Labels.DeleteLabel(r.projectID(repository.URL), &gitlab.DeleteLabelOptions{
+		Name: gitlab.String(label.Name),
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// Not an error
+		return false, nil
+	}
+	if err == nil {
+		r.removeLabelFromCache(repository.URL, label)
+	}
+	return err == nil, r.instrumentation.deletedLabel(repository, label, err)
+}
+
+func (r *GlRemote) fetchAllLabels(repository *domain.GitRepository) ([]*gitlab.Label, error) {
+	client, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+	nextPage := 1
+	var allLabels []*gitlab.Label
+	for repeat := true; repeat; repeat = nextPage > 0 {
+		labels, resp, err := client.Labels.ListLabels(r.projectID(repository.URL), &gitlab.ListLabelsOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    nextPage,
+				PerPage: 100,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		allLabels = append(allLabels, labels...)
+		// On the last page, the NextPage is 0 again, we can use that to exit the loop
+		nextPage = resp.NextPage
+	}
+	r.instrumentation.fetchedAllLabels(repository, allLabels)
+	return allLabels, nil
+}
+
+func (r *GlRemote) hasLabelChanged(glLabel *gitlab.Label, repoLabel domain.Label) bool {
+	converted := githosting.HexColorConverter{}.ConvertFromEntity(repoLabel.GetColor())
+	return glLabel.Description != repoLabel.Description || glLabel.Color != converted
+}
@@ -0,0 +1,103 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestGlRemote_hasLabelChanged(t *testing.T) {
+	label := "label"
+	description := "description"
+	color := "#ababab"
+
+	tests := map[string]struct {
+		givenGlLabel   *gitlab.Label
+		givenRepoLabel domain.Label
+		expectedResult bool
+	}{
+		"GivenSameLabel_ThenExpectFalse": {
+			givenGlLabel:   newGitLabLabel(label, description, color),
+			givenRepoLabel: newDomainLabel(t, label, description, color),
+			expectedResult: false,
+		},
+		"GivenDifferentDescription_ThenExpectTrue": {
+			givenGlLabel:   newGitLabLabel(label, description, color),
+			givenRepoLabel: newDomainLabel(t, label, "different", color),
+			expectedResult: true,
+		},
+		"GivenDifferentColor_ThenExpectTrue": {
+			givenGlLabel:   newGitLabLabel(label, description, color),
+			givenRepoLabel: newDomainLabel(t, label, description, "#FFFFFF"),
+			expectedResult: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &GlRemote{}
+			result := p.hasLabelChanged(tt.givenGlLabel, tt.givenRepoLabel)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestGlRemote_HasSupportFor(t *testing.T) {
+	tests := map[string]struct {
+		givenBaseURL   string
+		givenHost      string
+		expectedResult bool
+	}{
+		"GivenSameHost_ThenExpectTrue": {
+			givenBaseURL:   "https://gitlab.com",
+			givenHost:      "gitlab.com",
+			expectedResult: true,
+		},
+		"GivenSelfHostedInstance_WhenHostMatches_ThenExpectTrue": {
+			givenBaseURL:   "https://git.example.com/gitlab",
+			givenHost:      "git.example.com",
+			expectedResult: true,
+		},
+		"GivenDifferentHost_ThenExpectFalse": {
+			givenBaseURL:   "https://gitlab.com",
+			givenHost:      "github.com",
+			expectedResult: false,
+		},
+		"GivenEmptyBaseURL_ThenExpectFalse": {
+			givenBaseURL:   "",
+			givenHost:      "gitlab.com",
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := cfg.NewDefaultConfig()
+			config.GitLab.URL = tt.givenBaseURL
+			p := NewRemote(nil, config)
+			result := p.HasSupportFor(&domain.GitURL{Host: tt.givenHost})
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func newGitLabLabel(name, description, color string) *gitlab.Label {
+	return &gitlab.Label{
+		Name:        name,
+		Description: description,
+		Color:       color,
+	}
+}
+
+func newDomainLabel(t *testing.T, name, description, color string) domain.Label {
+	label := domain.Label{
+		Name:        name,
+		Description: description,
+	}
+	err := label.SetColor(githosting.HexColorConverter{}.ConvertToEntity(color))
+	require.NoError(t, err)
+	return label
+}
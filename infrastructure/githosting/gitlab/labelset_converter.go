@@ -0,0 +1,45 @@
+package gitlab
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/xanzy/go-gitlab"
+)
+
+// LabelSetConverter converts domain.LabelSet to gitlab.Label and vice-versa
+type LabelSetConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+// Returns a non-nil empty list if labels is empty or nil.
+func (LabelSetConverter) ConvertToEntity(labels []*gitlab.Label) domain.LabelSet {
+	if labels == nil || len(labels) == 0 {
+		return domain.LabelSet{}
+	}
+	converted := make(domain.LabelSet, len(labels))
+	for i := range labels {
+		converted[i] = LabelConverter{}.ConvertToEntity(labels[i])
+	}
+	return converted
+}
+
+// ConvertFromEntity converts the given object to another.
+// Returns a non-nil empty list if labels is empty or nil.
+func (LabelSetConverter) ConvertFromEntity(labels domain.LabelSet) []*gitlab.Label {
+	if labels == nil || len(labels) == 0 {
+		return []*gitlab.Label{}
+	}
+	converted := make([]*gitlab.Label, len(labels))
+	for i := range labels {
+		converted[i] = LabelConverter{}.ConvertFromEntity(labels[i])
+	}
+	return converted
+}
+
+// ConvertToNames returns the names of the given domain.LabelSet.
+// Merge requests in GitLab reference labels only by name.
+func (LabelSetConverter) ConvertToNames(labels domain.LabelSet) gitlab.Labels {
+	names := make(gitlab.Labels, len(labels))
+	for i := range labels {
+		names[i] = labels[i].Name
+	}
+	return names
+}
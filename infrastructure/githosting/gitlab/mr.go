@@ -0,0 +1,134 @@
+package gitlab
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/xanzy/go-gitlab"
+)
+
+// FindPullRequest implements githosting.Remote.
+// In GitLab, pull requests are called merge requests.
+func (r *GlRemote) FindPullRequest(repository *domain.GitRepository) (*domain.PullRequest, error) {
+	mr, err := r.findExistingMr(repository)
+	if err != nil {
+		return nil, err
+	}
+	r.m.Lock()
+	if mr != nil {
+		r.mrCache[repository.URL] = mr
+	} else {
+		delete(r.mrCache, repository.URL)
+	}
+	r.m.Unlock()
+	converted := MrConverter{}.ConvertToEntity(mr)
+	return converted, nil
+}
+
+func (r *GlRemote) findExistingMr(repository *domain.GitRepository) (*gitlab.MergeRequest, error) {
+	client, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+	list, _, err := client.MergeRequests.ListProjectMergeRequests(r.projectID(repository.URL), &gitlab.ListProjectMergeRequestsOptions{
+		State:        gitlab.String("opened"),
+		SourceBranch: gitlab.String(repository.CommitBranch),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) > 0 {
+		return list[0], r.instrumentation.mrFound(repository, list[0])
+	}
+	return nil, r.instrumentation.noMrFound(repository)
+}
+
+// EnsurePullRequest implements githosting.Remote.
+func (r *GlRemote) EnsurePullRequest(repository *domain.GitRepository, pr *domain.PullRequest) error {
+	r.m.Lock()
+	cached, exists := r.mrCache[repository.URL]
+	r.m.Unlock()
+	if !exists || pr.GetNumber() == nil {
+		return r.createNewMr(repository, pr)
+	}
+	return r.updateExistingMr(repository, cached, pr)
+}
+
+func (r *GlRemote) updateExistingMr(repository *domain.GitRepository, cached *gitlab.MergeRequest, pr *domain.PullRequest) error {
+	if r.canSkipDescriptionUpdate(cached, pr) && r.canSkipLabelUpdate(cached, pr) && r.canSkipAutoMergeUpdate(cached, pr) {
+		return r.instrumentation.mrIsUpToDate(repository, cached)
+	}
+	updated, err := r.updateMrDescription(repository, cached, pr)
+	if err != nil {
+		return err
+	}
+	return r.updateAutoMerge(repository, updated, pr)
+}
+
+func (r *GlRemote) updateMrDescription(repository *domain.GitRepository, cached *gitlab.MergeRequest, pr *domain.PullRequest) (*gitlab.MergeRequest, error) {
+	if r.canSkipDescriptionUpdate(cached, pr) && r.canSkipLabelUpdate(cached, pr) {
+		return cached, nil
+	}
+	client, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+	opts := &gitlab.UpdateMergeRequestOptions{
+		Title:       gitlab.String(expectedTitle(cached, pr)),
+		Description: gitlab.String(pr.GetBody()),
+	}
+	if !r.canSkipLabelUpdate(cached, pr) {
+		current := domain.FromStringSlice(cached.Labels)
+		addLabels := LabelSetConverter{}.ConvertToNames(pr.GetLabels().Without(current))
+		removeLabels := LabelSetConverter{}.ConvertToNames(current.Without(pr.GetLabels()))
+		if len(addLabels) > 0 {
+			opts.AddLabels = &addLabels
+		}
+		if len(removeLabels) > 0 {
+			opts.RemoveLabels = &removeLabels
+		}
+	}
+	updated, _, err := client.MergeRequests.UpdateMergeRequest(r.projectID(repository.URL), cached.IID, opts)
+	if err := r.instrumentation.mrUpdated(repository, updated, err); err != nil {
+		return nil, err
+	}
+	r.m.Lock()
+	r.mrCache[repository.URL] = updated
+	r.m.Unlock()
+	return updated, nil
+}
+
+func (r *GlRemote) canSkipDescriptionUpdate(cached *gitlab.MergeRequest, pr *domain.PullRequest) bool {
+	sameTitle := cached.Title == expectedTitle(cached, pr)
+	sameBody := cached.Description == pr.GetBody()
+	return sameTitle && sameBody
+}
+
+func (r *GlRemote) canSkipLabelUpdate(cached *gitlab.MergeRequest, pr *domain.PullRequest) bool {
+	converted := domain.FromStringSlice(cached.Labels)
+	return converted.Equals(pr.GetLabels())
+}
+
+func (r *GlRemote) createNewMr(repository *domain.GitRepository, pr *domain.PullRequest) error {
+	client, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	opts := &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.String(mrTitle(pr.GetTitle(), pr.IsDraft())),
+		Description:  gitlab.String(pr.GetBody()),
+		SourceBranch: gitlab.String(pr.CommitBranch),
+		TargetBranch: gitlab.String(pr.BaseBranch),
+	}
+	if len(pr.GetLabels()) > 0 {
+		labels := LabelSetConverter{}.ConvertToNames(pr.GetLabels())
+		opts.Labels = &labels
+	}
+	mr, _, err := client.MergeRequests.CreateMergeRequest(r.projectID(repository.URL), opts)
+	if err != nil {
+		return err
+	}
+	r.m.Lock()
+	r.mrCache[repository.URL] = mr
+	r.m.Unlock()
+	r.instrumentation.mrCreated(repository, mr.WebURL)
+	return r.enableAutoMerge(repository, mr, pr)
+}
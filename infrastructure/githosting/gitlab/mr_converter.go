@@ -0,0 +1,54 @@
+package gitlab
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/xanzy/go-gitlab"
+)
+
+// MrConverter converts domain.PullRequest to gitlab.MergeRequest and vice-versa.
+type MrConverter struct{}
+
+// ConvertToEntity converts the given object to another.
+func (c MrConverter) ConvertToEntity(mr *gitlab.MergeRequest) *domain.PullRequest {
+	if mr == nil {
+		return nil
+	}
+
+	// Merge requests only contain the label names.
+	set := domain.FromStringSlice(mr.Labels)
+	iid := mr.IID
+
+	title := mr.Title
+	if mr.Draft {
+		title = stripDraftPrefix(title)
+	}
+	entity, _ := domain.NewPullRequest(domain.NewPullRequestNumber(&iid), title, mr.Description, mr.SourceBranch, mr.TargetBranch, set)
+	_ = entity.SetDraft(mr.Draft)
+	if mr.MergeWhenPipelineSucceeds {
+		method := domain.MergeMethodMerge
+		if mr.Squash {
+			method = domain.MergeMethodSquash
+		}
+		_ = entity.EnableAutoMerge(method)
+	}
+	return entity
+}
+
+// ConvertFromEntity converts the given object to another.
+func (c MrConverter) ConvertFromEntity(entity *domain.PullRequest) *gitlab.MergeRequest {
+	if entity == nil {
+		return nil
+	}
+	mr := &gitlab.MergeRequest{
+		Title:        mrTitle(entity.GetTitle(), entity.IsDraft()),
+		Description:  entity.GetBody(),
+		SourceBranch: entity.CommitBranch,
+		TargetBranch: entity.BaseBranch,
+		Labels:       LabelSetConverter{}.ConvertToNames(entity.GetLabels()),
+		Draft:        entity.IsDraft(),
+	}
+	if nr := entity.GetNumber().Int(); nr != nil {
+		mr.IID = *nr
+	}
+	return mr
+}
@@ -0,0 +1,83 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestMrConverter_ConvertToEntity(t *testing.T) {
+	tests := map[string]struct {
+		givenMr           *gitlab.MergeRequest
+		expectedTitle     string
+		expectedDraft     bool
+		expectedAutoMerge domain.MergeMethod
+	}{
+		"GivenNil_ThenExpectNil": {
+			givenMr: nil,
+		},
+		"GivenMr_ThenExpectSameTitle": {
+			givenMr:       &gitlab.MergeRequest{IID: 1, Title: "title"},
+			expectedTitle: "title",
+		},
+		"GivenDraftMr_ThenExpectDraftPrefixRemoved": {
+			givenMr:       &gitlab.MergeRequest{IID: 1, Title: "Draft: title", Draft: true},
+			expectedTitle: "title",
+			expectedDraft: true,
+		},
+		"GivenReadyMr_WhenTitleHasDraftPrefix_ThenExpectTitleUnchanged": {
+			givenMr:       &gitlab.MergeRequest{IID: 1, Title: "Draft: title"},
+			expectedTitle: "Draft: title",
+		},
+		"GivenMergeWhenPipelineSucceeds_ThenExpectAutoMergeWithMerge": {
+			givenMr:           &gitlab.MergeRequest{IID: 1, Title: "title", MergeWhenPipelineSucceeds: true},
+			expectedTitle:     "title",
+			expectedAutoMerge: domain.MergeMethodMerge,
+		},
+		"GivenMergeWhenPipelineSucceeds_WhenSquash_ThenExpectAutoMergeWithSquash": {
+			givenMr:           &gitlab.MergeRequest{IID: 1, Title: "title", MergeWhenPipelineSucceeds: true, Squash: true},
+			expectedTitle:     "title",
+			expectedAutoMerge: domain.MergeMethodSquash,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := MrConverter{}.ConvertToEntity(tt.givenMr)
+			if tt.givenMr == nil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expectedTitle, result.GetTitle())
+			assert.Equal(t, tt.expectedDraft, result.IsDraft())
+			assert.Equal(t, tt.expectedAutoMerge, result.GetAutoMergeMethod())
+			assert.Equal(t, &tt.givenMr.IID, result.GetNumber().Int())
+		})
+	}
+}
+
+func TestMrConverter_RoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		givenMr *gitlab.MergeRequest
+	}{
+		"GivenMr": {
+			givenMr: &gitlab.MergeRequest{IID: 1, Title: "title", Description: "body", SourceBranch: "greposync-update", TargetBranch: "main", Labels: gitlab.Labels{}},
+		},
+		"GivenMrWithLabels": {
+			givenMr: &gitlab.MergeRequest{IID: 2, Title: "title", Description: "body", SourceBranch: "greposync-update", TargetBranch: "main", Labels: gitlab.Labels{"bug", "dependency"}},
+		},
+		"GivenDraftMr": {
+			givenMr: &gitlab.MergeRequest{IID: 3, Title: "Draft: title", Description: "body", SourceBranch: "greposync-update", TargetBranch: "main", Labels: gitlab.Labels{}, Draft: true},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			converter := MrConverter{}
+			result := converter.ConvertFromEntity(converter.ConvertToEntity(tt.givenMr))
+			assert.Equal(t, tt.givenMr, result)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+const mrPath = "/api/v4/projects/owner%2Frepository/merge_requests"
+
+func TestGlRemote_FindPullRequest(t *testing.T) {
+	tests := map[string]struct {
+		givenResponse  string
+		expectedNumber *int
+	}{
+		"GivenNoMrs_ThenExpectNil": {
+			givenResponse: `[]`,
+		},
+		"GivenMr_ThenExpectPullRequest": {
+			givenResponse:  `[{"iid": 2, "title": "title", "source_branch": "greposync-update", "target_branch": "main", "labels": []}]`,
+			expectedNumber: intPtr(2),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, mrPath, r.URL.EscapedPath())
+				assert.Equal(t, "opened", r.URL.Query().Get("state"))
+				assert.Equal(t, "greposync-update", r.URL.Query().Get("source_branch"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.givenResponse))
+			})
+			defer server.Close()
+			p, repo := newTestRemote(t, server.URL)
+
+			result, err := p.FindPullRequest(repo)
+			require.NoError(t, err)
+			_, cached := p.mrCache[repo.URL]
+			if tt.expectedNumber == nil {
+				assert.Nil(t, result)
+				assert.False(t, cached, "mr cached")
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expectedNumber, result.GetNumber().Int())
+			assert.Equal(t, "greposync-update", result.CommitBranch)
+			assert.True(t, cached, "mr cached")
+		})
+	}
+}
+
+func TestGlRemote_EnsurePullRequest(t *testing.T) {
+	tests := map[string]struct {
+		givenCachedMr    *gitlab.MergeRequest
+		givenDraft       bool
+		givenLabels      []string
+		expectedRequests []string
+		expectedBody     map[string]interface{}
+	}{
+		"GivenNoMr_ThenExpectMrCreated": {
+			givenLabels:      []string{"bug"},
+			expectedRequests: []string{"POST " + mrPath},
+			expectedBody:     map[string]interface{}{"title": "title", "description": "body", "source_branch": "greposync-update", "target_branch": "main", "labels": "bug"},
+		},
+		"GivenNoMr_WhenDraft_ThenExpectMrCreatedWithDraftPrefix": {
+			givenDraft:       true,
+			expectedRequests: []string{"POST " + mrPath},
+			expectedBody:     map[string]interface{}{"title": "Draft: title", "description": "body", "source_branch": "greposync-update", "target_branch": "main"},
+		},
+		"GivenMr_WhenUpToDate_ThenExpectNoRequest": {
+			givenCachedMr:    &gitlab.MergeRequest{IID: 1, Title: "title", Description: "body", Labels: gitlab.Labels{"bug"}},
+			givenLabels:      []string{"bug"},
+			expectedRequests: []string{},
+		},
+		"GivenDraftMr_WhenStillDraft_ThenExpectNoRequest": {
+			givenCachedMr:    &gitlab.MergeRequest{IID: 1, Title: "Draft: title", Description: "body", Draft: true},
+			givenDraft:       true,
+			expectedRequests: []string{},
+		},
+		"GivenMr_WhenBodyChanged_ThenExpectMrUpdated": {
+			givenCachedMr:    &gitlab.MergeRequest{IID: 1, Title: "title", Description: "old"},
+			expectedRequests: []string{"PUT " + mrPath + "/1"},
+			expectedBody:     map[string]interface{}{"title": "title", "description": "body"},
+		},
+		"GivenMr_WhenLabelsChanged_ThenExpectLabelsAddedAndRemoved": {
+			givenCachedMr:    &gitlab.MergeRequest{IID: 1, Title: "title", Description: "body", Labels: gitlab.Labels{"foreign"}},
+			givenLabels:      []string{"bug"},
+			expectedRequests: []string{"PUT " + mrPath + "/1"},
+			expectedBody:     map[string]interface{}{"title": "title", "description": "body", "add_labels": "bug", "remove_labels": "foreign"},
+		},
+		"GivenDraftMr_WhenReady_ThenExpectDraftPrefixRemoved": {
+			givenCachedMr:    &gitlab.MergeRequest{IID: 1, Title: "Draft: title", Description: "body", Draft: true},
+			expectedRequests: []string{"PUT " + mrPath + "/1"},
+			expectedBody:     map[string]interface{}{"title": "title", "description": "body"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := make([]string, 0)
+			server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+				raw, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				body := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(raw, &body))
+				assert.Equal(t, tt.expectedBody, body)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"iid": 1, "title": "title", "labels": []}`))
+			})
+			defer server.Close()
+			p, repo := newTestRemote(t, server.URL)
+			var number *domain.PullRequestNumber
+			if tt.givenCachedMr != nil {
+				p.mrCache[repo.URL] = tt.givenCachedMr
+				number = domain.NewPullRequestNumber(&tt.givenCachedMr.IID)
+			}
+			pr, err := domain.NewPullRequest(number, "title", "body", "greposync-update", "main", domain.FromStringSlice(tt.givenLabels))
+			require.NoError(t, err)
+			require.NoError(t, pr.SetDraft(tt.givenDraft))
+
+			err = p.EnsurePullRequest(repo, pr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRequests, requests)
+		})
+	}
+}
+
+func TestGlRemote_canSkipDescriptionUpdate(t *testing.T) {
+	tests := map[string]struct {
+		givenMr        *gitlab.MergeRequest
+		givenTitle     string
+		givenBody      string
+		expectedResult bool
+	}{
+		"GivenSameTitleAndBody_ThenExpectTrue": {
+			givenMr:        &gitlab.MergeRequest{Title: "title", Description: "body"},
+			givenTitle:     "title",
+			givenBody:      "body",
+			expectedResult: true,
+		},
+		"GivenDraftMr_WhenTitleWithoutPrefixIsSame_ThenExpectFalse": {
+			givenMr:        &gitlab.MergeRequest{Title: "Draft: title", Description: "body", Draft: true},
+			givenTitle:     "title",
+			givenBody:      "body",
+			expectedResult: false,
+		},
+		"GivenDifferentTitle_ThenExpectFalse": {
+			givenMr:        &gitlab.MergeRequest{Title: "title", Description: "body"},
+			givenTitle:     "other",
+			givenBody:      "body",
+			expectedResult: false,
+		},
+		"GivenDifferentBody_ThenExpectFalse": {
+			givenMr:        &gitlab.MergeRequest{Title: "title", Description: "body"},
+			givenTitle:     "title",
+			givenBody:      "other",
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pr, err := domain.NewPullRequest(nil, tt.givenTitle, tt.givenBody, "greposync-update", "main", nil)
+			require.NoError(t, err)
+			result := (&GlRemote{}).canSkipDescriptionUpdate(tt.givenMr, pr)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestGlRemote_canSkipLabelUpdate(t *testing.T) {
+	tests := map[string]struct {
+		givenMrLabels  gitlab.Labels
+		givenLabels    []string
+		expectedResult bool
+	}{
+		"GivenNoLabels_ThenExpectTrue": {
+			givenMrLabels:  gitlab.Labels{},
+			givenLabels:    []string{},
+			expectedResult: true,
+		},
+		"GivenSameLabelsInDifferentOrder_ThenExpectTrue": {
+			givenMrLabels:  gitlab.Labels{"dependency", "bug"},
+			givenLabels:    []string{"bug", "dependency"},
+			expectedResult: true,
+		},
+		"GivenMissingLabel_ThenExpectFalse": {
+			givenMrLabels:  gitlab.Labels{"bug"},
+			givenLabels:    []string{"bug", "dependency"},
+			expectedResult: false,
+		},
+		"GivenForeignLabel_ThenExpectFalse": {
+			givenMrLabels:  gitlab.Labels{"bug", "foreign"},
+			givenLabels:    []string{"bug"},
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pr, err := domain.NewPullRequest(nil, "title", "body", "greposync-update", "main", domain.FromStringSlice(tt.givenLabels))
+			require.NoError(t, err)
+			result := (&GlRemote{}).canSkipLabelUpdate(&gitlab.MergeRequest{Labels: tt.givenMrLabels}, pr)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+// newTestServer returns a server that answers the GitLab API requests with the given handler.
+// The rate limit probe of the client is answered with an empty response.
+func newTestServer(handler http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/" {
+			return
+		}
+		handler(w, r)
+	}))
+}
+
+func newTestRemote(t *testing.T, serverURL string) (*GlRemote, *domain.GitRepository) {
+	config := cfg.NewDefaultConfig()
+	config.GitLab.URL = serverURL
+	p := NewRemote(NewGitLabInstrumentation(loggingtest.NewDiscardLoggerFactory()), config)
+	u, err := url.Parse(serverURL + "/owner/repository.git")
+	require.NoError(t, err)
+	repo := domain.NewGitRepository(domain.FromURL(u), "")
+	repo.CommitBranch = "greposync-update"
+	return p, repo
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,94 @@
+package gitlab
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/xanzy/go-gitlab"
+)
+
+type (
+	// GlRemote contains the methods and data to interact with the GitLab API.
+	GlRemote struct {
+		client          *gitlab.Client
+		config          *cfg.Configuration
+		m               *sync.Mutex
+		mrCache         map[*domain.GitURL]*gitlab.MergeRequest
+		labelCache      map[*domain.GitURL][]*gitlab.Label
+		instrumentation *GitLabInstrumentation
+	}
+)
+
+// ProviderKey is the identifier for the GitLab githosting.RemoteProvider.
+const ProviderKey githosting.RemoteProvider = "gitlab"
+
+// TokenEnvVarName is the name of the environment variable that contains the GitLab access token.
+const TokenEnvVarName = "GITLAB_TOKEN"
+
+// NewRemote returns a new GitLab provider instance.
+// The API client is created lazily once the configuration has been parsed.
+func NewRemote(instrumentation *GitLabInstrumentation, config *cfg.Configuration) *GlRemote {
+	provider := &GlRemote{
+		m:               &sync.Mutex{},
+		config:          config,
+		mrCache:         map[*domain.GitURL]*gitlab.MergeRequest{},
+		labelCache:      map[*domain.GitURL][]*gitlab.Label{},
+		instrumentation: instrumentation,
+	}
+	return provider
+}
+
+// HasSupportFor implements githosting.Remote.
+// It returns true if the host of the given URL is the same as the host of the configured GitLab instance.
+func (r *GlRemote) HasSupportFor(url *domain.GitURL) bool {
+	baseURL, err := r.parseBaseURL()
+	if err != nil {
+		return false
+	}
+	return url.Host == baseURL.Host
+}
+
+// HasCapability implements githosting.Remote.
+// None of the optional capabilities are supported for GitLab yet.
+func (r *GlRemote) HasCapability(_ githosting.Capability) bool {
+	return false
+}
+
+func (r *GlRemote) parseBaseURL() (*url.URL, error) {
+	if r.config == nil || r.config.GitLab == nil || r.config.GitLab.URL == "" {
+		return nil, githosting.ErrProviderNotSupported
+	}
+	return url.Parse(r.config.GitLab.URL)
+}
+
+// getClient returns the GitLab API client.
+// The client is initialized on first invocation.
+func (r *GlRemote) getClient() (*gitlab.Client, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.client != nil {
+		return r.client, nil
+	}
+	baseURL, err := r.parseBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	client, err := gitlab.NewClient(os.Getenv(TokenEnvVarName), gitlab.WithBaseURL(baseURL.String()))
+	r.client = client
+	return client, err
+}
+
+// projectID returns the path-encoded project ID as expected by the GitLab API.
+// The path of the configured GitLab URL is not part of the project ID, if the instance is served under a sub-path.
+func (r *GlRemote) projectID(repositoryURL *domain.GitURL) string {
+	baseURL, err := r.parseBaseURL()
+	if err != nil {
+		return path.Join(repositoryURL.GetNamespace(), repositoryURL.GetRepositoryName())
+	}
+	return path.Join(githosting.RepositoryPath(repositoryURL, baseURL))
+}
@@ -0,0 +1,45 @@
+package gitlab
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlRemote_projectID(t *testing.T) {
+	tests := map[string]struct {
+		givenBaseURL      string
+		givenURL          string
+		expectedProjectID string
+	}{
+		"GivenGitLabCom_ThenExpectNamespaceAndName": {
+			givenBaseURL:      "https://gitlab.com",
+			givenURL:          "https://gitlab.com/group/subgroup/project.git",
+			expectedProjectID: "group/subgroup/project",
+		},
+		"GivenSelfHostedInstanceWithSubPath_WhenHTTPSURL_ThenExpectSubPathRemoved": {
+			givenBaseURL:      "https://git.example.com/gitlab",
+			givenURL:          "https://git.example.com/gitlab/group/project.git",
+			expectedProjectID: "group/project",
+		},
+		"GivenSelfHostedInstanceWithSubPath_WhenSSHURL_ThenExpectNamespaceAndName": {
+			givenBaseURL:      "https://git.example.com/gitlab",
+			givenURL:          "ssh://git@git.example.com/group/project.git",
+			expectedProjectID: "group/project",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := cfg.NewDefaultConfig()
+			config.GitLab.URL = tt.givenBaseURL
+			u, err := url.Parse(tt.givenURL)
+			require.NoError(t, err)
+			p := NewRemote(nil, config)
+			assert.Equal(t, tt.expectedProjectID, p.projectID(domain.FromURL(u)))
+		})
+	}
+}
@@ -34,3 +34,12 @@ func (p *PullRequestStore) EnsurePullRequest(repository *domain.GitRepository) e
 	}
 	return fmt.Errorf("%s: %w", repository.URL, ErrProviderNotSupported)
 }
+
+func (p *PullRequestStore) ClosePullRequest(repository *domain.GitRepository, comment string) error {
+	for _, remote := range p.providers {
+		if remote.HasSupportFor(repository.URL) {
+			return remote.ClosePullRequest(repository, repository.PullRequest, comment)
+		}
+	}
+	return fmt.Errorf("%s: %w", repository.URL, ErrProviderNotSupported)
+}
@@ -1,13 +1,33 @@
 package githosting
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ccremer/greposync/domain"
 )
 
+// ErrCapabilityNotSupported indicates that the remote provider of a repository doesn't support the requested Capability.
+var ErrCapabilityNotSupported = errors.New("capability not supported by remote provider")
+
 type ProviderMap map[RemoteProvider]Remote
 
 type RemoteProvider string
 
+// Capability is an optional feature of a Git hosting service that not every Remote supports.
+type Capability string
+
+const (
+	// CapabilityRepositorySettings indicates that the Remote implements SettingsRemote.
+	CapabilityRepositorySettings Capability = "repositorySettings"
+	// CapabilityBranchProtection indicates that the Remote implements BranchProtectionRemote.
+	CapabilityBranchProtection Capability = "branchProtection"
+	// CapabilityRepositoryDiscovery indicates that the Remote implements DiscoveryRemote.
+	CapabilityRepositoryDiscovery Capability = "repositoryDiscovery"
+	// CapabilityFork indicates that the Remote implements ForkRemote.
+	CapabilityFork Capability = "fork"
+)
+
 type Remote interface {
 	// FetchLabels returns the domain.LabelSet found for the given repository.
 	// An empty set without error is returned if none found.
@@ -24,6 +44,31 @@ type Remote interface {
 	// The same rules as domain.PullRequestStore:EnsurePullRequest applies.
 	EnsurePullRequest(repository *domain.GitRepository, pr *domain.PullRequest) error
 
+	// ClosePullRequest closes the given domain.PullRequest.
+	// The same rules as domain.PullRequestStore:ClosePullRequest applies.
+	ClosePullRequest(repository *domain.GitRepository, pr *domain.PullRequest, comment string) error
+
 	// HasSupportFor returns true if the remote implementation supports interacting with the remote API for the given repository URL.
 	HasSupportFor(url *domain.GitURL) bool
+
+	// HasCapability returns true if the remote implementation supports the given optional Capability.
+	HasCapability(capability Capability) bool
+}
+
+// findRemoteWithCapability returns the Remote that supports the given repository.
+// Returns ErrProviderNotSupported if there is no Remote for the repository, or ErrCapabilityNotSupported if the Remote doesn't support the given Capability.
+func (m ProviderMap) findRemoteWithCapability(repository *domain.GitRepository, capability Capability) (Remote, error) {
+	return m.findRemoteForURL(repository.URL, capability)
+}
+
+func (m ProviderMap) findRemoteForURL(url *domain.GitURL, capability Capability) (Remote, error) {
+	for _, remote := range m {
+		if remote.HasSupportFor(url) {
+			if remote.HasCapability(capability) {
+				return remote, nil
+			}
+			return nil, fmt.Errorf("%s: %w: %s", url.GetFullName(), ErrCapabilityNotSupported, capability)
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", url.GetFullName(), ErrProviderNotSupported)
 }
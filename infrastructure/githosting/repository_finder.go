@@ -0,0 +1,32 @@
+package githosting
+
+import (
+	"github.com/ccremer/greposync/domain"
+)
+
+// DiscoveryRemote is a Remote that supports discovering repositories.
+type DiscoveryRemote interface {
+	Remote
+
+	// FindRepositories returns the full names of the repositories matching the given domain.RepositoryQuery.
+	// The same rules as domain.GitRepositoryFinder:FindRepositories applies.
+	FindRepositories(url *domain.GitURL, query domain.RepositoryQuery) ([]string, error)
+}
+
+type RepositoryFinder struct {
+	providers ProviderMap
+}
+
+func NewRepositoryFinder(providers ProviderMap) *RepositoryFinder {
+	return &RepositoryFinder{
+		providers: providers,
+	}
+}
+
+func (f *RepositoryFinder) FindRepositories(url *domain.GitURL, query domain.RepositoryQuery) ([]string, error) {
+	remote, err := f.providers.findRemoteForURL(url, CapabilityRepositoryDiscovery)
+	if err != nil {
+		return nil, err
+	}
+	return remote.(DiscoveryRemote).FindRepositories(url, query)
+}
@@ -0,0 +1,24 @@
+package githosting
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+)
+
+// RepositoryPath returns the namespace and name of the given repository on a self-hosted instance whose web UI and API are served under the given base URL.
+// If the instance is served under a sub-path, e.g. `https://git.example.com/gitlab`, the sub-path is removed from the namespace of HTTP(S) URLs.
+// SSH URLs don't contain the sub-path.
+func RepositoryPath(repositoryURL *domain.GitURL, baseURL *url.URL) (namespace, name string) {
+	namespace = repositoryURL.GetNamespace()
+	name = repositoryURL.GetRepositoryName()
+	basePath := strings.Trim(baseURL.Path, "/")
+	if basePath == "" || (repositoryURL.Scheme != "http" && repositoryURL.Scheme != "https") {
+		return namespace, name
+	}
+	if namespace == basePath {
+		return "", name
+	}
+	return strings.TrimPrefix(namespace, basePath+"/"), name
+}
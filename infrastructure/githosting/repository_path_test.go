@@ -0,0 +1,55 @@
+package githosting
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryPath(t *testing.T) {
+	tests := map[string]struct {
+		givenBaseURL      string
+		givenURL          string
+		expectedNamespace string
+		expectedName      string
+	}{
+		"GivenBaseURLWithoutPath_ThenExpectFullNamespace": {
+			givenBaseURL:      "https://gitlab.com",
+			givenURL:          "https://gitlab.com/group/subgroup/project.git",
+			expectedNamespace: "group/subgroup",
+			expectedName:      "project",
+		},
+		"GivenBaseURLWithPath_WhenHTTPSURL_ThenExpectPathRemoved": {
+			givenBaseURL:      "https://git.example.com/gitlab/",
+			givenURL:          "https://git.example.com/gitlab/group/project.git",
+			expectedNamespace: "group",
+			expectedName:      "project",
+		},
+		"GivenBaseURLWithPath_WhenNamespaceStartsWithSamePrefix_ThenExpectFullNamespace": {
+			givenBaseURL:      "https://git.example.com/gitlab",
+			givenURL:          "https://git.example.com/gitlab-group/project.git",
+			expectedNamespace: "gitlab-group",
+			expectedName:      "project",
+		},
+		"GivenBaseURLWithPath_WhenSSHURL_ThenExpectFullNamespace": {
+			givenBaseURL:      "https://git.example.com/gitlab",
+			givenURL:          "ssh://git@git.example.com/gitlab/project.git",
+			expectedNamespace: "gitlab",
+			expectedName:      "project",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			baseURL, err := url.Parse(tt.givenBaseURL)
+			require.NoError(t, err)
+			u, err := url.Parse(tt.givenURL)
+			require.NoError(t, err)
+			namespace, repoName := RepositoryPath(domain.FromURL(u), baseURL)
+			assert.Equal(t, tt.expectedNamespace, namespace)
+			assert.Equal(t, tt.expectedName, repoName)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package githosting
+
+import (
+	"github.com/ccremer/greposync/domain"
+)
+
+// SettingsRemote is a Remote that supports managing repository settings.
+type SettingsRemote interface {
+	Remote
+
+	// FetchSettings returns the current domain.RepositorySettings of the given repository.
+	FetchSettings(repository *domain.GitRepository) (domain.RepositorySettings, error)
+
+	// UpdateSettings updates the given domain.RepositorySettings.
+	// The same rules as domain.SettingsStore:UpdateSettingsForRepository applies.
+	UpdateSettings(repository *domain.GitRepository, settings domain.RepositorySettings) error
+}
+
+type SettingsStore struct {
+	providers ProviderMap
+}
+
+func NewSettingsStore(providers ProviderMap) *SettingsStore {
+	return &SettingsStore{
+		providers: providers,
+	}
+}
+
+func (s *SettingsStore) FetchSettingsForRepository(repository *domain.GitRepository) (domain.RepositorySettings, error) {
+	remote, err := s.findRemote(repository)
+	if err != nil {
+		return domain.RepositorySettings{}, err
+	}
+	return remote.FetchSettings(repository)
+}
+
+func (s *SettingsStore) UpdateSettingsForRepository(repository *domain.GitRepository, settings domain.RepositorySettings) error {
+	remote, err := s.findRemote(repository)
+	if err != nil {
+		return err
+	}
+	return remote.UpdateSettings(repository, settings)
+}
+
+// findRemote returns the SettingsRemote that supports the given repository.
+// Returns ErrCapabilityNotSupported if the responsible Remote doesn't support repository settings.
+func (s *SettingsStore) findRemote(repository *domain.GitRepository) (SettingsRemote, error) {
+	remote, err := s.providers.findRemoteWithCapability(repository, CapabilityRepositorySettings)
+	if err != nil {
+		return nil, err
+	}
+	return remote.(SettingsRemote), nil
+}
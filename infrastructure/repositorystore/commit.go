@@ -3,6 +3,8 @@ package repositorystore
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/ccremer/greposync/domain"
@@ -21,11 +23,24 @@ func (s *RepositoryStore) Commit(repository *domain.GitRepository, options domai
 		return err
 	}
 
-	args := []string{"commit", "-a", "-F", f.Name()}
+	args := append(signingArgs(options.SigningKey), "commit", "-a", "-F", f.Name())
+	if options.SigningKey.IsEnabled() {
+		args = append(args, "--gpg-sign")
+	}
+	if options.Signoff {
+		args = append(args, "--signoff")
+	}
+	for _, trailer := range options.Trailers {
+		args = append(args, "--trailer", trailer)
+	}
 
 	// Try to figure out if amend makes sense
 	if options.Amend {
-		if hasCommits, err := HasCommitsBetween(repository, repository.DefaultBranch, repository.CommitBranch); err != nil {
+		base, err := preferRemoteBranch(repository, repository.GetTargetBranch())
+		if err != nil {
+			return err
+		}
+		if hasCommits, err := HasCommitsBetween(repository, base, repository.CommitBranch); err != nil {
 			return err
 		} else if hasCommits {
 			args = append(args, "--amend")
@@ -33,7 +48,8 @@ func (s *RepositoryStore) Commit(repository *domain.GitRepository, options domai
 	}
 
 	// Commit
-	out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, args))
+	env := append(identityEnv("AUTHOR", options.Author), identityEnv("COMMITTER", options.Committer)...)
+	out, stderr, err := execGitCommandWithEnv(repository.RootDir, env, s.instrumentation.logGitArguments(repository, 0, args))
 	if err != nil {
 		s.instrumentation.logInfo(repository, out)
 		return mergeWithStdErr(err, stderr)
@@ -42,6 +58,80 @@ func (s *RepositoryStore) Commit(repository *domain.GitRepository, options domai
 	return nil
 }
 
+// CheckSigningKey returns an error if the given key is not available for signing commits.
+// It's not an error if signing is disabled.
+func (s *RepositoryStore) CheckSigningKey(key domain.SigningKey) error {
+	switch key.Format {
+	case domain.SigningFormatGPG:
+		args := []string{"--list-secret-keys", "--with-colons"}
+		if key.Key != "" {
+			args = append(args, key.Key)
+		}
+		out, stderr, err := execCommand(domain.NewPath(), GPGBinary, args)
+		if err != nil {
+			return fmt.Errorf("%w: GPG key '%s' not available: %s", domain.ErrInvalidArgument, key.Key, strings.TrimSpace(stderr))
+		}
+		if !strings.Contains(out, "sec:") {
+			return fmt.Errorf("%w: no secret GPG key available", domain.ErrInvalidArgument)
+		}
+	case domain.SigningFormatSSH:
+		if strings.HasPrefix(key.Key, "key::") {
+			return nil
+		}
+		if path := expandHome(key.Key); !domain.NewFilePath(path).FileExists() {
+			return fmt.Errorf("%w: SSH key file '%s' not found", domain.ErrInvalidArgument, path)
+		}
+	}
+	return nil
+}
+
+// signingArgs returns the Git configuration arguments that configure signing with the given key.
+// Returns an empty slice if signing is disabled.
+func signingArgs(key domain.SigningKey) []string {
+	args := make([]string, 0)
+	switch key.Format {
+	case domain.SigningFormatGPG:
+		args = append(args, "-c", "gpg.format=openpgp")
+	case domain.SigningFormatSSH:
+		args = append(args, "-c", "gpg.format=ssh")
+	default:
+		return args
+	}
+	if key.Key != "" {
+		signingKey := key.Key
+		if !strings.HasPrefix(signingKey, "key::") {
+			signingKey = expandHome(signingKey)
+		}
+		args = append(args, "-c", "user.signingKey="+signingKey)
+	}
+	return args
+}
+
+// identityEnv returns the environment variables that set the given identity for the given role, which is either `AUTHOR` or `COMMITTER`.
+// Environment variables take precedence over `user.name` and `user.email` in the Git configuration.
+// Returns an empty slice if the identity is empty.
+func identityEnv(role string, identity domain.Identity) []string {
+	if identity.IsEmpty() {
+		return []string{}
+	}
+	return []string{
+		fmt.Sprintf("GIT_%s_NAME=%s", role, identity.Name),
+		fmt.Sprintf("GIT_%s_EMAIL=%s", role, identity.Email),
+	}
+}
+
+// expandHome replaces a leading `~/` with the home directory of the current user.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~/"))
+}
+
 func (s *RepositoryStore) Add(repository *domain.GitRepository) error {
 	out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, []string{"add", "-A"}))
 	if err != nil {
@@ -51,10 +141,14 @@ func (s *RepositoryStore) Add(repository *domain.GitRepository) error {
 	return nil
 }
 
+// Diff implements domain.GitRepositoryStore.
+// In a shallow clone, the parent of HEAD is fetched if it's missing.
 func (s *RepositoryStore) Diff(repository *domain.GitRepository, options domain.DiffOptions) (string, error) {
 	args := []string{"diff", "HEAD~1"}
 	if options.WorkDirToHEAD {
 		args = []string{"diff", "HEAD"}
+	} else if err := s.ensureParentCommit(repository); err != nil {
+		return "", err
 	}
 	out, stderr, err := execGitCommand(repository.RootDir, args)
 	if err != nil {
@@ -67,6 +161,60 @@ func (s *RepositoryStore) Diff(repository *domain.GitRepository, options domain.
 	return out, nil
 }
 
+// DiffStat implements domain.GitRepositoryStore.
+// Like HasCommitsBetween, the remote-tracking branch of baseBranch is preferred over the local branch,
+// and the history of a shallow clone is deepened until the branches have a common ancestor.
+func (s *RepositoryStore) DiffStat(repository *domain.GitRepository, baseBranch, headBranch string) ([]domain.FileChange, error) {
+	base, err := preferRemoteBranch(repository, baseBranch)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMergeBase(repository, base, headBranch); err != nil {
+		return nil, err
+	}
+	revisions := fmt.Sprintf("%s...%s", base, headBranch)
+	statusOut, stderr, err := execGitCommand(repository.RootDir, []string{"diff", "--no-renames", "--name-status", "-z", revisions})
+	if err != nil {
+		return nil, mergeWithStdErr(err, stderr)
+	}
+	numstatOut, stderr, err := execGitCommand(repository.RootDir, []string{"diff", "--no-renames", "--numstat", "-z", revisions})
+	if err != nil {
+		return nil, mergeWithStdErr(err, stderr)
+	}
+	return parseDiffStat(statusOut, numstatOut), nil
+}
+
+// parseDiffStat combines the NUL-separated outputs of `git diff --name-status -z` and `git diff --numstat -z`.
+func parseDiffStat(statusOut, numstatOut string) []domain.FileChange {
+	changes := make([]domain.FileChange, 0)
+	index := map[string]int{}
+	// --name-status -z prints "<status>\0<path>\0" per file.
+	fields := strings.Split(strings.TrimSuffix(statusOut, "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		changeType := domain.FileModified
+		switch fields[i] {
+		case "A":
+			changeType = domain.FileAdded
+		case "D":
+			changeType = domain.FileDeleted
+		}
+		index[fields[i+1]] = len(changes)
+		changes = append(changes, domain.FileChange{Path: domain.NewFilePath(fields[i+1]), Type: changeType})
+	}
+	// --numstat -z prints "<additions>\t<deletions>\t<path>\0" per file, with "-" for binary files.
+	for _, line := range strings.Split(numstatOut, "\x00") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if i, exists := index[parts[2]]; exists {
+			changes[i].Additions, _ = strconv.Atoi(parts[0])
+			changes[i].Deletions, _ = strconv.Atoi(parts[1])
+		}
+	}
+	return changes
+}
+
 func (s *RepositoryStore) IsDirty(repository *domain.GitRepository) bool {
 	out, stderr, err := execGitCommand(repository.RootDir, []string{"status", "--short"})
 	if err != nil {
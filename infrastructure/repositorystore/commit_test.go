@@ -0,0 +1,152 @@
+package repositorystore
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryStore_DiffStat(t *testing.T) {
+	repo := &domain.GitRepository{RootDir: domain.NewFilePath(t.TempDir())}
+	runGit(t, repo, "init")
+	runGit(t, repo, "checkout", "-b", "main")
+	commitFile(t, repo, "modified.txt", "line1\nline2\n")
+	commitFile(t, repo, "deleted.txt", "line1\n")
+	runGit(t, repo, "checkout", "-b", "greposync-update")
+	commitFile(t, repo, "modified.txt", "line1\nchanged\nline3\n")
+	commitFile(t, repo, "added.txt", "line1\n")
+	runGit(t, repo, "rm", "deleted.txt")
+	runGit(t, repo, "commit", "-m", "delete file")
+	require.NoError(t, os.WriteFile(filepath.Join(repo.RootDir.String(), "binary.bin"), []byte{0, 1, 2}, 0644))
+	runGit(t, repo, "add", "binary.bin")
+	runGit(t, repo, "commit", "-m", "add binary")
+
+	s := &RepositoryStore{}
+	result, err := s.DiffStat(repo, "main", "greposync-update")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []domain.FileChange{
+		{Path: "added.txt", Type: domain.FileAdded, Additions: 1},
+		{Path: "binary.bin", Type: domain.FileAdded},
+		{Path: "deleted.txt", Type: domain.FileDeleted, Deletions: 1},
+		{Path: "modified.txt", Type: domain.FileModified, Additions: 2, Deletions: 1},
+	}, result)
+}
+
+func TestRepositoryStore_Commit(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "greposync")
+	t.Setenv("GIT_AUTHOR_EMAIL", "greposync@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "greposync")
+	t.Setenv("GIT_COMMITTER_EMAIL", "greposync@example.com")
+	tests := map[string]struct {
+		givenOptions      domain.CommitOptions
+		expectedSignoff   bool
+		expectedSignature bool
+		expectedHeaders   []string
+	}{
+		"GivenNoOptions_ThenExpectUnsignedCommit": {
+			givenOptions: domain.CommitOptions{Message: "update"},
+		},
+		"GivenSignoff_ThenExpectTrailer": {
+			givenOptions:    domain.CommitOptions{Message: "update", Signoff: true},
+			expectedSignoff: true,
+		},
+		"GivenSSHKey_ThenExpectSignedCommit": {
+			givenOptions:      domain.CommitOptions{Message: "update", SigningKey: domain.SigningKey{Format: domain.SigningFormatSSH}},
+			expectedSignature: true,
+		},
+		"GivenIdentities_ThenExpectAuthorAndCommitter": {
+			givenOptions: domain.CommitOptions{
+				Message:   "update",
+				Author:    domain.Identity{Name: "Author", Email: "author@example.com"},
+				Committer: domain.Identity{Name: "Bot", Email: "bot@example.com"},
+				Signoff:   true,
+			},
+			expectedHeaders: []string{"author Author <author@example.com>", "committer Bot <bot@example.com>", "Signed-off-by: Bot <bot@example.com>"},
+		},
+		"GivenTrailers_ThenExpectTrailersInMessage": {
+			givenOptions: domain.CommitOptions{
+				Message:  "update",
+				Trailers: []string{"Refs: TICKET-123", "Co-authored-by: Other <other@example.com>"},
+			},
+			expectedHeaders: []string{"author greposync <greposync@example.com>", "\n\nRefs: TICKET-123\nCo-authored-by: Other <other@example.com>\n"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := &domain.GitRepository{RootDir: domain.NewFilePath(t.TempDir())}
+			repo.URL = newFileURL(repo)
+			runGit(t, repo, "init")
+			commitFile(t, repo, "file.txt", "initial")
+			require.NoError(t, os.WriteFile(filepath.Join(repo.RootDir.String(), "file.txt"), []byte("changed"), 0644))
+			options := tt.givenOptions
+			if options.SigningKey.Format == domain.SigningFormatSSH {
+				options.SigningKey.Key = generateSSHKey(t)
+			}
+
+			s := NewRepositoryStore(NewRepositoryStoreInstrumentation(loggingtest.NewDiscardLoggerFactory()), nil)
+			require.NoError(t, s.Commit(repo, options))
+			commit, _, err := execGitCommand(repo.RootDir, []string{"cat-file", "commit", "HEAD"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSignoff, strings.Contains(commit, "Signed-off-by: greposync <greposync@example.com>"), "signoff")
+			assert.Equal(t, tt.expectedSignature, strings.Contains(commit, "-----BEGIN SSH SIGNATURE-----"), "signature")
+			for _, header := range tt.expectedHeaders {
+				assert.Contains(t, commit, header)
+			}
+		})
+	}
+}
+
+func TestRepositoryStore_CheckSigningKey(t *testing.T) {
+	tests := map[string]struct {
+		givenKey      func(t *testing.T) domain.SigningKey
+		expectedError bool
+	}{
+		"GivenNoSigning_ThenExpectNoError": {
+			givenKey: func(t *testing.T) domain.SigningKey { return domain.SigningKey{} },
+		},
+		"GivenSSHKeyFile_ThenExpectNoError": {
+			givenKey: func(t *testing.T) domain.SigningKey {
+				return domain.SigningKey{Format: domain.SigningFormatSSH, Key: generateSSHKey(t)}
+			},
+		},
+		"GivenLiteralSSHKey_ThenExpectNoError": {
+			givenKey: func(t *testing.T) domain.SigningKey {
+				return domain.SigningKey{Format: domain.SigningFormatSSH, Key: "key::ssh-ed25519 AAAA"}
+			},
+		},
+		"GivenMissingSSHKeyFile_ThenExpectError": {
+			givenKey: func(t *testing.T) domain.SigningKey {
+				return domain.SigningKey{Format: domain.SigningFormatSSH, Key: filepath.Join(t.TempDir(), "id_ed25519")}
+			},
+			expectedError: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &RepositoryStore{}
+			err := s.CheckSigningKey(tt.givenKey(t))
+			if tt.expectedError {
+				assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func generateSSHKey(t *testing.T) string {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	_, stderr, err := execCommand(domain.NewPath(), "ssh-keygen", []string{"-q", "-t", "ed25519", "-N", "", "-f", keyFile})
+	require.NoError(t, err, stderr)
+	return keyFile
+}
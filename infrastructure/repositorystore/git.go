@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 
@@ -12,8 +13,27 @@ import (
 
 var GitBinary = "git"
 
+// GPGBinary is the binary that is used to check the availability of GPG keys.
+var GPGBinary = "gpg"
+
 func execGitCommand(rootDir domain.Path, args []string) (stdOut, stdErr string, cmdErr error) {
-	cmd := exec.Command(GitBinary, args...)
+	return execCommand(rootDir, GitBinary, args)
+}
+
+// execGitCommandWithEnv is like execGitCommand, but adds the given environment variables in the `KEY=value` format.
+func execGitCommandWithEnv(rootDir domain.Path, env []string, args []string) (stdOut, stdErr string, cmdErr error) {
+	return execCommandWithEnv(rootDir, GitBinary, env, args)
+}
+
+func execCommand(rootDir domain.Path, binary string, args []string) (stdOut, stdErr string, cmdErr error) {
+	return execCommandWithEnv(rootDir, binary, nil, args)
+}
+
+func execCommandWithEnv(rootDir domain.Path, binary string, env []string, args []string) (stdOut, stdErr string, cmdErr error) {
+	cmd := exec.Command(binary, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	if rootDir.DirExists() {
 		cmd.Dir = rootDir.String()
 	}
@@ -64,6 +84,55 @@ func HasCommitsBetween(repository *domain.GitRepository, rootBranch, headBranch
 	return out != "", err
 }
 
+// hasChangesBetween returns true if the files changed in headBranch since it diverged from rootBranch have a different content than in rootBranch.
+func hasChangesBetween(repository *domain.GitRepository, rootBranch, headBranch string) (bool, error) {
+	out, stderr, err := execGitCommand(repository.RootDir, []string{"diff", "--name-only", "-z", fmt.Sprintf("%s...%s", rootBranch, headBranch)})
+	if err != nil {
+		return false, mergeWithStdErr(err, stderr)
+	}
+	files := make([]string, 0)
+	for _, file := range strings.Split(out, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		return false, nil
+	}
+	args := append([]string{"diff", "--quiet", rootBranch, headBranch, "--"}, files...)
+	_, stderr, err = execGitCommand(repository.RootDir, args)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return true, nil
+	}
+	if err != nil {
+		return false, mergeWithStdErr(err, stderr)
+	}
+	return false, nil
+}
+
+// isShallow returns true if the history of the repository is truncated.
+func isShallow(repository *domain.GitRepository) (bool, error) {
+	out, stderr, err := execGitCommand(repository.RootDir, []string{"rev-parse", "--is-shallow-repository"})
+	if err != nil {
+		return false, mergeWithStdErr(err, stderr)
+	}
+	return strings.TrimSpace(out) == "true", nil
+}
+
+// hasMergeBase returns true if the given revisions have a common ancestor in the local history.
+func hasMergeBase(repository *domain.GitRepository, base, head string) (bool, error) {
+	_, stderr, err := execGitCommand(repository.RootDir, []string{"merge-base", base, head})
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	if err != nil {
+		return false, mergeWithStdErr(err, stderr)
+	}
+	return true, nil
+}
+
 // GetDefaultBranch returns the name of the default branch in origin.
 // Returns an error if either Git command failed or if no default branch could be detected.
 func GetDefaultBranch(repository *domain.GitRepository) (string, error) {
@@ -0,0 +1,347 @@
+package repositorystore
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Add implements domain.GitRepositoryStore.
+// Files that are ignored by `.gitignore` files in the repository are not staged.
+func (s *GoGitRepositoryStore) Add(repository *domain.GitRepository) error {
+	_, w, err := openWorktree(repository)
+	if err != nil {
+		return err
+	}
+	// Unlike `git add`, go-git only skips ignored files if the patterns are given explicitly.
+	patterns, err := gitignore.ReadPatterns(w.Filesystem, nil)
+	if err != nil {
+		return newGitError(repository, "add", err)
+	}
+	w.Excludes = patterns
+	s.logOperation(repository, "add", "-A")
+	if err := w.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return newGitError(repository, "add", err)
+	}
+	status, err := w.Status()
+	if err != nil {
+		return newGitError(repository, "add", err)
+	}
+	// Deleted files are staged explicitly.
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Deleted {
+			if _, err := w.Remove(path); err != nil {
+				return newGitError(repository, "add", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Commit implements domain.GitRepositoryStore.
+// Returns ErrNotSupported if the commit should be signed.
+func (s *GoGitRepositoryStore) Commit(repository *domain.GitRepository, options domain.CommitOptions) error {
+	if options.SigningKey.IsEnabled() {
+		return fmt.Errorf("%w: signing commits with go-git", ErrNotSupported)
+	}
+	r, w, err := openWorktree(repository)
+	if err != nil {
+		return err
+	}
+	author, err := resolveSignature(r, options.Author, "AUTHOR")
+	if err != nil {
+		return err
+	}
+	committer, err := resolveSignature(r, options.Committer, "COMMITTER")
+	if err != nil {
+		return err
+	}
+	commitOptions := &git.CommitOptions{All: true, Author: author, Committer: committer}
+
+	// Try to figure out if amend makes sense
+	if options.Amend {
+		if hasCommits, err := s.hasCommits(r, preferRemoteReference(r, repository.GetTargetBranch()), plumbing.NewBranchReferenceName(repository.CommitBranch)); err != nil {
+			return newGitError(repository, "commit", err)
+		} else if hasCommits {
+			head, err := headCommit(r)
+			if err != nil {
+				return newGitError(repository, "commit", err)
+			}
+			// Like `git commit --amend`, the author of the amended commit is kept.
+			commitOptions.Author = &head.Author
+			commitOptions.Parents = head.ParentHashes
+		}
+	}
+
+	trailers := options.Trailers
+	if options.Signoff {
+		trailers = append(trailers, fmt.Sprintf("Signed-off-by: %s <%s>", committer.Name, committer.Email))
+	}
+	message := buildCommitMessage(options.Message, trailers)
+	s.logOperation(repository, "commit", "-a")
+	hash, err := w.Commit(message, commitOptions)
+	if err != nil {
+		return newGitError(repository, "commit", err)
+	}
+	s.instrumentation.logDebugInfo(repository, hash.String())
+	return nil
+}
+
+// buildCommitMessage appends the trailers to the message, separated by an empty line.
+func buildCommitMessage(message string, trailers []string) string {
+	message = strings.TrimRight(message, " \t\n")
+	if len(trailers) > 0 {
+		message = fmt.Sprintf("%s\n\n%s", message, strings.Join(trailers, "\n"))
+	}
+	return message + "\n"
+}
+
+// resolveSignature returns the signature for the given role, which is either `AUTHOR` or `COMMITTER`.
+// Like the Git binary, the identity is taken from the first source that is set:
+// The given identity, the `GIT_<role>_NAME` and `GIT_<role>_EMAIL` environment variables or the Git configuration.
+func resolveSignature(r *git.Repository, identity domain.Identity, role string) (*object.Signature, error) {
+	now := time.Now()
+	if !identity.IsEmpty() {
+		return &object.Signature{Name: identity.Name, Email: identity.Email, When: now}, nil
+	}
+	if name, email := os.Getenv("GIT_"+role+"_NAME"), os.Getenv("GIT_"+role+"_EMAIL"); name != "" && email != "" {
+		return &object.Signature{Name: name, Email: email, When: now}, nil
+	}
+	cfg, err := r.ConfigScoped(config.SystemScope)
+	if err != nil {
+		return nil, err
+	}
+	name, email := cfg.User.Name, cfg.User.Email
+	switch {
+	case role == "AUTHOR" && cfg.Author.Name != "":
+		name, email = cfg.Author.Name, cfg.Author.Email
+	case role == "COMMITTER" && cfg.Committer.Name != "":
+		name, email = cfg.Committer.Name, cfg.Committer.Email
+	}
+	if name == "" || email == "" {
+		return nil, fmt.Errorf("%w: no %s identity configured", domain.ErrInvalidArgument, strings.ToLower(role))
+	}
+	return &object.Signature{Name: name, Email: email, When: now}, nil
+}
+
+// Diff implements domain.GitRepositoryStore.
+func (s *GoGitRepositoryStore) Diff(repository *domain.GitRepository, options domain.DiffOptions) (string, error) {
+	r, w, err := openWorktree(repository)
+	if err != nil {
+		return "", err
+	}
+	head, err := headCommit(r)
+	if err != nil {
+		return "", newGitError(repository, "diff", err)
+	}
+	if options.WorkDirToHEAD {
+		patch, err := worktreePatch(w, head)
+		return patch, newGitError(repository, "diff", err)
+	}
+	if head.NumParents() == 0 {
+		s.instrumentation.logInfo(repository, "This is the first commit, no diff available.")
+		return "", nil
+	}
+	parent, err := head.Parent(0)
+	if err != nil {
+		return "", newGitError(repository, "diff", err)
+	}
+	patch, err := parent.Patch(head)
+	if err != nil {
+		return "", newGitError(repository, "diff", err)
+	}
+	return patch.String(), nil
+}
+
+// DiffStat implements domain.GitRepositoryStore.
+// Like HasCommitsBetween, the remote-tracking branch of baseBranch is preferred over the local branch.
+func (s *GoGitRepositoryStore) DiffStat(repository *domain.GitRepository, baseBranch, headBranch string) ([]domain.FileChange, error) {
+	r, err := openRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+	base, headRef := preferRemoteReference(r, baseBranch), plumbing.NewBranchReferenceName(headBranch)
+	if err := s.ensureMergeBase(repository, r, base, headRef); err != nil {
+		return nil, err
+	}
+	mergeBase, head, err := resolveMergeBase(r, base, headRef)
+	if err != nil {
+		return nil, newGitError(repository, "diff", err)
+	}
+	patch, err := mergeBase.Patch(head)
+	if err != nil {
+		return nil, newGitError(repository, "diff", err)
+	}
+	changes := make([]domain.FileChange, 0)
+	for _, filePatch := range patch.FilePatches() {
+		from, to := filePatch.Files()
+		change := domain.FileChange{Type: domain.FileModified}
+		switch {
+		case from == nil:
+			change.Type = domain.FileAdded
+			change.Path = domain.NewFilePath(to.Path())
+		case to == nil:
+			change.Type = domain.FileDeleted
+			change.Path = domain.NewFilePath(from.Path())
+		default:
+			change.Path = domain.NewFilePath(to.Path())
+		}
+		change.Additions, change.Deletions = countChangedLines(filePatch.Chunks())
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// countChangedLines returns the number of added and deleted lines in the given chunks.
+// The numbers are 0 for binary files, as they don't have chunks.
+func countChangedLines(chunks []fdiff.Chunk) (additions, deletions int) {
+	for _, c := range chunks {
+		lines := strings.Count(c.Content(), "\n")
+		if c.Content() != "" && !strings.HasSuffix(c.Content(), "\n") {
+			lines++
+		}
+		switch c.Type() {
+		case fdiff.Add:
+			additions += lines
+		case fdiff.Delete:
+			deletions += lines
+		}
+	}
+	return additions, deletions
+}
+
+// HasCommitsBetween implements domain.GitRepositoryStore.
+// The remote-tracking branch of baseBranch is preferred over the local branch, as the local branch may be outdated.
+func (s *GoGitRepositoryStore) HasCommitsBetween(repository *domain.GitRepository, baseBranch, headBranch string) (bool, error) {
+	if baseBranch == "" {
+		return false, fmt.Errorf("%w: rootBranch cannot be empty", domain.ErrInvalidArgument)
+	}
+	r, err := openRepository(repository)
+	if err != nil {
+		return false, err
+	}
+	base := preferRemoteReference(r, baseBranch)
+	head := plumbing.HEAD
+	if headBranch != "" {
+		head = plumbing.NewBranchReferenceName(headBranch)
+	}
+	if hasCommits, err := s.hasCommits(r, base, head); err != nil || !hasCommits {
+		return false, newGitError(repository, "log", err)
+	}
+	if err := s.ensureMergeBase(repository, r, base, head); err != nil {
+		return false, err
+	}
+	hasChanges, err := hasChangesSinceMergeBase(r, base, head)
+	return hasChanges, newGitError(repository, "diff", err)
+}
+
+// hasCommits returns true if head contains commits that are not reachable from base.
+func (s *GoGitRepositoryStore) hasCommits(r *git.Repository, base, head plumbing.ReferenceName) (bool, error) {
+	baseCommit, err := resolveCommit(r, base)
+	if err != nil {
+		return false, err
+	}
+	headCommit, err := resolveCommit(r, head)
+	if err != nil {
+		return false, err
+	}
+	if baseCommit.Hash == headCommit.Hash {
+		return false, nil
+	}
+	contained, err := isAncestor(r, headCommit, baseCommit)
+	return !contained, err
+}
+
+// hasChangesSinceMergeBase returns true if the files changed in head since it diverged from base have a different content than in base.
+func hasChangesSinceMergeBase(r *git.Repository, base, head plumbing.ReferenceName) (bool, error) {
+	mergeBase, headCommit, err := resolveMergeBase(r, base, head)
+	if err != nil {
+		return false, err
+	}
+	baseCommit, err := resolveCommit(r, base)
+	if err != nil {
+		return false, err
+	}
+	trees := make([]*object.Tree, 3)
+	for i, commit := range []*object.Commit{mergeBase, headCommit, baseCommit} {
+		if trees[i], err = commit.Tree(); err != nil {
+			return false, err
+		}
+	}
+	changes, err := object.DiffTree(trees[0], trees[1])
+	if err != nil {
+		return false, err
+	}
+	for _, change := range changes {
+		path := change.To.Name
+		if path == "" {
+			path = change.From.Name
+		}
+		headEntry, headErr := trees[1].FindEntry(path)
+		baseEntry, baseErr := trees[2].FindEntry(path)
+		if (headErr == nil) != (baseErr == nil) {
+			return true, nil
+		}
+		if headErr == nil && (headEntry.Hash != baseEntry.Hash || headEntry.Mode != baseEntry.Mode) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsDirty returns true if the working tree contains changes or untracked files.
+func (s *GoGitRepositoryStore) IsDirty(repository *domain.GitRepository) bool {
+	_, w, err := openWorktree(repository)
+	if err != nil {
+		s.instrumentation.logInfo(repository, err.Error())
+		return true
+	}
+	status, err := w.Status()
+	if err != nil {
+		s.instrumentation.logInfo(repository, err.Error())
+		return true
+	}
+	if status.IsClean() {
+		s.instrumentation.logInfo(repository, "Nothing to commit, working tree clean")
+		return false
+	}
+	return true
+}
+
+// CheckSigningKey returns ErrNotSupported if signing is enabled, as go-git can't sign commits with the keys of the GPG agent or SSH.
+func (s *GoGitRepositoryStore) CheckSigningKey(key domain.SigningKey) error {
+	if key.IsEnabled() {
+		return fmt.Errorf("%w: signing commits with go-git", ErrNotSupported)
+	}
+	return nil
+}
+
+// preferRemoteReference returns the remote-tracking branch of the given branch in origin if it exists, otherwise the local branch.
+func preferRemoteReference(r *git.Repository, branch string) plumbing.ReferenceName {
+	remote := plumbing.NewRemoteReferenceName("origin", branch)
+	if _, err := r.Reference(remote, false); err == nil {
+		return remote
+	}
+	return plumbing.NewBranchReferenceName(branch)
+}
+
+func resolveCommit(r *git.Repository, name plumbing.ReferenceName) (*object.Commit, error) {
+	ref, err := r.Reference(name, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name.Short(), err)
+	}
+	return r.CommitObject(ref.Hash())
+}
+
+func headCommit(r *git.Repository) (*object.Commit, error) {
+	return resolveCommit(r, plumbing.HEAD)
+}
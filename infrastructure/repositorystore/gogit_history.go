@@ -0,0 +1,170 @@
+package repositorystore
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// The commit iterators of go-git fail with plumbing.ErrObjectNotFound at the boundary of shallow clones, where the parents of the shallow commits are missing.
+// The functions in this file walk only the local history instead.
+
+// shallowCommits returns the commits whose parents are missing in the local history.
+// It returns an empty map if the repository isn't a shallow clone.
+func shallowCommits(r *git.Repository) (map[plumbing.Hash]bool, error) {
+	hashes, err := r.Storer.Shallow()
+	if err != nil {
+		return nil, err
+	}
+	shallow := make(map[plumbing.Hash]bool, len(hashes))
+	for _, hash := range hashes {
+		shallow[hash] = true
+	}
+	return shallow, nil
+}
+
+// localHistory returns the given commit and its ancestors in breadth-first order.
+// The missing parents of the given shallow commits are skipped.
+func localHistory(r *git.Repository, commit *object.Commit, shallow map[plumbing.Hash]bool) ([]*object.Commit, error) {
+	seen := map[plumbing.Hash]bool{commit.Hash: true}
+	history := []*object.Commit{commit}
+	for i := 0; i < len(history); i++ {
+		for _, hash := range history[i].ParentHashes {
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			parent, err := r.CommitObject(hash)
+			if errors.Is(err, plumbing.ErrObjectNotFound) && shallow[history[i].Hash] {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			history = append(history, parent)
+		}
+	}
+	return history, nil
+}
+
+// isAncestor returns true if ancestor is reachable from commit.
+// In shallow clones, only the local history is searched.
+func isAncestor(r *git.Repository, ancestor, commit *object.Commit) (bool, error) {
+	shallow, err := shallowCommits(r)
+	if err != nil {
+		return false, err
+	}
+	if len(shallow) == 0 {
+		return ancestor.IsAncestor(commit)
+	}
+	history, err := localHistory(r, commit, shallow)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range history {
+		if c.Hash == ancestor.Hash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// localMergeBase returns the first commit in the local history of head that is also in the local history of base.
+// This is the merge base if head has been branched off base.
+// It returns nil if the local histories have no common commit.
+func localMergeBase(r *git.Repository, base, head *object.Commit, shallow map[plumbing.Hash]bool) (*object.Commit, error) {
+	baseHistory, err := localHistory(r, base, shallow)
+	if err != nil {
+		return nil, err
+	}
+	inBase := make(map[plumbing.Hash]bool, len(baseHistory))
+	for _, c := range baseHistory {
+		inBase[c.Hash] = true
+	}
+	headHistory, err := localHistory(r, head, shallow)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range headHistory {
+		if inBase[c.Hash] {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveMergeBase returns the best common ancestor of base and head, as well as the head commit.
+// In shallow clones, the merge base is searched in the local history, see ensureMergeBase.
+func resolveMergeBase(r *git.Repository, base, head plumbing.ReferenceName) (mergeBase *object.Commit, headCommit *object.Commit, err error) {
+	baseCommit, err := resolveCommit(r, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	headCommit, err = resolveCommit(r, head)
+	if err != nil {
+		return nil, nil, err
+	}
+	shallow, err := shallowCommits(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(shallow) > 0 {
+		mergeBase, err = localMergeBase(r, baseCommit, headCommit, shallow)
+		if err != nil || mergeBase != nil {
+			return mergeBase, headCommit, err
+		}
+		return nil, nil, fmt.Errorf("no merge base between %s and %s", base.Short(), head.Short())
+	}
+	bases, err := baseCommit.MergeBase(headCommit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bases) == 0 {
+		return nil, nil, fmt.Errorf("no merge base between %s and %s", base.Short(), head.Short())
+	}
+	return bases[0], headCommit, nil
+}
+
+// ensureMergeBase deepens the history of a shallow clone until base and head have a common ancestor, like RepositoryStore does.
+// After maxDeepenAttempts, the complete history is fetched.
+// Nothing is fetched if the clone isn't shallow or if the common ancestor is already available.
+func (s *GoGitRepositoryStore) ensureMergeBase(repository *domain.GitRepository, r *git.Repository, base, head plumbing.ReferenceName) error {
+	depth := initialDeepenCount
+	for attempt := 1; ; attempt++ {
+		shallow, err := shallowCommits(r)
+		if err != nil || len(shallow) == 0 {
+			return newGitError(repository, "fetch", err)
+		}
+		baseCommit, err := resolveCommit(r, base)
+		if err != nil {
+			return newGitError(repository, "log", err)
+		}
+		headCommit, err := resolveCommit(r, head)
+		if err != nil {
+			return newGitError(repository, "log", err)
+		}
+		if mergeBase, err := localMergeBase(r, baseCommit, headCommit, shallow); err != nil || mergeBase != nil {
+			return newGitError(repository, "log", err)
+		}
+		unshallow := attempt > maxDeepenAttempts
+		if unshallow {
+			depth = math.MaxInt32
+		}
+		s.logOperation(repository, "fetch", "origin", fmt.Sprintf("--depth=%d", depth))
+		err = r.Fetch(&git.FetchOptions{RemoteName: "origin", Depth: depth})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) && !errors.Is(err, transport.ErrEmptyUploadPackRequest) {
+			return newGitError(repository, "fetch", err)
+		}
+		if unshallow {
+			// go-git doesn't remove obsolete shallow commits, so the history would still look shallow.
+			return nil
+		}
+		depth *= 2
+	}
+}
@@ -0,0 +1,199 @@
+package repositorystore
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// worktreePatch returns a unified diff between the given commit and the working tree, like `git diff HEAD`.
+// go-git only supports patches between commits, so the patch is assembled from the file contents.
+// Untracked files are not included.
+func worktreePatch(w *git.Worktree, commit *object.Commit) (string, error) {
+	status, err := w.Status()
+	if err != nil {
+		return "", err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", err
+	}
+	paths := make([]string, 0, len(status))
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Untracked {
+			continue
+		}
+		if fileStatus.Worktree != git.Unmodified || fileStatus.Staging != git.Unmodified {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	patch := &worktreeChanges{}
+	for _, path := range paths {
+		from, err := treeFile(tree, path)
+		if err != nil {
+			return "", err
+		}
+		to, err := worktreeFile(w.Filesystem.Root(), path)
+		if err != nil {
+			return "", err
+		}
+		if from == nil && to == nil {
+			continue
+		}
+		patch.files = append(patch.files, newFilePatch(from, to))
+	}
+	buf := &bytes.Buffer{}
+	err = fdiff.NewUnifiedEncoder(buf, fdiff.DefaultContextLines).Encode(patch)
+	return buf.String(), err
+}
+
+// worktreeChanges implements diff.Patch.
+type worktreeChanges struct {
+	files []fdiff.FilePatch
+}
+
+func (p *worktreeChanges) FilePatches() []fdiff.FilePatch { return p.files }
+func (p *worktreeChanges) Message() string                { return "" }
+
+// worktreeFilePatch implements diff.FilePatch.
+type worktreeFilePatch struct {
+	from, to *fileContent
+	chunks   []fdiff.Chunk
+	binary   bool
+}
+
+func newFilePatch(from, to *fileContent) *worktreeFilePatch {
+	p := &worktreeFilePatch{from: from, to: to}
+	var src, dst string
+	if from != nil {
+		src = from.content
+		p.binary = from.binary
+	}
+	if to != nil {
+		dst = to.content
+		p.binary = p.binary || to.binary
+	}
+	if p.binary {
+		return p
+	}
+	for _, d := range diff.Do(src, dst) {
+		op := fdiff.Equal
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		}
+		p.chunks = append(p.chunks, chunk{content: d.Text, op: op})
+	}
+	return p
+}
+
+func (p *worktreeFilePatch) IsBinary() bool        { return p.binary }
+func (p *worktreeFilePatch) Chunks() []fdiff.Chunk { return p.chunks }
+
+func (p *worktreeFilePatch) Files() (from, to fdiff.File) {
+	// Nil pointers have to be converted to nil interfaces explicitly.
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return from, to
+}
+
+// chunk implements diff.Chunk.
+type chunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c chunk) Content() string       { return c.content }
+func (c chunk) Type() fdiff.Operation { return c.op }
+
+// fileContent implements diff.File.
+type fileContent struct {
+	path    string
+	hash    plumbing.Hash
+	mode    filemode.FileMode
+	content string
+	binary  bool
+}
+
+func (f *fileContent) Hash() plumbing.Hash     { return f.hash }
+func (f *fileContent) Mode() filemode.FileMode { return f.mode }
+func (f *fileContent) Path() string            { return f.path }
+
+// treeFile returns the file at the given path in the tree, or nil if it doesn't exist.
+func treeFile(tree *object.Tree, path string) (*fileContent, error) {
+	file, err := tree.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	binary, err := file.IsBinary()
+	if err != nil {
+		return nil, err
+	}
+	content := ""
+	if !binary {
+		if content, err = file.Contents(); err != nil {
+			return nil, err
+		}
+	}
+	return &fileContent{path: path, hash: file.Hash, mode: file.Mode, content: content, binary: binary}, nil
+}
+
+// worktreeFile returns the file at the given path in the working tree, or nil if it doesn't exist.
+func worktreeFile(root, path string) (*fileContent, error) {
+	fullPath := filepath.Join(root, filepath.FromSlash(path))
+	info, err := os.Lstat(fullPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		data = []byte(target)
+	} else if data, err = os.ReadFile(fullPath); err != nil {
+		return nil, err
+	}
+	// Like Git, a file is considered binary if it contains a NUL byte.
+	binary := bytes.IndexByte(data, 0) >= 0
+	content := ""
+	if !binary {
+		content = string(data)
+	}
+	return &fileContent{
+		path:    path,
+		hash:    plumbing.ComputeHash(plumbing.BlobObject, data),
+		mode:    mode,
+		content: content,
+		binary:  binary,
+	}, nil
+}
@@ -0,0 +1,332 @@
+package repositorystore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// GoGitRepositoryStore is a domain.GitRepositoryStore that performs the Git operations with go-git instead of the Git binary.
+// The managed repositories are loaded by the embedded RepositoryStore.
+//
+// Compared to RepositoryStore, there are a few limitations:
+//   - Pull only fast-forwards the commit branch, diverged branches are not merged.
+//   - Commits can't be signed.
+//   - Partial and sparse clones are not supported, only shallow clones.
+type GoGitRepositoryStore struct {
+	*RepositoryStore
+}
+
+// GitError is returned by GoGitRepositoryStore if a Git operation failed.
+// The underlying errors of go-git, for example git.ErrNonFastForwardUpdate or transport.ErrAuthenticationRequired, can be checked with errors.Is.
+type GitError struct {
+	// Operation is the name of the failed Git operation, e.g. `fetch`.
+	Operation string
+	// Repository is the full name of the repository.
+	Repository string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.
+func (e *GitError) Error() string {
+	return fmt.Sprintf("git %s failed in %s: %v", e.Operation, e.Repository, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// NewGoGitRepositoryStore returns a new instance that shares the configuration with the given RepositoryStore.
+func NewGoGitRepositoryStore(store *RepositoryStore) *GoGitRepositoryStore {
+	return &GoGitRepositoryStore{RepositoryStore: store}
+}
+
+func newGitError(repository *domain.GitRepository, operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &GitError{Operation: operation, Repository: repository.URL.GetFullName(), Err: err}
+}
+
+// Clone implements domain.GitRepositoryStore.
+// All branches are cloned, also if the history is truncated with domain.CloneOptions Depth.
+// Returns ErrNotSupported if a Filter or Sparse is given in the options.
+func (s *GoGitRepositoryStore) Clone(repository *domain.GitRepository, options domain.CloneOptions) error {
+	if repository.RootDir.DirExists() {
+		return errors.New("clone exists already")
+	}
+	if options.Filter != domain.CloneFilterNone || options.Sparse {
+		return newGitError(repository, "clone", fmt.Errorf("%w: partial and sparse clones", ErrNotSupported))
+	}
+	s.instrumentation.attemptCloning(repository)
+	r, err := git.PlainClone(repository.RootDir.String(), false, &git.CloneOptions{
+		URL:          repository.URL.String(),
+		Depth:        options.Depth,
+		SingleBranch: false,
+	})
+	if err != nil {
+		return newGitError(repository, "clone", err)
+	}
+	// After cloning, HEAD points to the default branch of origin.
+	head, err := r.Head()
+	if err != nil {
+		return newGitError(repository, "clone", err)
+	}
+	repository.DefaultBranch = head.Name().Short()
+	return nil
+}
+
+// Checkout implements domain.GitRepositoryStore.
+// The commit branch is created from HEAD if it doesn't exist locally, unless the target branch differs from the default branch.
+func (s *GoGitRepositoryStore) Checkout(repository *domain.GitRepository) error {
+	r, w, err := openWorktree(repository)
+	if err != nil {
+		return err
+	}
+	branch := plumbing.NewBranchReferenceName(repository.CommitBranch)
+	if repository.GetTargetBranch() != repository.DefaultBranch {
+		// The clone's HEAD is the default branch, so the commit branch is (re)created explicitly.
+		startPoint, err := s.commitBranchStartPoint(repository, r)
+		if err != nil {
+			return err
+		}
+		s.logOperation(repository, "checkout", "-B", branch.Short(), startPoint.Short())
+		start, err := resolveCommit(r, startPoint)
+		if err != nil {
+			return newGitError(repository, "checkout", err)
+		}
+		if err := r.Storer.SetReference(plumbing.NewHashReference(branch, start.Hash)); err != nil {
+			return newGitError(repository, "checkout", err)
+		}
+		err = w.Checkout(&git.CheckoutOptions{Branch: branch, Force: true})
+		return newGitError(repository, "checkout", err)
+	}
+	_, err = r.Reference(branch, false)
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return newGitError(repository, "checkout", err)
+	}
+	create := errors.Is(err, plumbing.ErrReferenceNotFound)
+	s.logOperation(repository, "checkout", branch.Short())
+	err = w.Checkout(&git.CheckoutOptions{Branch: branch, Create: create, Keep: true})
+	return newGitError(repository, "checkout", err)
+}
+
+// commitBranchStartPoint returns the remote commit branch if it exists, otherwise the target branch.
+func (s *GoGitRepositoryStore) commitBranchStartPoint(repository *domain.GitRepository, r *git.Repository) (plumbing.ReferenceName, error) {
+	remote, err := s.pushRemote(repository, r)
+	if err != nil {
+		return "", err
+	}
+	remoteBranch := plumbing.NewRemoteReferenceName(remote, repository.CommitBranch)
+	_, err = r.Reference(remoteBranch, false)
+	if err == nil {
+		return remoteBranch, nil
+	}
+	if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return "", newGitError(repository, "checkout", err)
+	}
+	return preferRemoteReference(r, repository.GetTargetBranch()), nil
+}
+
+// Fetch implements domain.GitRepositoryStore.
+// If the repository is forked, the fork is fetched as well.
+func (s *GoGitRepositoryStore) Fetch(repository *domain.GitRepository) error {
+	r, err := openRepository(repository)
+	if err != nil {
+		return err
+	}
+	if err := s.fetchRemote(repository, r, "origin"); err != nil {
+		return err
+	}
+	if repository.IsForked() {
+		remote, err := s.pushRemote(repository, r)
+		if err != nil {
+			return err
+		}
+		return s.fetchRemote(repository, r, remote)
+	}
+	return nil
+}
+
+func (s *GoGitRepositoryStore) fetchRemote(repository *domain.GitRepository, r *git.Repository, remote string) error {
+	s.logOperation(repository, "fetch", remote)
+	err := r.Fetch(&git.FetchOptions{RemoteName: remote})
+	// In shallow clones, go-git requests all references again, to which the server doesn't respond if they're up-to-date.
+	if errors.Is(err, git.NoErrAlreadyUpToDate) || errors.Is(err, transport.ErrEmptyUploadPackRequest) {
+		return nil
+	}
+	return newGitError(repository, "fetch", err)
+}
+
+// Reset implements domain.GitRepositoryStore.
+func (s *GoGitRepositoryStore) Reset(repository *domain.GitRepository) error {
+	r, w, err := openWorktree(repository)
+	if err != nil {
+		return err
+	}
+	head, err := r.Head()
+	if err != nil {
+		return newGitError(repository, "reset", err)
+	}
+	s.logOperation(repository, "reset", "--hard")
+	err = w.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset})
+	return newGitError(repository, "reset", err)
+}
+
+// Pull implements domain.GitRepositoryStore.
+// The commit branch is fast-forwarded to the remote branch, if it exists.
+// Returns a GitError wrapping git.ErrNonFastForwardUpdate if the branches have diverged.
+func (s *GoGitRepositoryStore) Pull(repository *domain.GitRepository) error {
+	r, w, err := openWorktree(repository)
+	if err != nil {
+		return err
+	}
+	remote, err := s.pushRemote(repository, r)
+	if err != nil {
+		return err
+	}
+	if err := s.fetchRemote(repository, r, remote); err != nil {
+		return err
+	}
+	remoteRef, err := r.Reference(plumbing.NewRemoteReferenceName(remote, repository.CommitBranch), true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return newGitError(repository, "pull", err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return newGitError(repository, "pull", err)
+	}
+	if head.Hash() == remoteRef.Hash() {
+		return nil
+	}
+	remoteCommit, err := r.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return newGitError(repository, "pull", err)
+	}
+	headCommit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return newGitError(repository, "pull", err)
+	}
+	if contained, err := isAncestor(r, remoteCommit, headCommit); err != nil || contained {
+		// The local branch already contains the remote commits.
+		return newGitError(repository, "pull", err)
+	}
+	if fastForward, err := isAncestor(r, headCommit, remoteCommit); err != nil {
+		return newGitError(repository, "pull", err)
+	} else if !fastForward {
+		return newGitError(repository, "pull", git.ErrNonFastForwardUpdate)
+	}
+	s.logOperation(repository, "pull", remote, repository.CommitBranch)
+	err = w.Reset(&git.ResetOptions{Commit: remoteRef.Hash(), Mode: git.MergeReset})
+	return newGitError(repository, "pull", err)
+}
+
+// Push implements domain.GitRepositoryStore.
+// If the repository is forked, the commit branch is pushed to the fork instead of origin.
+func (s *GoGitRepositoryStore) Push(repository *domain.GitRepository, options domain.PushOptions) error {
+	r, err := openRepository(repository)
+	if err != nil {
+		return err
+	}
+	remote, err := s.pushRemote(repository, r)
+	if err != nil {
+		return err
+	}
+	branch := plumbing.NewBranchReferenceName(repository.CommitBranch)
+	refSpec := fmt.Sprintf("%s:%s", branch, branch)
+	if options.Force {
+		refSpec = "+" + refSpec
+	}
+	s.logOperation(repository, "push", remote, refSpec)
+	err = r.Push(&git.PushOptions{RemoteName: remote, RefSpecs: []config.RefSpec{config.RefSpec(refSpec)}})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return newGitError(repository, "push", err)
+}
+
+// DeleteRemoteBranch implements domain.GitRepositoryStore.
+// If the repository is forked, the commit branch is deleted in the fork.
+func (s *GoGitRepositoryStore) DeleteRemoteBranch(repository *domain.GitRepository) error {
+	r, err := openRepository(repository)
+	if err != nil {
+		return err
+	}
+	remote, err := s.pushRemote(repository, r)
+	if err != nil {
+		return err
+	}
+	trackingRef := plumbing.NewRemoteReferenceName(remote, repository.CommitBranch)
+	if _, err := r.Reference(trackingRef, false); errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil
+	} else if err != nil {
+		return newGitError(repository, "push", err)
+	}
+	refSpec := fmt.Sprintf(":%s", plumbing.NewBranchReferenceName(repository.CommitBranch))
+	s.logOperation(repository, "push", remote, refSpec)
+	err = r.Push(&git.PushOptions{RemoteName: remote, RefSpecs: []config.RefSpec{config.RefSpec(refSpec)}})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return newGitError(repository, "push", err)
+	}
+	return newGitError(repository, "push", r.Storer.RemoveReference(trackingRef))
+}
+
+// SparseCheckout returns ErrNotSupported.
+func (s *GoGitRepositoryStore) SparseCheckout(repository *domain.GitRepository, _ []domain.Path) error {
+	return newGitError(repository, "sparse-checkout", ErrNotSupported)
+}
+
+// pushRemote returns the name of the Git remote that the commit branch is pushed to.
+// If the repository is forked, the remote ForkRemoteName is added or updated to point to the fork.
+func (s *GoGitRepositoryStore) pushRemote(repository *domain.GitRepository, r *git.Repository) (string, error) {
+	if !repository.IsForked() {
+		return "origin", nil
+	}
+	forkURL := repository.ForkURL.String()
+	existing, err := r.Remote(ForkRemoteName)
+	if err == nil {
+		if urls := existing.Config().URLs; len(urls) == 1 && urls[0] == forkURL {
+			return ForkRemoteName, nil
+		}
+		if err := r.DeleteRemote(ForkRemoteName); err != nil {
+			return "", newGitError(repository, "remote", err)
+		}
+	} else if !errors.Is(err, git.ErrRemoteNotFound) {
+		return "", newGitError(repository, "remote", err)
+	}
+	// The URL may contain credentials, so only the redacted URL is logged.
+	s.logOperation(repository, "remote", "add", ForkRemoteName, repository.ForkURL.Redacted())
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: ForkRemoteName, URLs: []string{forkURL}})
+	if err != nil {
+		return "", newGitError(repository, "remote", err)
+	}
+	return ForkRemoteName, nil
+}
+
+func (s *GoGitRepositoryStore) logOperation(repository *domain.GitRepository, args ...string) {
+	s.instrumentation.logGoGitOperation(repository, args)
+}
+
+func openRepository(repository *domain.GitRepository) (*git.Repository, error) {
+	r, err := git.PlainOpen(repository.RootDir.String())
+	return r, newGitError(repository, "open", err)
+}
+
+func openWorktree(repository *domain.GitRepository) (*git.Repository, *git.Worktree, error) {
+	r, err := openRepository(repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	w, err := r.Worktree()
+	return r, w, newGitError(repository, "open", err)
+}
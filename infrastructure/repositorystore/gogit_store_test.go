@@ -0,0 +1,333 @@
+package repositorystore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoGitRepositoryStore_Update(t *testing.T) {
+	dir := t.TempDir()
+	origin := newBareOrigin(t, dir)
+	repo := &domain.GitRepository{
+		RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+		URL:          newFileURL(origin),
+		CommitBranch: "greposync-update",
+	}
+	s := newGoGitStore()
+
+	require.NoError(t, s.Clone(repo, domain.CloneOptions{}))
+	assert.Equal(t, "main", repo.DefaultBranch)
+	require.NoError(t, s.Fetch(repo))
+	require.NoError(t, s.Reset(repo))
+	require.NoError(t, s.Checkout(repo))
+	require.NoError(t, s.Pull(repo))
+	assert.False(t, s.IsDirty(repo))
+
+	writeFile(t, repo, "modified.txt", "line1\nchanged\n")
+	writeFile(t, repo, "added.txt", "new\n")
+	require.NoError(t, os.Remove(filepath.Join(repo.RootDir.String(), "deleted.txt")))
+	assert.True(t, s.IsDirty(repo))
+	diff, err := s.Diff(repo, domain.DiffOptions{WorkDirToHEAD: true})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "diff --git a/modified.txt b/modified.txt")
+	assert.Contains(t, diff, "-line2\n+changed\n")
+	assert.Contains(t, diff, "deleted file mode 100644")
+	assert.NotContains(t, diff, "added.txt", "untracked files")
+
+	require.NoError(t, s.Add(repo))
+	require.NoError(t, s.Commit(repo, domain.CommitOptions{
+		Message:   "update",
+		Author:    domain.Identity{Name: "Author", Email: "author@example.com"},
+		Committer: domain.Identity{Name: "Bot", Email: "bot@example.com"},
+		Trailers:  []string{"Refs: TICKET-123"},
+		Signoff:   true,
+	}))
+	assert.False(t, s.IsDirty(repo))
+	commit, _, err := execGitCommand(repo.RootDir, []string{"cat-file", "commit", "HEAD"})
+	require.NoError(t, err)
+	assert.Contains(t, commit, "author Author <author@example.com>")
+	assert.Contains(t, commit, "committer Bot <bot@example.com>")
+	assert.Contains(t, commit, "update\n\nRefs: TICKET-123\nSigned-off-by: Bot <bot@example.com>\n")
+
+	hasCommits, err := s.HasCommitsBetween(repo, "main", repo.CommitBranch)
+	require.NoError(t, err)
+	assert.True(t, hasCommits)
+	changes, err := s.DiffStat(repo, "main", repo.CommitBranch)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []domain.FileChange{
+		{Path: "added.txt", Type: domain.FileAdded, Additions: 1},
+		{Path: "deleted.txt", Type: domain.FileDeleted, Deletions: 1},
+		{Path: "modified.txt", Type: domain.FileModified, Additions: 1, Deletions: 1},
+	}, changes)
+	diff, err = s.Diff(repo, domain.DiffOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+new\n")
+
+	require.NoError(t, s.Push(repo, domain.PushOptions{}))
+	assert.True(t, hasBranch(t, origin, repo.CommitBranch), "branch pushed")
+	require.NoError(t, s.Fetch(repo))
+	require.NoError(t, s.DeleteRemoteBranch(repo))
+	assert.False(t, hasBranch(t, origin, repo.CommitBranch), "branch deleted")
+}
+
+func TestGoGitRepositoryStore_Clone(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions  domain.CloneOptions
+		expectedError error
+	}{
+		"GivenShallowClone_ThenExpectTruncatedHistory": {
+			givenOptions: domain.CloneOptions{Depth: 1},
+		},
+		"GivenPartialClone_ThenExpectNotSupported": {
+			givenOptions:  domain.CloneOptions{Filter: domain.CloneFilterBlobless},
+			expectedError: ErrNotSupported,
+		},
+		"GivenSparseClone_ThenExpectNotSupported": {
+			givenOptions:  domain.CloneOptions{Depth: 1, Sparse: true},
+			expectedError: ErrNotSupported,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			origin := newBareOrigin(t, dir)
+			repo := &domain.GitRepository{
+				RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+				URL:          newFileURL(origin),
+				CommitBranch: "greposync-update",
+			}
+			s := newGoGitStore()
+
+			err := s.Clone(repo, tt.givenOptions)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "main", repo.DefaultBranch)
+			count, _, err := execGitCommand(repo.RootDir, []string{"rev-list", "--count", "HEAD"})
+			require.NoError(t, err)
+			assert.Equal(t, "1", strings.TrimSpace(count))
+
+			require.NoError(t, s.Fetch(repo))
+			require.NoError(t, s.Checkout(repo))
+			commitFile(t, repo, "added.txt", "new\n")
+			hasCommits, err := s.HasCommitsBetween(repo, "main", repo.CommitBranch)
+			require.NoError(t, err)
+			assert.True(t, hasCommits)
+			require.NoError(t, s.Push(repo, domain.PushOptions{}))
+			assert.True(t, hasBranch(t, origin, repo.CommitBranch), "branch pushed")
+		})
+	}
+}
+
+func TestGoGitRepositoryStore_ShallowCloneWithoutMergeBase(t *testing.T) {
+	dir := t.TempDir()
+	origin := newBareOrigin(t, dir)
+	other := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "other"))}
+	runGit(t, &domain.GitRepository{RootDir: domain.NewFilePath(dir)}, "clone", origin.RootDir.String(), other.RootDir.String())
+	runGit(t, other, "checkout", "-b", "greposync-update")
+	commitFile(t, other, "added.txt", "new\n")
+	runGit(t, other, "push", "origin", "greposync-update")
+	runGit(t, other, "checkout", "main")
+	commitFile(t, other, "main.txt", "main\n")
+	commitFile(t, other, "main.txt", "changed\n")
+	runGit(t, other, "push", "origin", "main")
+
+	repo := &domain.GitRepository{
+		RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+		URL:          newFileURL(origin),
+		CommitBranch: "greposync-update",
+	}
+	s := newGoGitStore()
+	require.NoError(t, s.Clone(repo, domain.CloneOptions{Depth: 1}))
+	runGit(t, repo, "branch", "greposync-update", "origin/greposync-update")
+
+	hasCommits, err := s.HasCommitsBetween(repo, "main", repo.CommitBranch)
+	require.NoError(t, err)
+	assert.True(t, hasCommits)
+	changes, err := s.DiffStat(repo, "main", repo.CommitBranch)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.FileChange{{Path: "added.txt", Type: domain.FileAdded, Additions: 1}}, changes)
+}
+
+func TestGoGitRepositoryStore_HasCommitsBetween(t *testing.T) {
+	tests := map[string]struct {
+		givenChange    func(t *testing.T, repo *domain.GitRepository)
+		expectedResult bool
+	}{
+		"GivenNoCommits_ThenExpectFalse": {
+			givenChange:    func(t *testing.T, repo *domain.GitRepository) {},
+			expectedResult: false,
+		},
+		"GivenCommitWithChanges_ThenExpectTrue": {
+			givenChange: func(t *testing.T, repo *domain.GitRepository) {
+				commitFile(t, repo, "modified.txt", "changed\n")
+			},
+			expectedResult: true,
+		},
+		"GivenRevertedCommit_ThenExpectFalse": {
+			givenChange: func(t *testing.T, repo *domain.GitRepository) {
+				commitFile(t, repo, "modified.txt", "changed\n")
+				commitFile(t, repo, "modified.txt", "line1\nline2\n")
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := &domain.GitRepository{RootDir: domain.NewFilePath(t.TempDir())}
+			repo.URL = newFileURL(repo)
+			runGit(t, repo, "init")
+			runGit(t, repo, "checkout", "-b", "main")
+			commitFile(t, repo, "modified.txt", "line1\nline2\n")
+			runGit(t, repo, "checkout", "-b", "greposync-update")
+			tt.givenChange(t, repo)
+
+			result, err := newGoGitStore().HasCommitsBetween(repo, "main", "greposync-update")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestGoGitRepositoryStore_Pull(t *testing.T) {
+	tests := map[string]struct {
+		givenLocalCommit bool
+		expectedError    error
+	}{
+		"GivenRemoteAhead_ThenExpectFastForward": {
+			givenLocalCommit: false,
+		},
+		"GivenDivergedBranches_ThenExpectNonFastForwardError": {
+			givenLocalCommit: true,
+			expectedError:    git.ErrNonFastForwardUpdate,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			origin := newBareOrigin(t, dir)
+			other := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "other"))}
+			runGit(t, &domain.GitRepository{RootDir: domain.NewFilePath(dir)}, "clone", origin.RootDir.String(), other.RootDir.String())
+			runGit(t, other, "checkout", "-b", "greposync-update")
+			runGit(t, other, "push", "origin", "greposync-update")
+
+			repo := &domain.GitRepository{
+				RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+				URL:          newFileURL(origin),
+				CommitBranch: "greposync-update",
+			}
+			s := newGoGitStore()
+			require.NoError(t, s.Clone(repo, domain.CloneOptions{}))
+			require.NoError(t, s.Fetch(repo))
+			require.NoError(t, s.Checkout(repo))
+
+			commitFile(t, other, "remote.txt", "remote\n")
+			runGit(t, other, "push", "origin", "greposync-update")
+			if tt.givenLocalCommit {
+				commitFile(t, repo, "local.txt", "local\n")
+			}
+
+			err := s.Pull(repo)
+			if tt.expectedError != nil {
+				var gitErr *GitError
+				require.True(t, errors.As(err, &gitErr), "structured error")
+				assert.Equal(t, "pull", gitErr.Operation)
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(repo.RootDir.String(), "remote.txt"))
+		})
+	}
+}
+
+func TestGoGitRepositoryStore_Add(t *testing.T) {
+	tests := map[string]struct {
+		givenGitignore map[string]string
+		expectedStaged []string
+	}{
+		"GivenNoGitignore_ThenExpectAllFiles": {
+			expectedStaged: []string{"added.txt", "build/output.bin", "deleted.txt", "modified.txt", "sub/debug.log"},
+		},
+		"GivenGitignore_ThenExpectIgnoredFilesNotStaged": {
+			givenGitignore: map[string]string{
+				".gitignore":     "build/\n",
+				"sub/.gitignore": "*.log\n",
+			},
+			expectedStaged: []string{".gitignore", "added.txt", "deleted.txt", "modified.txt", "sub/.gitignore"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			origin := newBareOrigin(t, dir)
+			repo := &domain.GitRepository{
+				RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+				URL:          newFileURL(origin),
+				CommitBranch: "greposync-update",
+			}
+			s := newGoGitStore()
+			require.NoError(t, s.Clone(repo, domain.CloneOptions{}))
+			for _, subDir := range []string{"build", "sub"} {
+				require.NoError(t, os.Mkdir(filepath.Join(repo.RootDir.String(), subDir), 0755))
+			}
+			for file, content := range tt.givenGitignore {
+				writeFile(t, repo, file, content)
+			}
+			writeFile(t, repo, "modified.txt", "changed\n")
+			writeFile(t, repo, "added.txt", "new\n")
+			writeFile(t, repo, "build/output.bin", "binary\n")
+			writeFile(t, repo, "sub/debug.log", "log\n")
+			require.NoError(t, os.Remove(filepath.Join(repo.RootDir.String(), "deleted.txt")))
+
+			require.NoError(t, s.Add(repo))
+			staged, _, err := execGitCommand(repo.RootDir, []string{"diff", "--cached", "--name-only"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStaged, strings.Fields(staged))
+		})
+	}
+}
+
+func TestGoGitRepositoryStore_Checkout(t *testing.T) {
+	testCheckoutWithTargetBranch(t, newGoGitStore())
+}
+
+func TestGoGitRepositoryStore_CheckSigningKey(t *testing.T) {
+	s := newGoGitStore()
+	assert.NoError(t, s.CheckSigningKey(domain.SigningKey{}))
+	assert.ErrorIs(t, s.CheckSigningKey(domain.SigningKey{Format: domain.SigningFormatGPG}), ErrNotSupported)
+}
+
+func newGoGitStore() *GoGitRepositoryStore {
+	return NewGoGitRepositoryStore(NewRepositoryStore(NewRepositoryStoreInstrumentation(loggingtest.NewDiscardLoggerFactory()), nil))
+}
+
+// newBareOrigin creates a bare repository with an initial commit in the `main` branch.
+func newBareOrigin(t *testing.T, dir string) *domain.GitRepository {
+	origin := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "origin"))}
+	seed := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "seed"))}
+	for _, r := range []*domain.GitRepository{origin, seed} {
+		require.NoError(t, os.Mkdir(r.RootDir.String(), 0755))
+	}
+	runGit(t, origin, "init", "--bare", "--initial-branch", "main")
+	runGit(t, seed, "init", "--initial-branch", "main")
+	commitFile(t, seed, "modified.txt", "line1\nline2\n")
+	commitFile(t, seed, "deleted.txt", "line1\n")
+	runGit(t, seed, "push", origin.RootDir.String(), "main")
+	return origin
+}
+
+func writeFile(t *testing.T, repo *domain.GitRepository, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(repo.RootDir.String(), name), []byte(content), 0644))
+}
@@ -35,6 +35,10 @@ func (i *RepositoryStoreInstrumentation) logGitArguments(repository *domain.GitR
 	return args
 }
 
+func (i *RepositoryStoreInstrumentation) logGoGitOperation(repository *domain.GitRepository, args []string) {
+	i.log.WithName(repository.URL.GetFullName()).Info(fmt.Sprintf("go-git %s", strings.Join(args, " ")))
+}
+
 func (i *RepositoryStoreInstrumentation) logDebugInfo(repository *domain.GitRepository, line string) {
 	if line != "" {
 		i.log.WithName(repository.URL.GetFullName()).V(1).Info(line)
@@ -45,6 +49,10 @@ func (i *RepositoryStoreInstrumentation) skipRepository(url *domain.GitURL) {
 	i.log.Info("Skipping repository due to filters", "url", url.GetFullName())
 }
 
+func (i *RepositoryStoreInstrumentation) resolvedQuery(query domain.RepositoryQuery, names []string) {
+	i.log.Info("Resolved repository query", "query", query.String(), "count", len(names))
+}
+
 func (i *RepositoryStoreInstrumentation) loadRepositoryConfigFile(name string) {
 	i.log.V(1).Info("Loading config file", "name", name)
 }
@@ -21,11 +21,23 @@ type RepositoryStore struct {
 	StoreConfig
 	k               *koanf.Koanf
 	instrumentation *RepositoryStoreInstrumentation
+	finder          domain.GitRepositoryFinder
 }
 
 // ManagedGitRepo is the representation of the managed git repos in the config file.
+// An entry either contains the Name of a single repository, or a query that is resolved through the Git hosting service.
 type ManagedGitRepo struct {
-	Name string
+	Name string `koanf:"name"`
+	// Org selects all repositories of the given organization.
+	Org string `koanf:"org"`
+	// Topic selects all repositories with the given topic.
+	Topic string `koanf:"topic"`
+	// Search selects all repositories matching the given search query.
+	Search string `koanf:"search"`
+	// IncludeArchived includes archived repositories in query results.
+	IncludeArchived bool `koanf:"includeArchived"`
+	// IncludeForks includes forked repositories in query results.
+	IncludeForks bool `koanf:"includeForks"`
 }
 
 type StoreConfig struct {
@@ -40,10 +52,11 @@ type StoreConfig struct {
 	ManagedReposFileName string
 }
 
-func NewRepositoryStore(instrumentation *RepositoryStoreInstrumentation) *RepositoryStore {
+func NewRepositoryStore(instrumentation *RepositoryStoreInstrumentation, finder domain.GitRepositoryFinder) *RepositoryStore {
 	return &RepositoryStore{
 		k:               koanf.New("."),
 		instrumentation: instrumentation,
+		finder:          finder,
 		StoreConfig: StoreConfig{
 			ManagedReposFileName: "managed_repos.yml",
 		},
@@ -71,6 +84,12 @@ func (s *RepositoryStore) FetchGitRepositories() ([]*domain.GitRepository, error
 		return nil, err
 	}
 
+	m, err = s.resolveQueries(m)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(m))
 	for _, repo := range m {
 		u, err := parseUrl(repo, s.BaseURL, s.DefaultNamespace)
 		if err != nil {
@@ -78,20 +97,19 @@ func (s *RepositoryStore) FetchGitRepositories() ([]*domain.GitRepository, error
 		}
 
 		gitUrl := domain.FromURL(u)
+		if seen[gitUrl.String()] {
+			// Repositories may be listed explicitly and be part of a query result at the same time.
+			continue
+		}
+		seen[gitUrl.String()] = true
 		if skipRepository(gitUrl.String(), includeRegex, excludeRegex) {
 			s.instrumentation.skipRepository(gitUrl)
 			continue
 		}
 
-		root := domain.NewFilePath(s.toLocalFilePath(gitUrl.AsURL()))
-		gitRepository := domain.NewGitRepository(gitUrl, root)
-		gitRepository.CommitBranch = s.CommitBranch
-		if root.DirExists() {
-			defaultBranch, err := GetDefaultBranch(gitRepository)
-			if err != nil && !strings.Contains(err.Error(), "no default branch determined") {
-				return list, err
-			}
-			gitRepository.DefaultBranch = defaultBranch
+		gitRepository, err := s.newGitRepository(gitUrl)
+		if err != nil {
+			return list, err
 		}
 		list = append(list, gitRepository)
 	}
@@ -103,11 +121,92 @@ func (s *RepositoryStore) FetchGitRepositories() ([]*domain.GitRepository, error
 	return list, nil
 }
 
+// resolveQueries replaces the entries that contain a query with the repositories found by the Git hosting service.
+// Entries with a name are returned as-is.
+func (s *RepositoryStore) resolveQueries(repos []ManagedGitRepo) ([]ManagedGitRepo, error) {
+	resolved := make([]ManagedGitRepo, 0, len(repos))
+	for _, repo := range repos {
+		if !repo.isQuery() {
+			resolved = append(resolved, repo)
+			continue
+		}
+		if repo.Name != "" {
+			return nil, fmt.Errorf("%w: repository '%s' cannot be combined with a query", domain.ErrInvalidArgument, repo.Name)
+		}
+		if s.finder == nil {
+			return nil, fmt.Errorf("%w: repository queries are not supported", domain.ErrInvalidArgument)
+		}
+		namespace := repo.Org
+		if namespace == "" {
+			namespace = s.DefaultNamespace
+		}
+		if namespace == "" {
+			// Without owner, the query would search all repositories of the Git hosting service.
+			return nil, fmt.Errorf("%w: query requires 'org' or a default namespace", domain.ErrInvalidArgument)
+		}
+		// The namespace URL determines the Git hosting service that resolves the query.
+		u, err := parseUrl(ManagedGitRepo{Name: namespace + "/"}, s.BaseURL, s.DefaultNamespace)
+		if err != nil {
+			return nil, err
+		}
+		query := repo.toQuery(namespace)
+		names, err := s.finder.FindRepositories(domain.FromURL(u), query)
+		if err != nil {
+			return nil, err
+		}
+		s.instrumentation.resolvedQuery(query, names)
+		for _, name := range names {
+			resolved = append(resolved, ManagedGitRepo{Name: name})
+		}
+	}
+	return resolved, nil
+}
+
+// FetchGitRepository returns a single repository by the given name.
+// The name has the same format as the repositories in the managed repositories config file, e.g. `repository` or `namespace/repository`.
+// Include and exclude filters are not applied.
+func (s *RepositoryStore) FetchGitRepository(name string) (*domain.GitRepository, error) {
+	u, err := parseUrl(ManagedGitRepo{Name: name}, s.BaseURL, s.DefaultNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return s.newGitRepository(domain.FromURL(u))
+}
+
+func (s *RepositoryStore) newGitRepository(gitUrl *domain.GitURL) (*domain.GitRepository, error) {
+	root := domain.NewFilePath(s.toLocalFilePath(gitUrl.AsURL()))
+	gitRepository := domain.NewGitRepository(gitUrl, root)
+	gitRepository.CommitBranch = s.CommitBranch
+	if root.DirExists() {
+		defaultBranch, err := GetDefaultBranch(gitRepository)
+		if err != nil && !strings.Contains(err.Error(), "no default branch determined") {
+			return gitRepository, err
+		}
+		gitRepository.DefaultBranch = defaultBranch
+	}
+	return gitRepository, nil
+}
+
 func (s *RepositoryStore) toLocalFilePath(u *url.URL) string {
 	p := strings.ReplaceAll(u.Path, "/", string(filepath.Separator))
 	return filepath.Clean(filepath.Join(s.ParentDir, strings.ReplaceAll(u.Hostname(), ":", "-"), p))
 }
 
+func (m ManagedGitRepo) isQuery() bool {
+	return m.Org != "" || m.Topic != "" || m.Search != ""
+}
+
+// toQuery returns the query limited to the repositories of the given owner.
+func (m ManagedGitRepo) toQuery(owner string) domain.RepositoryQuery {
+	return domain.RepositoryQuery{
+		Owner:           owner,
+		Topic:           m.Topic,
+		Search:          m.Search,
+		IncludeArchived: m.IncludeArchived,
+		IncludeForks:    m.IncludeForks,
+	}
+}
+
 func parseUrl(m ManagedGitRepo, gitBase, defaultNs string) (*url.URL, error) {
 	if strings.Contains(m.Name, "/") {
 		u, err := giturls.Parse(fmt.Sprintf("%s/%s", gitBase, m.Name))
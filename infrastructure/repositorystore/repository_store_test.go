@@ -1,8 +1,12 @@
 package repositorystore
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,3 +53,121 @@ func TestRepositoryStore_skipRepositories(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryStore_FetchGitRepository(t *testing.T) {
+	tests := map[string]struct {
+		givenName       string
+		expectedURL     string
+		expectedRootDir string
+	}{
+		"GivenNameOnly_ThenExpectDefaultNamespace": {
+			givenName:       "greposync",
+			expectedURL:     "ssh://git@github.com/ccremer/greposync",
+			expectedRootDir: "repos/github.com/ccremer/greposync",
+		},
+		"GivenNamespace_ThenExpectGivenNamespace": {
+			givenName:       "other/repository",
+			expectedURL:     "ssh://git@github.com/other/repository",
+			expectedRootDir: "repos/github.com/other/repository",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewRepositoryStore(nil, nil)
+			s.BaseURL = "git@github.com:"
+			s.DefaultNamespace = "ccremer"
+			s.ParentDir = "repos"
+			result, err := s.FetchGitRepository(tt.givenName)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedURL, result.URL.String())
+			assert.Equal(t, tt.expectedRootDir, result.RootDir.String())
+		})
+	}
+}
+
+type fakeRepositoryFinder struct {
+	givenURL   *domain.GitURL
+	givenQuery domain.RepositoryQuery
+	names      []string
+}
+
+func (f *fakeRepositoryFinder) FindRepositories(url *domain.GitURL, query domain.RepositoryQuery) ([]string, error) {
+	f.givenURL = url
+	f.givenQuery = query
+	return f.names, nil
+}
+
+func TestRepositoryStore_FetchGitRepositories(t *testing.T) {
+	tests := map[string]struct {
+		givenConfig             string
+		givenNoDefaultNamespace bool
+		expectedURLs            []string
+		expectedQuery           domain.RepositoryQuery
+		expectedHost            string
+		expectedError           string
+	}{
+		"GivenStaticEntries_ThenExpectRepositories": {
+			givenConfig:  "repositories:\n  - name: greposync\n",
+			expectedURLs: []string{"ssh://git@github.com/ccremer/greposync"},
+		},
+		"GivenQuery_ThenExpectMergedRepositoriesWithoutDuplicates": {
+			givenConfig:   "repositories:\n  - name: greposync\n  - org: other\n    topic: go\n    includeForks: true\n",
+			expectedURLs:  []string{"ssh://git@github.com/ccremer/greposync", "ssh://git@github.com/other/repository"},
+			expectedQuery: domain.RepositoryQuery{Owner: "other", Topic: "go", IncludeForks: true},
+			expectedHost:  "github.com",
+		},
+		"GivenTopicOnly_ThenExpectDefaultNamespaceAsOwner": {
+			givenConfig:   "repositories:\n  - topic: greposync\n",
+			expectedURLs:  []string{"ssh://git@github.com/ccremer/greposync", "ssh://git@github.com/other/repository"},
+			expectedQuery: domain.RepositoryQuery{Owner: "ccremer", Topic: "greposync"},
+			expectedHost:  "github.com",
+		},
+		"GivenSearchOnly_ThenExpectDefaultNamespaceAsOwner": {
+			givenConfig:   "repositories:\n  - search: language:go\n",
+			expectedURLs:  []string{"ssh://git@github.com/ccremer/greposync", "ssh://git@github.com/other/repository"},
+			expectedQuery: domain.RepositoryQuery{Owner: "ccremer", Search: "language:go"},
+			expectedHost:  "github.com",
+		},
+		"GivenQueryWithoutOwner_WhenNoDefaultNamespace_ThenExpectError": {
+			givenConfig:             "repositories:\n  - search: language:go\n",
+			givenNoDefaultNamespace: true,
+			expectedError:           "invalid argument: query requires 'org' or a default namespace",
+		},
+		"GivenQueryWithName_ThenExpectError": {
+			givenConfig:   "repositories:\n  - name: greposync\n    search: language:go\n",
+			expectedError: "invalid argument: repository 'greposync' cannot be combined with a query",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			configFile := filepath.Join(dir, "managed_repos.yml")
+			require.NoError(t, os.WriteFile(configFile, []byte(tt.givenConfig), 0644))
+			finder := &fakeRepositoryFinder{names: []string{"other/repository", "ccremer/greposync"}}
+			s := NewRepositoryStore(NewRepositoryStoreInstrumentation(loggingtest.NewDiscardLoggerFactory()), finder)
+			s.ManagedReposFileName = configFile
+			s.BaseURL = "git@github.com:"
+			s.DefaultNamespace = "ccremer"
+			if tt.givenNoDefaultNamespace {
+				s.DefaultNamespace = ""
+			}
+			s.ParentDir = dir
+
+			result, err := s.FetchGitRepositories()
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			urls := make([]string, len(result))
+			for i, repo := range result {
+				urls[i] = repo.URL.String()
+			}
+			assert.ElementsMatch(t, tt.expectedURLs, urls)
+			assert.Equal(t, tt.expectedQuery, finder.givenQuery)
+			if tt.expectedHost != "" {
+				assert.Equal(t, tt.expectedHost, finder.givenURL.Host)
+			}
+		})
+	}
+}
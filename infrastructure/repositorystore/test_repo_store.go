@@ -131,7 +131,22 @@ func (s *TestRepositoryStore) Commit(_ *domain.GitRepository, _ domain.CommitOpt
 	return ErrNotSupported
 }
 
+// DiffStat returns ErrNotSupported.
+func (s *TestRepositoryStore) DiffStat(_ *domain.GitRepository, _, _ string) ([]domain.FileChange, error) {
+	return nil, ErrNotSupported
+}
+
 // Push returns ErrNotSupported.
 func (s *TestRepositoryStore) Push(_ *domain.GitRepository, _ domain.PushOptions) error {
 	return ErrNotSupported
 }
+
+// DeleteRemoteBranch returns ErrNotSupported.
+func (s *TestRepositoryStore) DeleteRemoteBranch(_ *domain.GitRepository) error {
+	return ErrNotSupported
+}
+
+// HasCommitsBetween returns ErrNotSupported.
+func (s *TestRepositoryStore) HasCommitsBetween(_ *domain.GitRepository, _, _ string) (bool, error) {
+	return false, ErrNotSupported
+}
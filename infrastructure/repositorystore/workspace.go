@@ -2,12 +2,30 @@ package repositorystore
 
 import (
 	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ccremer/greposync/domain"
 )
 
-func (s *RepositoryStore) Clone(repository *domain.GitRepository) error {
+// ForkRemoteName is the name of the Git remote that points to domain.GitRepository.ForkURL.
+const ForkRemoteName = "fork"
+
+const (
+	// initialDeepenCount is the number of commits by which the history of a shallow clone is deepened at first if it's too short.
+	// The number doubles with each attempt.
+	initialDeepenCount = 50
+	// maxDeepenAttempts is the number of attempts to deepen the history of a shallow clone, before the complete history is fetched.
+	maxDeepenAttempts = 4
+)
+
+// Clone implements domain.GitRepositoryStore.
+// Shallow clones contain all branches of origin, so that the commit branch can be checked out.
+func (s *RepositoryStore) Clone(repository *domain.GitRepository, options domain.CloneOptions) error {
 	if repository.RootDir.DirExists() {
 		return errors.New("clone exists already")
 	}
@@ -16,7 +34,17 @@ func (s *RepositoryStore) Clone(repository *domain.GitRepository) error {
 
 	s.instrumentation.attemptCloning(repository)
 
-	out, stderr, err := execGitCommand(repository.RootDir, []string{"clone", gitURL.String(), dir})
+	args := []string{"clone"}
+	if options.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(options.Depth), "--no-single-branch")
+	}
+	if options.Filter != domain.CloneFilterNone {
+		args = append(args, "--filter="+string(options.Filter))
+	}
+	if options.Sparse {
+		args = append(args, "--sparse")
+	}
+	out, stderr, err := execGitCommand(repository.RootDir, append(args, gitURL.String(), dir))
 	if err != nil {
 		return mergeWithStdErr(err, stderr)
 	}
@@ -31,15 +59,27 @@ func (s *RepositoryStore) Clone(repository *domain.GitRepository) error {
 	return nil
 }
 
+// Checkout implements domain.GitRepositoryStore.
 func (s *RepositoryStore) Checkout(repository *domain.GitRepository) error {
 	args := []string{"checkout"}
-	if localExists, err := hasLocalBranch(repository, repository.CommitBranch); err != nil {
-		return err
-	} else if !localExists {
-		// Checkout to new branch
-		args = append(args, "-b")
+	if repository.GetTargetBranch() != repository.DefaultBranch {
+		startPoint, err := s.commitBranchStartPoint(repository)
+		if err != nil {
+			return err
+		}
+		// The clone's HEAD is the default branch, so the commit branch is (re)created explicitly.
+		args = append(args, "-B", repository.CommitBranch, startPoint)
+	} else {
+		localExists, err := hasLocalBranch(repository, repository.CommitBranch)
+		if err != nil {
+			return err
+		}
+		if !localExists {
+			// Checkout to new branch
+			args = append(args, "-b")
+		}
+		args = append(args, repository.CommitBranch)
 	}
-	args = append(args, repository.CommitBranch)
 
 	out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, args))
 	if err != nil {
@@ -50,7 +90,20 @@ func (s *RepositoryStore) Checkout(repository *domain.GitRepository) error {
 }
 
 func (s *RepositoryStore) Fetch(repository *domain.GitRepository) error {
-	out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, []string{"fetch"}))
+	return s.fetch(repository)
+}
+
+// fetch fetches origin and the fork, if the repository is forked, with the given additional arguments.
+func (s *RepositoryStore) fetch(repository *domain.GitRepository, extraArgs ...string) error {
+	args := append([]string{"fetch"}, extraArgs...)
+	if repository.IsForked() {
+		remote, err := s.pushRemote(repository)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--multiple", "origin", remote)
+	}
+	out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, args))
 	if err != nil {
 		return mergeWithStdErr(err, stderr)
 	}
@@ -69,13 +122,22 @@ func (s *RepositoryStore) Reset(repository *domain.GitRepository) error {
 	return nil
 }
 
+// Pull implements domain.GitRepositoryStore.
+// In a shallow clone, the history is deepened until the commit branch and its remote branch have a common ancestor.
 func (s *RepositoryStore) Pull(repository *domain.GitRepository) error {
-	exists, err := hasRemoteBranch(repository, repository.CommitBranch)
+	remote, err := s.pushRemote(repository)
+	if err != nil {
+		return err
+	}
+	exists, err := hasRemoteBranch(repository, remote+"/"+repository.CommitBranch)
 	if err != nil {
 		return err
 	}
 	if exists {
-		out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, []string{"pull", "origin", repository.CommitBranch}))
+		if err := s.ensureMergeBase(repository, "HEAD", remote+"/"+repository.CommitBranch); err != nil {
+			return err
+		}
+		out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, []string{"pull", remote, repository.CommitBranch}))
 		if err != nil {
 			return mergeWithStdErr(err, stderr)
 		}
@@ -84,8 +146,14 @@ func (s *RepositoryStore) Pull(repository *domain.GitRepository) error {
 	return nil
 }
 
+// Push implements domain.GitRepositoryStore.
+// If the repository is forked, the commit branch is pushed to the fork instead of origin.
 func (s *RepositoryStore) Push(repository *domain.GitRepository, options domain.PushOptions) error {
-	args := []string{"push", "origin", repository.CommitBranch}
+	remote, err := s.pushRemote(repository)
+	if err != nil {
+		return err
+	}
+	args := []string{"push", remote, repository.CommitBranch}
 	if options.Force {
 		args = append(args, "--force")
 	}
@@ -96,3 +164,150 @@ func (s *RepositoryStore) Push(repository *domain.GitRepository, options domain.
 	s.instrumentation.logDebugInfo(repository, out)
 	return nil
 }
+
+// DeleteRemoteBranch implements domain.GitRepositoryStore.
+// If the repository is forked, the commit branch is deleted in the fork.
+func (s *RepositoryStore) DeleteRemoteBranch(repository *domain.GitRepository) error {
+	remote, err := s.pushRemote(repository)
+	if err != nil {
+		return err
+	}
+	exists, err := hasRemoteBranch(repository, remote+"/"+repository.CommitBranch)
+	if err != nil || !exists {
+		return err
+	}
+	out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, []string{"push", remote, "--delete", repository.CommitBranch}))
+	if err != nil {
+		return mergeWithStdErr(err, stderr)
+	}
+	s.instrumentation.logDebugInfo(repository, out)
+	return nil
+}
+
+// pushRemote returns the name of the Git remote that the commit branch is pushed to.
+// If the repository is forked, the remote ForkRemoteName is added or updated to point to the fork.
+func (s *RepositoryStore) pushRemote(repository *domain.GitRepository) (string, error) {
+	if !repository.IsForked() {
+		return "origin", nil
+	}
+	forkURL := repository.ForkURL.String()
+	out, _, err := execGitCommand(repository.RootDir, []string{"remote", "get-url", ForkRemoteName})
+	if err == nil && strings.TrimSpace(out) == forkURL {
+		return ForkRemoteName, nil
+	}
+	args := []string{"remote", "add", ForkRemoteName, forkURL}
+	if err == nil {
+		args = []string{"remote", "set-url", ForkRemoteName, forkURL}
+	}
+	// The URL may contain credentials, so only the redacted URL is logged.
+	s.instrumentation.logGitArguments(repository, 1, []string{args[0], args[1], args[2], repository.ForkURL.Redacted()})
+	out, stderr, err := execGitCommand(repository.RootDir, args)
+	if err != nil {
+		return "", mergeWithStdErr(err, stderr)
+	}
+	s.instrumentation.logDebugInfo(repository, out)
+	return ForkRemoteName, nil
+}
+
+// HasCommitsBetween implements domain.GitRepositoryStore.
+// The remote-tracking branch of baseBranch is preferred over the local branch, as the local branch may be outdated.
+// In a shallow clone, the history is deepened until the branches have a common ancestor.
+func (s *RepositoryStore) HasCommitsBetween(repository *domain.GitRepository, baseBranch, headBranch string) (bool, error) {
+	base, err := preferRemoteBranch(repository, baseBranch)
+	if err != nil {
+		return false, err
+	}
+	if err := s.ensureMergeBase(repository, base, headBranch); err != nil {
+		return false, err
+	}
+	if hasCommits, err := HasCommitsBetween(repository, base, headBranch); err != nil || !hasCommits {
+		return false, err
+	}
+	return hasChangesBetween(repository, base, headBranch)
+}
+
+// commitBranchStartPoint returns the remote commit branch if it exists, otherwise the target branch.
+func (s *RepositoryStore) commitBranchStartPoint(repository *domain.GitRepository) (string, error) {
+	remote, err := s.pushRemote(repository)
+	if err != nil {
+		return "", err
+	}
+	if exists, err := hasRemoteBranch(repository, remote+"/"+repository.CommitBranch); err != nil || exists {
+		return remote + "/" + repository.CommitBranch, err
+	}
+	return preferRemoteBranch(repository, repository.GetTargetBranch())
+}
+
+// preferRemoteBranch returns the remote-tracking branch of the given branch in origin if it exists, otherwise the given branch.
+func preferRemoteBranch(repository *domain.GitRepository, branch string) (string, error) {
+	if exists, err := hasRemoteBranch(repository, "origin/"+branch); err != nil {
+		return "", err
+	} else if exists {
+		return "origin/" + branch, nil
+	}
+	return branch, nil
+}
+
+// SparseCheckout limits the working tree to the directories that contain the given files.
+// The files in the root directory are always checked out.
+// Sparse checkout is enabled if it's not enabled in the repository yet.
+func (s *RepositoryStore) SparseCheckout(repository *domain.GitRepository, files []domain.Path) error {
+	args := append([]string{"sparse-checkout", "set", "--cone"}, sparseDirectories(files)...)
+	out, stderr, err := execGitCommand(repository.RootDir, s.instrumentation.logGitArguments(repository, 0, args))
+	if err != nil {
+		return mergeWithStdErr(err, stderr)
+	}
+	s.instrumentation.logDebugInfo(repository, out)
+	return nil
+}
+
+// sparseDirectories returns the sorted parent directories of the given files without duplicates.
+// The root directory is omitted.
+func sparseDirectories(files []domain.Path) []string {
+	dirs := make([]string, 0, len(files))
+	seen := map[string]bool{}
+	for _, file := range files {
+		dir := path.Dir(filepath.ToSlash(file.String()))
+		if dir == "." || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// ensureMergeBase deepens the history of a shallow clone until the given revisions have a common ancestor.
+// After maxDeepenAttempts, the complete history is fetched.
+// Nothing is fetched if the clone isn't shallow or if the common ancestor is already available.
+func (s *RepositoryStore) ensureMergeBase(repository *domain.GitRepository, base, head string) error {
+	count := initialDeepenCount
+	for attempt := 1; ; attempt++ {
+		if shallow, err := isShallow(repository); err != nil || !shallow {
+			return err
+		}
+		if found, err := hasMergeBase(repository, base, head); err != nil || found {
+			return err
+		}
+		deepenArg := fmt.Sprintf("--deepen=%d", count)
+		if attempt > maxDeepenAttempts {
+			deepenArg = "--unshallow"
+		}
+		if err := s.fetch(repository, deepenArg); err != nil {
+			return err
+		}
+		count *= 2
+	}
+}
+
+// ensureParentCommit fetches the parent of HEAD if the clone is shallow and HEAD is the oldest commit in the local history.
+func (s *RepositoryStore) ensureParentCommit(repository *domain.GitRepository) error {
+	if shallow, err := isShallow(repository); err != nil || !shallow {
+		return err
+	}
+	if _, _, err := execGitCommand(repository.RootDir, []string{"rev-parse", "--verify", "--quiet", "HEAD~1"}); err == nil {
+		return nil
+	}
+	return s.fetch(repository, "--deepen=1")
+}
@@ -0,0 +1,317 @@
+package repositorystore
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/ccremer/greposync/infrastructure/logging/loggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryStore_HasCommitsBetween(t *testing.T) {
+	tests := map[string]struct {
+		prepare        func(t *testing.T, repo *domain.GitRepository)
+		expectedResult bool
+	}{
+		"GivenNoCommits_ThenExpectFalse": {
+			prepare:        func(t *testing.T, repo *domain.GitRepository) {},
+			expectedResult: false,
+		},
+		"GivenCommitWithChanges_ThenExpectTrue": {
+			prepare: func(t *testing.T, repo *domain.GitRepository) {
+				commitFile(t, repo, "file.txt", "changed")
+			},
+			expectedResult: true,
+		},
+		"GivenRevertedCommit_ThenExpectFalse": {
+			prepare: func(t *testing.T, repo *domain.GitRepository) {
+				commitFile(t, repo, "file.txt", "changed")
+				commitFile(t, repo, "file.txt", "initial")
+			},
+			expectedResult: false,
+		},
+		"GivenCommit_WhenChangesAppliedInBaseBranch_ThenExpectFalse": {
+			prepare: func(t *testing.T, repo *domain.GitRepository) {
+				commitFile(t, repo, "file.txt", "changed")
+				runGit(t, repo, "checkout", "main")
+				commitFile(t, repo, "file.txt", "changed")
+				commitFile(t, repo, "other.txt", "unrelated")
+				runGit(t, repo, "checkout", "greposync-update")
+			},
+			expectedResult: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := &domain.GitRepository{RootDir: domain.NewFilePath(t.TempDir())}
+			runGit(t, repo, "init")
+			runGit(t, repo, "checkout", "-b", "main")
+			commitFile(t, repo, "file.txt", "initial")
+			runGit(t, repo, "checkout", "-b", "greposync-update")
+			tt.prepare(t, repo)
+
+			s := &RepositoryStore{}
+			result, err := s.HasCommitsBetween(repo, "main", "greposync-update")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func commitFile(t *testing.T, repo *domain.GitRepository, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(repo.RootDir.String(), name), []byte(content), 0644))
+	runGit(t, repo, "add", name)
+	runGit(t, repo, "commit", "-m", "update "+name)
+}
+
+func runGit(t *testing.T, repo *domain.GitRepository, args ...string) {
+	args = append([]string{"-c", "user.name=greposync", "-c", "user.email=greposync@example.com", "-c", "commit.gpgSign=false"}, args...)
+	_, stderr, err := execGitCommand(repo.RootDir, args)
+	require.NoError(t, err, stderr)
+}
+
+func TestRepositoryStore_Clone(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions        domain.CloneOptions
+		expectUnmanagedFile bool
+	}{
+		"GivenFullClone_ThenExpectAllFiles": {
+			givenOptions:        domain.CloneOptions{},
+			expectUnmanagedFile: true,
+		},
+		"GivenShallowClone_ThenExpectAllFiles": {
+			givenOptions:        domain.CloneOptions{Depth: 1},
+			expectUnmanagedFile: true,
+		},
+		"GivenBloblessClone_ThenExpectAllFiles": {
+			givenOptions:        domain.CloneOptions{Filter: domain.CloneFilterBlobless},
+			expectUnmanagedFile: true,
+		},
+		"GivenTreelessShallowClone_ThenExpectAllFiles": {
+			givenOptions:        domain.CloneOptions{Depth: 1, Filter: domain.CloneFilterTreeless},
+			expectUnmanagedFile: true,
+		},
+		"GivenSparseBloblessShallowClone_ThenExpectOnlyManagedFiles": {
+			givenOptions:        domain.CloneOptions{Depth: 1, Filter: domain.CloneFilterBlobless, Sparse: true},
+			expectUnmanagedFile: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			origin := newDivergedOrigin(t, dir)
+			repo := &domain.GitRepository{
+				RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+				URL:          newFileURL(origin),
+				CommitBranch: "greposync-update",
+			}
+			s := NewRepositoryStore(NewRepositoryStoreInstrumentation(loggingtest.NewDiscardLoggerFactory()), nil)
+
+			require.NoError(t, s.Clone(repo, tt.givenOptions))
+			assert.Equal(t, "main", repo.DefaultBranch)
+			for _, cfg := range [][]string{{"user.name", "greposync"}, {"user.email", "greposync@example.com"}, {"pull.rebase", "false"}} {
+				runGit(t, repo, "config", cfg[0], cfg[1])
+			}
+			diff, err := s.Diff(repo, domain.DiffOptions{})
+			require.NoError(t, err)
+			assert.Contains(t, diff, "+main 3\n", "diff of HEAD")
+
+			require.NoError(t, s.Fetch(repo))
+			require.NoError(t, s.Reset(repo))
+			require.NoError(t, s.Checkout(repo))
+			require.NoError(t, s.Pull(repo))
+			if tt.givenOptions.Sparse {
+				require.NoError(t, s.SparseCheckout(repo, []domain.Path{"managed/file.txt"}))
+			}
+			assert.FileExists(t, filepath.Join(repo.RootDir.String(), "README.md"))
+			assert.FileExists(t, filepath.Join(repo.RootDir.String(), "managed", "file.txt"))
+			_, err = os.Stat(filepath.Join(repo.RootDir.String(), "unmanaged", "file.txt"))
+			assert.Equal(t, tt.expectUnmanagedFile, err == nil, "unmanaged file checked out")
+
+			hasCommits, err := s.HasCommitsBetween(repo, "main", repo.CommitBranch)
+			require.NoError(t, err)
+			assert.True(t, hasCommits)
+			changes, err := s.DiffStat(repo, "main", repo.CommitBranch)
+			require.NoError(t, err)
+			assert.Equal(t, []domain.FileChange{
+				{Path: "managed/file.txt", Type: domain.FileModified, Additions: 1, Deletions: 1},
+			}, changes)
+		})
+	}
+}
+
+func TestRepositoryStore_Push(t *testing.T) {
+	tests := map[string]struct {
+		givenFork        bool
+		expectedInOrigin bool
+		expectedInFork   bool
+	}{
+		"GivenNoFork_ThenExpectBranchInOrigin": {
+			givenFork:        false,
+			expectedInOrigin: true,
+		},
+		"GivenFork_ThenExpectBranchInFork": {
+			givenFork:      true,
+			expectedInFork: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			origin := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "origin"))}
+			fork := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "fork"))}
+			for _, remote := range []*domain.GitRepository{origin, fork} {
+				require.NoError(t, os.Mkdir(remote.RootDir.String(), 0755))
+				runGit(t, remote, "init", "--bare")
+			}
+			repo := &domain.GitRepository{
+				RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+				URL:          newFileURL(origin),
+				CommitBranch: "greposync-update",
+			}
+			require.NoError(t, os.Mkdir(repo.RootDir.String(), 0755))
+			runGit(t, repo, "init")
+			runGit(t, repo, "remote", "add", "origin", repo.URL.String())
+			runGit(t, repo, "checkout", "-b", repo.CommitBranch)
+			commitFile(t, repo, "file.txt", "changed")
+			if tt.givenFork {
+				repo.ForkURL = newFileURL(fork)
+			}
+
+			s := NewRepositoryStore(NewRepositoryStoreInstrumentation(loggingtest.NewDiscardLoggerFactory()), nil)
+			require.NoError(t, s.Push(repo, domain.PushOptions{}))
+			assert.Equal(t, tt.expectedInOrigin, hasBranch(t, origin, repo.CommitBranch), "branch in origin")
+			assert.Equal(t, tt.expectedInFork, hasBranch(t, fork, repo.CommitBranch), "branch in fork")
+		})
+	}
+}
+
+func newFileURL(repo *domain.GitRepository) *domain.GitURL {
+	return domain.FromURL(&url.URL{Scheme: "file", Path: repo.RootDir.String()})
+}
+
+func hasBranch(t *testing.T, repo *domain.GitRepository, branch string) bool {
+	exists, err := hasLocalBranch(repo, branch)
+	require.NoError(t, err)
+	return exists
+}
+
+// newDivergedOrigin creates a bare repository whose `greposync-update` branch has diverged from the `main` branch after the first commit.
+func newDivergedOrigin(t *testing.T, dir string) *domain.GitRepository {
+	origin := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "origin"))}
+	seed := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "seed"))}
+	for _, r := range []*domain.GitRepository{origin, seed} {
+		require.NoError(t, os.Mkdir(r.RootDir.String(), 0755))
+	}
+	runGit(t, origin, "init", "--bare", "--initial-branch", "main")
+	runGit(t, origin, "config", "uploadpack.allowFilter", "true")
+	runGit(t, seed, "init", "--initial-branch", "main")
+	for _, name := range []string{"managed", "unmanaged"} {
+		require.NoError(t, os.Mkdir(filepath.Join(seed.RootDir.String(), name), 0755))
+		commitFile(t, seed, name+"/file.txt", "initial\n")
+	}
+	commitFile(t, seed, "README.md", "initial\n")
+	runGit(t, seed, "checkout", "-b", "greposync-update")
+	commitFile(t, seed, "managed/file.txt", "changed\n")
+	runGit(t, seed, "checkout", "main")
+	for _, content := range []string{"main 1\n", "main 2\n", "main 3\n"} {
+		commitFile(t, seed, "unmanaged/file.txt", content)
+	}
+	runGit(t, seed, "push", origin.RootDir.String(), "main", "greposync-update")
+	return origin
+}
+
+func TestRepositoryStore_Checkout(t *testing.T) {
+	s := NewRepositoryStore(NewRepositoryStoreInstrumentation(loggingtest.NewDiscardLoggerFactory()), nil)
+	testCheckoutWithTargetBranch(t, s)
+}
+
+// testCheckoutWithTargetBranch verifies that the commit branch is based on the target branch when checking out and amending.
+func testCheckoutWithTargetBranch(t *testing.T, s domain.GitRepositoryStore) {
+	tests := map[string]struct {
+		givenTargetBranch       string
+		givenRemoteCommitBranch bool
+		givenLocalCommitBranch  bool
+		expectedHead            string
+	}{
+		"GivenNoTargetBranch_ThenExpectBranchFromDefaultBranch": {
+			expectedHead: "origin/main",
+		},
+		"GivenTargetBranch_ThenExpectBranchFromTargetBranch": {
+			givenTargetBranch: "develop",
+			expectedHead:      "origin/develop",
+		},
+		"GivenTargetBranch_WhenRemoteCommitBranch_ThenExpectRemoteCommitBranch": {
+			givenTargetBranch:       "develop",
+			givenRemoteCommitBranch: true,
+			expectedHead:            "origin/greposync-update",
+		},
+		"GivenTargetBranch_WhenLocalBranchFromDefaultBranch_ThenExpectBranchReset": {
+			givenTargetBranch:      "develop",
+			givenLocalCommitBranch: true,
+			expectedHead:           "origin/develop",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			origin := newOriginWithTargetBranch(t, dir, tt.givenRemoteCommitBranch)
+			repo := &domain.GitRepository{
+				RootDir:      domain.NewFilePath(filepath.Join(dir, "repo")),
+				URL:          newFileURL(origin),
+				CommitBranch: "greposync-update",
+				TargetBranch: tt.givenTargetBranch,
+			}
+			require.NoError(t, s.Clone(repo, domain.CloneOptions{}))
+			runGit(t, repo, "config", "commit.gpgSign", "false")
+			if tt.givenLocalCommitBranch {
+				runGit(t, repo, "branch", repo.CommitBranch)
+			}
+
+			require.NoError(t, s.Fetch(repo))
+			require.NoError(t, s.Reset(repo))
+			require.NoError(t, s.Checkout(repo))
+			require.NoError(t, s.Pull(repo))
+			assert.Equal(t, revParse(t, repo, tt.expectedHead), revParse(t, repo, "HEAD"), "HEAD after checkout")
+
+			writeFile(t, repo, "file.txt", "updated\n")
+			require.NoError(t, s.Add(repo))
+			identity := domain.Identity{Name: "greposync", Email: "greposync@example.com"}
+			require.NoError(t, s.Commit(repo, domain.CommitOptions{Message: "update", Amend: true, Author: identity, Committer: identity}))
+			assert.Equal(t, revParse(t, repo, "origin/"+repo.GetTargetBranch()), revParse(t, repo, "HEAD^"), "parent of the amended commit")
+		})
+	}
+}
+
+func revParse(t *testing.T, repo *domain.GitRepository, revision string) string {
+	out, stderr, err := execGitCommand(repo.RootDir, []string{"rev-parse", revision})
+	require.NoError(t, err, stderr)
+	return out
+}
+
+// newOriginWithTargetBranch creates a bare repository with a `develop` branch that is ahead of the `main` branch.
+// If commitBranch is true, the `greposync-update` branch is branched off `develop`.
+func newOriginWithTargetBranch(t *testing.T, dir string, commitBranch bool) *domain.GitRepository {
+	origin := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "origin"))}
+	seed := &domain.GitRepository{RootDir: domain.NewFilePath(filepath.Join(dir, "seed"))}
+	for _, r := range []*domain.GitRepository{origin, seed} {
+		require.NoError(t, os.Mkdir(r.RootDir.String(), 0755))
+	}
+	runGit(t, origin, "init", "--bare", "--initial-branch", "main")
+	runGit(t, seed, "init", "--initial-branch", "main")
+	commitFile(t, seed, "file.txt", "initial\n")
+	runGit(t, seed, "checkout", "-b", "develop")
+	commitFile(t, seed, "develop.txt", "develop\n")
+	branches := []string{"main", "develop"}
+	if commitBranch {
+		runGit(t, seed, "checkout", "-b", "greposync-update")
+		commitFile(t, seed, "file.txt", "changed\n")
+		branches = append(branches, "greposync-update")
+	}
+	runGit(t, seed, append([]string{"push", origin.RootDir.String()}, branches...)...)
+	return origin
+}
@@ -28,6 +28,7 @@ type ColoredConsole struct {
 	// Quiet will redirect all console lines to an internal buffer if true.
 	Quiet       bool
 	commandName string
+	out         io.Writer
 }
 
 func NewColoredConsole() *ColoredConsole {
@@ -36,6 +37,7 @@ func NewColoredConsole() *ColoredConsole {
 		buffers:          map[string]*bytes.Buffer{},
 		isInteractive:    isatty.IsTerminal(os.Stdout.Fd()),
 		commandName:      "Update",
+		out:              os.Stdout,
 	}
 }
 
@@ -79,7 +81,7 @@ func (c *ColoredConsole) getOrCreateBuffer(scope string) *bytes.Buffer {
 	return buf
 }
 
-// Flush dumps the logging buffers to stdout.
+// Flush dumps the logging buffers to the output.
 // This is a noop if the buffers is empty.
 func (c *ColoredConsole) Flush(scope, header string) {
 	c.m.Lock()
@@ -87,7 +89,7 @@ func (c *ColoredConsole) Flush(scope, header string) {
 	buf, exists := c.buffers[scope]
 	if exists {
 		pterm.DefaultHeader.WithBackgroundStyle(pterm.NewStyle(pterm.BgCyan)).Println(header)
-		_, _ = buf.WriteTo(os.Stdout)
+		_, _ = buf.WriteTo(c.out)
 	}
 }
 
@@ -100,3 +102,19 @@ func (c *ColoredConsole) SetTitle(title string) {
 func (c *ColoredConsole) SetCommandName(name string) {
 	c.commandName = name
 }
+
+// SetOutput redirects all console output, including log messages, to the given writer.
+// By default, the output is os.Stdout.
+func (c *ColoredConsole) SetOutput(w io.Writer) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.out = w
+	pterm.SetDefaultOutput(w)
+}
+
+// Output returns the writer where the console output is written to.
+func (c *ColoredConsole) Output() io.Writer {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.out
+}
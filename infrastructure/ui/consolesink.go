@@ -2,7 +2,6 @@ package ui
 
 import (
 	"bytes"
-	"os"
 
 	"github.com/ccremer/plogr"
 	"github.com/go-logr/logr"
@@ -43,26 +42,26 @@ func (t *ConsoleSink) Enabled(level int) bool {
 }
 
 // Info implements logr.LogSink.
-// If the name is empty or if Quiet is false, the message is always printed to os.Stdout.
+// If the name is empty or if Quiet is false, the message is always printed to the console output.
 // If the name is non-empty, the message will be buffered internally to be printed at once later.
 func (t *ConsoleSink) Info(level int, msg string, keysAndValues ...interface{}) {
 	buf := &bytes.Buffer{}
 	t.ptermSink.WithOutput(buf).Info(level, msg, keysAndValues...)
 	if t.ptermSink.Name() == "" || !t.console.Quiet {
-		_, _ = buf.WriteTo(os.Stdout)
+		_, _ = buf.WriteTo(t.console.Output())
 		return
 	}
 	t.console.AddToBuffer(t.ptermSink.Name(), buf)
 }
 
 // Error implements logr.LogSink.
-// If the name is empty or if Quiet is false, the message is always printed to os.Stdout.
+// If the name is empty or if Quiet is false, the message is always printed to the console output.
 // If the name is non-empty, the message will be buffered internally to be printed at once later.
 func (t *ConsoleSink) Error(err error, msg string, keysAndValues ...interface{}) {
 	buf := &bytes.Buffer{}
 	t.ptermSink.WithOutput(buf).Error(err, msg, keysAndValues...)
 	if t.ptermSink.Name() == "" || !t.console.Quiet {
-		_, _ = buf.WriteTo(os.Stdout)
+		_, _ = buf.WriteTo(t.console.Output())
 		return
 	}
 	t.console.AddToBuffer(t.ptermSink.Name(), buf)
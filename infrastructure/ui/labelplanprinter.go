@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/pterm/pterm"
+)
+
+// LabelPlan contains the planned label changes of a single repository.
+type LabelPlan struct {
+	// Repository is the repository in which the labels are changed.
+	Repository *domain.GitRepository
+	// Changes are the planned changes.
+	Changes []domain.LabelChange
+}
+
+// LabelPlanPrinter prints planned label changes in a human- or machine-readable format.
+type LabelPlanPrinter struct {
+	out io.Writer
+}
+
+type jsonLabelPlan struct {
+	Repository string            `json:"repository"`
+	Changes    []jsonLabelChange `json:"changes"`
+}
+
+type jsonLabelChange struct {
+	Action string                     `json:"action"`
+	Label  string                     `json:"label"`
+	Fields map[string]jsonFieldChange `json:"fields,omitempty"`
+}
+
+type jsonFieldChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// NewLabelPlanPrinter returns a new instance that prints to os.Stdout.
+func NewLabelPlanPrinter() *LabelPlanPrinter {
+	return &LabelPlanPrinter{
+		out: os.Stdout,
+	}
+}
+
+// PrintTable prints a table of changes for each repository.
+func (p *LabelPlanPrinter) PrintTable(plans []LabelPlan) error {
+	for _, plan := range plans {
+		_, _ = fmt.Fprint(p.out, pterm.DefaultSection.Sprint(plan.Repository.URL.GetFullName()))
+		if len(plan.Changes) == 0 {
+			_, _ = fmt.Fprintln(p.out, "No changes")
+			continue
+		}
+		data := pterm.TableData{{"Action", "Label", "Changes"}}
+		for _, change := range plan.Changes {
+			data = append(data, []string{string(change.Type), change.Label.Name, formatFieldChanges(change.FieldChanges())})
+		}
+		table, err := pterm.DefaultTable.WithHasHeader().WithData(data).Srender()
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(p.out, table)
+	}
+	return nil
+}
+
+// PrintJSON prints the changes of all repositories as a JSON array.
+func (p *LabelPlanPrinter) PrintJSON(plans []LabelPlan) error {
+	converted := make([]jsonLabelPlan, len(plans))
+	for i, plan := range plans {
+		changes := make([]jsonLabelChange, len(plan.Changes))
+		for j, change := range plan.Changes {
+			changes[j] = jsonLabelChange{
+				Action: string(change.Type),
+				Label:  change.Label.Name,
+			}
+			for _, field := range change.FieldChanges() {
+				if changes[j].Fields == nil {
+					changes[j].Fields = map[string]jsonFieldChange{}
+				}
+				changes[j].Fields[field.Field] = jsonFieldChange{Old: field.Old, New: field.New}
+			}
+		}
+		converted[i] = jsonLabelPlan{
+			Repository: plan.Repository.URL.GetFullName(),
+			Changes:    changes,
+		}
+	}
+	enc := json.NewEncoder(p.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(converted)
+}
+
+func formatFieldChanges(changes []domain.LabelFieldChange) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = fmt.Sprintf("%s: %q → %q", change.Field, change.Old, change.New)
+	}
+	return strings.Join(lines, ", ")
+}
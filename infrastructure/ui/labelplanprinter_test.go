@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelPlanPrinter_PrintJSON(t *testing.T) {
+	u, err := url.Parse("https://github.com/ccremer/greposync")
+	require.NoError(t, err)
+	repo := &domain.GitRepository{URL: domain.FromURL(u)}
+
+	tests := map[string]struct {
+		givenPlans  []LabelPlan
+		expectedOut string
+	}{
+		"GivenNoPlans_ThenExpectEmptyArray": {
+			givenPlans:  []LabelPlan{},
+			expectedOut: "[]\n",
+		},
+		"GivenRename_ThenExpectFieldChanges": {
+			givenPlans: []LabelPlan{{
+				Repository: repo,
+				Changes: []domain.LabelChange{
+					{Type: domain.LabelRename, Label: domain.Label{Name: "kind/bug"}, Current: domain.Label{Name: "bug"}},
+					{Type: domain.LabelDelete, Label: domain.Label{Name: "wontfix"}, Current: domain.Label{Name: "wontfix"}},
+				},
+			}},
+			expectedOut: `[
+  {
+    "repository": "github.com/ccremer/greposync",
+    "changes": [
+      {
+        "action": "rename",
+        "label": "kind/bug",
+        "fields": {
+          "name": {
+            "old": "bug",
+            "new": "kind/bug"
+          }
+        }
+      },
+      {
+        "action": "delete",
+        "label": "wontfix"
+      }
+    ]
+  }
+]
+`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			p := &LabelPlanPrinter{out: buf}
+			err := p.PrintJSON(tt.givenPlans)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOut, buf.String())
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package valuestore
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+)
+
+// CommitKey is the special top-level key in the sync config that contains commit settings.
+const CommitKey = ":git"
+
+// FetchCommitSettings implements domain.ValueStore.
+func (s *KoanfStore) FetchCommitSettings(repository *domain.GitRepository) (domain.CommitSettings, error) {
+	s.loadGlobals()
+	repoKoanf, err := s.prepareRepoKoanf(repository)
+	if err != nil {
+		return domain.CommitSettings{}, err
+	}
+	return s.loadCommitSettings(repoKoanf)
+}
+
+func (s *KoanfStore) loadCommitSettings(repoConfig *koanf.Koanf) (domain.CommitSettings, error) {
+	settings := domain.CommitSettings{}
+	raw, isMap := repoConfig.Get(CommitKey).(map[string]interface{})
+	if !isMap {
+		return settings, nil
+	}
+	var err error
+	if settings.Message, err = toString(raw, CommitKey, "commitMessage"); err != nil {
+		return settings, err
+	}
+	if settings.Trailers, err = toStringSlice(raw, CommitKey, "trailers"); err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
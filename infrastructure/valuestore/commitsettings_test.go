@@ -0,0 +1,57 @@
+package valuestore
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKoanfStore_FetchCommitSettings(t *testing.T) {
+	message := "chore(ci): sync workflows for {{ .Metadata.Repository.Name }}"
+	tests := map[string]struct {
+		givenSyncFile    string
+		givenGlobals     config
+		expectedSettings domain.CommitSettings
+	}{
+		"GivenNoCommitKey_ThenExpectNilSettings": {
+			givenSyncFile:    "sync.yml",
+			expectedSettings: domain.CommitSettings{},
+		},
+		"GivenCommitKey_ThenExpectSettings": {
+			givenSyncFile: "commit.yml",
+			expectedSettings: domain.CommitSettings{
+				Message:  &message,
+				Trailers: []string{"Refs: TICKET-123"},
+			},
+		},
+		"GivenGlobalCommitKey_ThenExpectRepositorySettingsToTakePrecedence": {
+			givenSyncFile: "commit.yml",
+			givenGlobals: config{
+				CommitKey: map[string]interface{}{"commitMessage": "global message", "trailers": []interface{}{"Refs: GLOBAL-1"}},
+			},
+			expectedSettings: domain.CommitSettings{
+				Message:  &message,
+				Trailers: []string{"Refs: TICKET-123"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewKoanfStore(nil)
+			s.syncConfigFileName = tt.givenSyncFile
+			s.globalKoanf = koanf.New("")
+			require.NoError(t, s.globalKoanf.Load(confmap.Provider(tt.givenGlobals, ""), nil))
+			u, err := url.Parse("https://github.com/ccremer/greposync")
+			require.NoError(t, err)
+			repo := &domain.GitRepository{URL: domain.FromURL(u), RootDir: domain.NewFilePath("testdata")}
+			result, err := s.FetchCommitSettings(repo)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSettings, result)
+		})
+	}
+}
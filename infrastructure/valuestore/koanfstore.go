@@ -72,6 +72,9 @@ func (s *KoanfStore) FetchFilesToDelete(repository *domain.GitRepository, templa
 }
 
 func (s *KoanfStore) prepareRepoKoanf(repository *domain.GitRepository) (*koanf.Koanf, error) {
+	// Repositories may be processed in parallel.
+	s.m.Lock()
+	defer s.m.Unlock()
 	if repoKoanf, exists := s.cache[repository.URL]; exists {
 		return repoKoanf, nil
 	}
@@ -0,0 +1,98 @@
+package valuestore
+
+import (
+	"fmt"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+)
+
+// LabelsKey is the special top-level key in the sync config that contains repository label settings.
+const LabelsKey = ":labels"
+
+// FetchRepositoryLabels returns the given labels merged with the repository-specific label settings.
+// The settings are merged by their key in cfg.RepositoryLabelMap, properties that aren't configured for the repository are inherited from the given labels.
+// The given labels are not modified.
+func (s *KoanfStore) FetchRepositoryLabels(repository *domain.GitRepository, labels cfg.RepositoryLabelMap) (cfg.RepositoryLabelMap, error) {
+	s.loadGlobals()
+	repoKoanf, err := s.prepareRepoKoanf(repository)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadRepositoryLabels(repoKoanf, labels)
+}
+
+func (s *KoanfStore) loadRepositoryLabels(repoConfig *koanf.Koanf, labels cfg.RepositoryLabelMap) (cfg.RepositoryLabelMap, error) {
+	merged := make(cfg.RepositoryLabelMap, len(labels))
+	for key, label := range labels {
+		merged[key] = label
+	}
+	raw, isMap := repoConfig.Get(LabelsKey).(map[string]interface{})
+	if !isMap {
+		return merged, nil
+	}
+	for key, value := range raw {
+		override, isMap := value.(map[string]interface{})
+		if !isMap {
+			return merged, fmt.Errorf("%w: %s.%s is not a map", domain.ErrInvalidArgument, LabelsKey, key)
+		}
+		label, err := mergeRepositoryLabel(merged[key], override, LabelsKey+"."+key)
+		if err != nil {
+			return merged, err
+		}
+		if label.Name == "" {
+			// Labels that aren't in repositoryLabels need a name.
+			return merged, fmt.Errorf("%w: %s.%s has no name", domain.ErrInvalidArgument, LabelsKey, key)
+		}
+		merged[key] = label
+	}
+	return merged, nil
+}
+
+// mergeRepositoryLabel returns a copy of label with the properties that are defined in raw.
+func mergeRepositoryLabel(label cfg.RepositoryLabel, raw map[string]interface{}, parent string) (cfg.RepositoryLabel, error) {
+	var err error
+	if label.Name, err = toStringOrDefault(raw, parent, "name", label.Name); err != nil {
+		return label, err
+	}
+	if label.Description, err = toStringOrDefault(raw, parent, "description", label.Description); err != nil {
+		return label, err
+	}
+	if label.Color, err = toStringOrDefault(raw, parent, "color", label.Color); err != nil {
+		return label, err
+	}
+	deletion, err := toBool(raw, parent, "delete")
+	if err != nil {
+		return label, err
+	}
+	if deletion != nil {
+		label.Delete = *deletion
+	}
+	renamedFrom, err := toStringSlice(raw, parent, "renamedFrom")
+	if err != nil {
+		return label, err
+	}
+	if renamedFrom != nil {
+		label.RenamedFrom = renamedFrom
+	}
+	return label, nil
+}
+
+// toStringOrDefault returns the value of the given key as string.
+// Numbers are converted to strings, as e.g. colors without letters are parsed as such.
+// Returns def if the key doesn't exist.
+// The parent key is only used in error messages.
+func toStringOrDefault(raw map[string]interface{}, parent, key, def string) (string, error) {
+	value, exists := raw[key]
+	if !exists || value == nil {
+		return def, nil
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int, int64, uint64, float64:
+		return fmt.Sprintf("%v", v), nil
+	}
+	return def, fmt.Errorf("%w: %s.%s is not a string", domain.ErrInvalidArgument, parent, key)
+}
@@ -0,0 +1,99 @@
+package valuestore
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/cfg"
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKoanfStore_FetchRepositoryLabels(t *testing.T) {
+	globalLabels := cfg.RepositoryLabelMap{
+		"greposync": {Name: "greposync", Description: "updates from template repository", Color: "ededed"},
+		"bug":       {Name: "bug", Color: "d73a4a"},
+	}
+	tests := map[string]struct {
+		givenSyncFile  string
+		expectedLabels cfg.RepositoryLabelMap
+		expectedError  string
+	}{
+		"GivenNoLabelsKey_ThenExpectGlobalLabels": {
+			givenSyncFile:  "sync.yml",
+			expectedLabels: globalLabels,
+		},
+		"GivenLabelsKey_ThenExpectMergedLabels": {
+			givenSyncFile: "labels.yml",
+			expectedLabels: cfg.RepositoryLabelMap{
+				"greposync": {Name: "greposync", Description: "updates from template repository", Color: "ff0000"},
+				"bug":       {Name: "bug", Color: "d73a4a", Delete: true},
+				"helm":      {Name: "area/helm", Description: "Helm chart", Color: "0e8a16"},
+			},
+		},
+		"GivenNewLabelWithoutName_ThenExpectError": {
+			givenSyncFile: "labels-without-name.yml",
+			expectedError: "invalid argument: :labels.helm has no name",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewKoanfStore(nil)
+			s.syncConfigFileName = tt.givenSyncFile
+			s.globalKoanf = koanf.New("")
+			u, err := url.Parse("https://github.com/ccremer/greposync")
+			require.NoError(t, err)
+			repo := &domain.GitRepository{URL: domain.FromURL(u), RootDir: domain.NewFilePath("testdata")}
+			result, err := s.FetchRepositoryLabels(repo, globalLabels)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLabels, result)
+			assert.Equal(t, "ededed", globalLabels["greposync"].Color, "global labels should not be modified")
+		})
+	}
+}
+
+func TestMergeRepositoryLabel(t *testing.T) {
+	tests := map[string]struct {
+		givenLabel    cfg.RepositoryLabel
+		givenRaw      map[string]interface{}
+		expectedLabel cfg.RepositoryLabel
+		expectedError string
+	}{
+		"GivenEmptyOverride_ThenExpectSameLabel": {
+			givenLabel:    cfg.RepositoryLabel{Name: "bug", Color: "d73a4a"},
+			givenRaw:      map[string]interface{}{},
+			expectedLabel: cfg.RepositoryLabel{Name: "bug", Color: "d73a4a"},
+		},
+		"GivenNumericColor_ThenExpectString": {
+			givenLabel:    cfg.RepositoryLabel{Name: "bug"},
+			givenRaw:      map[string]interface{}{"color": 123456},
+			expectedLabel: cfg.RepositoryLabel{Name: "bug", Color: "123456"},
+		},
+		"GivenRenamedFrom_ThenExpectSlice": {
+			givenLabel:    cfg.RepositoryLabel{Name: "kind/bug"},
+			givenRaw:      map[string]interface{}{"renamedFrom": "bug"},
+			expectedLabel: cfg.RepositoryLabel{Name: "kind/bug", RenamedFrom: []string{"bug"}},
+		},
+		"GivenInvalidName_ThenExpectError": {
+			givenRaw:      map[string]interface{}{"name": []interface{}{}},
+			expectedError: "invalid argument: :labels.key.name is not a string",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := mergeRepositoryLabel(tt.givenLabel, tt.givenRaw, LabelsKey+".key")
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLabel, result)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package valuestore
+
+import (
+	"fmt"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+)
+
+// PullRequestKey is the special top-level key in the sync config that contains pull request settings.
+const PullRequestKey = ":pr"
+
+// FetchPullRequestSettings implements domain.ValueStore.
+func (s *KoanfStore) FetchPullRequestSettings(repository *domain.GitRepository) (domain.PullRequestSettings, error) {
+	s.loadGlobals()
+	repoKoanf, err := s.prepareRepoKoanf(repository)
+	if err != nil {
+		return domain.PullRequestSettings{}, err
+	}
+	return s.loadPullRequestSettings(repoKoanf)
+}
+
+func (s *KoanfStore) loadPullRequestSettings(repoConfig *koanf.Koanf) (domain.PullRequestSettings, error) {
+	settings := domain.PullRequestSettings{}
+	raw, isMap := repoConfig.Get(PullRequestKey).(map[string]interface{})
+	if !isMap {
+		return settings, nil
+	}
+	var err error
+	if settings.Subject, err = toString(raw, PullRequestKey, "subject"); err != nil {
+		return settings, err
+	}
+	if settings.BodyTemplate, err = toString(raw, PullRequestKey, "body"); err != nil {
+		return settings, err
+	}
+	if settings.Labels, err = toStringSlice(raw, PullRequestKey, "labels"); err != nil {
+		return settings, err
+	}
+	if settings.TargetBranch, err = toStringOrDefault(raw, PullRequestKey, "targetBranch", ""); err != nil {
+		return settings, err
+	}
+	if settings.Reviewers, err = toStringSlice(raw, PullRequestKey, "reviewers"); err != nil {
+		return settings, err
+	}
+	if settings.TeamReviewers, err = toStringSlice(raw, PullRequestKey, "teamReviewers"); err != nil {
+		return settings, err
+	}
+	if settings.Assignees, err = toStringSlice(raw, PullRequestKey, "assignees"); err != nil {
+		return settings, err
+	}
+	if settings.Draft, err = toBool(raw, PullRequestKey, "draft"); err != nil {
+		return settings, err
+	}
+	if settings.AutoMerge, err = toBool(raw, PullRequestKey, "autoMerge"); err != nil {
+		return settings, err
+	}
+	if method, exists := raw["mergeMethod"]; exists && method != nil {
+		str, isString := method.(string)
+		if !isString {
+			return settings, fmt.Errorf("%w: %s.mergeMethod is not a string", domain.ErrInvalidArgument, PullRequestKey)
+		}
+		settings.MergeMethod = domain.MergeMethod(str)
+		if err := settings.MergeMethod.Validate(); err != nil {
+			return settings, err
+		}
+	}
+	return settings, nil
+}
+
+// toStringSlice returns the value of the given key as string slice.
+// A single string is converted to a slice with one element.
+// Returns nil if the key doesn't exist.
+// The parent key is only used in error messages.
+func toStringSlice(raw map[string]interface{}, parent, key string) ([]string, error) {
+	value, exists := raw[key]
+	if !exists || value == nil {
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		arr := make([]string, len(v))
+		for i, elem := range v {
+			str, isString := elem.(string)
+			if !isString {
+				return nil, fmt.Errorf("%w: %s.%s[%d] is not a string", domain.ErrInvalidArgument, parent, key, i)
+			}
+			arr[i] = str
+		}
+		return arr, nil
+	}
+	return nil, fmt.Errorf("%w: %s.%s is not a list of strings", domain.ErrInvalidArgument, parent, key)
+}
+
+// toBool returns the value of the given key as bool pointer.
+// Returns nil if the key doesn't exist.
+// The parent key is only used in error messages.
+func toBool(raw map[string]interface{}, parent, key string) (*bool, error) {
+	value, exists := raw[key]
+	if !exists || value == nil {
+		return nil, nil
+	}
+	b, isBool := value.(bool)
+	if !isBool {
+		return nil, fmt.Errorf("%w: %s.%s is not a boolean", domain.ErrInvalidArgument, parent, key)
+	}
+	return &b, nil
+}
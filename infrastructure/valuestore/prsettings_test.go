@@ -0,0 +1,106 @@
+package valuestore
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKoanfStore_FetchPullRequestSettings(t *testing.T) {
+	draft, subject, body := true, "chore: Update from template", "global body"
+	tests := map[string]struct {
+		givenSyncFile    string
+		givenGlobals     config
+		expectedSettings domain.PullRequestSettings
+	}{
+		"GivenNoPullRequestKey_ThenExpectNilSettings": {
+			givenSyncFile:    "sync.yml",
+			expectedSettings: domain.PullRequestSettings{},
+		},
+		"GivenPullRequestKey_ThenExpectSettings": {
+			givenSyncFile: "pr.yml",
+			expectedSettings: domain.PullRequestSettings{
+				Subject:       &subject,
+				Labels:        []string{"dependencies"},
+				TargetBranch:  "develop",
+				Reviewers:     []string{"@ccremer", "@org/maintainers"},
+				TeamReviewers: []string{"reviewers"},
+				Assignees:     []string{},
+				Draft:         &draft,
+				MergeMethod:   domain.MergeMethodSquash,
+			},
+		},
+		"GivenGlobalPullRequestKey_ThenExpectRepositorySettingsToTakePrecedence": {
+			givenSyncFile: "pr.yml",
+			givenGlobals: config{
+				PullRequestKey: map[string]interface{}{"subject": "global subject", "body": body, "labels": []interface{}{"global"}},
+			},
+			expectedSettings: domain.PullRequestSettings{
+				Subject:       &subject,
+				BodyTemplate:  &body,
+				Labels:        []string{"dependencies"},
+				TargetBranch:  "develop",
+				Reviewers:     []string{"@ccremer", "@org/maintainers"},
+				TeamReviewers: []string{"reviewers"},
+				Assignees:     []string{},
+				Draft:         &draft,
+				MergeMethod:   domain.MergeMethodSquash,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewKoanfStore(nil)
+			s.syncConfigFileName = tt.givenSyncFile
+			s.globalKoanf = koanf.New("")
+			require.NoError(t, s.globalKoanf.Load(confmap.Provider(tt.givenGlobals, ""), nil))
+			u, err := url.Parse("https://github.com/ccremer/greposync")
+			require.NoError(t, err)
+			repo := &domain.GitRepository{URL: domain.FromURL(u), RootDir: domain.NewFilePath("testdata")}
+			result, err := s.FetchPullRequestSettings(repo)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSettings, result)
+		})
+	}
+}
+
+func TestToStringSlice(t *testing.T) {
+	tests := map[string]struct {
+		givenValue     interface{}
+		expectedResult []string
+		expectedError  string
+	}{
+		"GivenString_ThenExpectSliceWithOneElement": {
+			givenValue:     "value",
+			expectedResult: []string{"value"},
+		},
+		"GivenList_ThenExpectSlice": {
+			givenValue:     []interface{}{"a", "b"},
+			expectedResult: []string{"a", "b"},
+		},
+		"GivenListWithNumber_ThenExpectError": {
+			givenValue:    []interface{}{"a", 1},
+			expectedError: "invalid argument: :pr.key[1] is not a string",
+		},
+		"GivenMap_ThenExpectError": {
+			givenValue:    map[string]interface{}{},
+			expectedError: "invalid argument: :pr.key is not a list of strings",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := toStringSlice(map[string]interface{}{"key": tt.givenValue}, PullRequestKey, "key")
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
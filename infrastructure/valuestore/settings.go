@@ -0,0 +1,70 @@
+package valuestore
+
+import (
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+)
+
+// SettingsKey is the special top-level key in the sync config that contains repository settings.
+const SettingsKey = ":settings"
+
+// FetchRepositorySettings implements domain.ValueStore.
+func (s *KoanfStore) FetchRepositorySettings(repository *domain.GitRepository) (domain.RepositorySettings, error) {
+	s.loadGlobals()
+	repoKoanf, err := s.prepareRepoKoanf(repository)
+	if err != nil {
+		return domain.RepositorySettings{}, err
+	}
+	return s.loadRepositorySettings(repoKoanf)
+}
+
+func (s *KoanfStore) loadRepositorySettings(repoConfig *koanf.Koanf) (domain.RepositorySettings, error) {
+	settings := domain.RepositorySettings{}
+	raw, isMap := repoConfig.Get(SettingsKey).(map[string]interface{})
+	if !isMap {
+		return settings, nil
+	}
+	var err error
+	if settings.Description, err = toString(raw, SettingsKey, "description"); err != nil {
+		return settings, err
+	}
+	if settings.Homepage, err = toString(raw, SettingsKey, "homepage"); err != nil {
+		return settings, err
+	}
+	if settings.Topics, err = toStringSlice(raw, SettingsKey, "topics"); err != nil {
+		return settings, err
+	}
+	if settings.AllowMergeCommit, err = toBool(raw, SettingsKey, "allowMergeCommit"); err != nil {
+		return settings, err
+	}
+	if settings.AllowSquashMerge, err = toBool(raw, SettingsKey, "allowSquashMerge"); err != nil {
+		return settings, err
+	}
+	if settings.AllowRebaseMerge, err = toBool(raw, SettingsKey, "allowRebaseMerge"); err != nil {
+		return settings, err
+	}
+	if settings.DeleteBranchOnMerge, err = toBool(raw, SettingsKey, "deleteBranchOnMerge"); err != nil {
+		return settings, err
+	}
+	if settings.HasWiki, err = toBool(raw, SettingsKey, "hasWiki"); err != nil {
+		return settings, err
+	}
+	if settings.HasProjects, err = toBool(raw, SettingsKey, "hasProjects"); err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
+
+// toString returns the value of the given key as string pointer.
+// Returns nil if the key doesn't exist.
+// The parent key is only used in error messages.
+func toString(raw map[string]interface{}, parent, key string) (*string, error) {
+	if value, exists := raw[key]; !exists || value == nil {
+		return nil, nil
+	}
+	str, err := toStringOrDefault(raw, parent, key, "")
+	if err != nil {
+		return nil, err
+	}
+	return &str, nil
+}
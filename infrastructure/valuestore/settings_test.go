@@ -0,0 +1,48 @@
+package valuestore
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ccremer/greposync/domain"
+	"github.com/knadh/koanf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKoanfStore_FetchRepositorySettings(t *testing.T) {
+	description, homepage := "A tool to keep Git repositories in sync", ""
+	yes, no := true, false
+	tests := map[string]struct {
+		givenSyncFile    string
+		expectedSettings domain.RepositorySettings
+	}{
+		"GivenNoSettingsKey_ThenExpectNilSettings": {
+			givenSyncFile:    "sync.yml",
+			expectedSettings: domain.RepositorySettings{},
+		},
+		"GivenSettingsKey_ThenExpectSettings": {
+			givenSyncFile: "settings.yml",
+			expectedSettings: domain.RepositorySettings{
+				Description:         &description,
+				Homepage:            &homepage,
+				Topics:              []string{"greposync"},
+				DeleteBranchOnMerge: &yes,
+				HasWiki:             &no,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := NewKoanfStore(nil)
+			s.syncConfigFileName = tt.givenSyncFile
+			s.globalKoanf = koanf.New("")
+			u, err := url.Parse("https://github.com/ccremer/greposync")
+			require.NoError(t, err)
+			repo := &domain.GitRepository{URL: domain.FromURL(u), RootDir: domain.NewFilePath("testdata")}
+			result, err := s.FetchRepositorySettings(repo)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSettings, result)
+		})
+	}
+}
@@ -19,8 +19,8 @@ func (s *KoanfStore) loadFilesToDelete(repoConfig *koanf.Koanf, templates []*dom
 		if !pathIsFile(filePath) {
 			continue
 		}
-		if filePath == ":globals" {
-			// can't delete file named ':globals' anyway
+		if strings.HasPrefix(filePath, ":") {
+			// special keys like ':globals' aren't file names
 			continue
 		}
 		del, err := s.loadBooleanFlag(repoConfig, filePath, "delete")
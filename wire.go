@@ -5,15 +5,19 @@ package main
 
 import (
 	"github.com/ccremer/greposync/application"
+	"github.com/ccremer/greposync/application/branchprotection"
 	"github.com/ccremer/greposync/application/initialize"
 	"github.com/ccremer/greposync/application/instrumentation"
 	"github.com/ccremer/greposync/application/labels"
+	"github.com/ccremer/greposync/application/settings"
 	"github.com/ccremer/greposync/application/test"
 	"github.com/ccremer/greposync/application/update"
 	"github.com/ccremer/greposync/cfg"
 	"github.com/ccremer/greposync/domain"
 	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/ccremer/greposync/infrastructure/githosting/gitea"
 	"github.com/ccremer/greposync/infrastructure/githosting/github"
+	"github.com/ccremer/greposync/infrastructure/githosting/gitlab"
 	"github.com/ccremer/greposync/infrastructure/logging"
 	"github.com/ccremer/greposync/infrastructure/repositorystore"
 	"github.com/ccremer/greposync/infrastructure/templateengine"
@@ -51,12 +55,17 @@ func initInjector() *injector {
 		cfg.NewDefaultConfig,
 		labels.NewCommand,
 		labels.NewConfigurator,
+		settings.NewCommand,
+		settings.NewConfigurator,
+		branchprotection.NewCommand,
+		branchprotection.NewConfigurator,
 		update.NewCommand,
 		update.NewConfigurator,
 		initialize.NewCommand,
 		test.NewCommand,
 		test.NewConfigurator,
 		wire.NewSet(ui.NewConsoleDiffPrinter, wire.Bind(new(ui.DiffPrinter), new(*ui.ConsoleDiffPrinter))),
+		ui.NewLabelPlanPrinter,
 
 		// Template Engine
 		wire.NewSet(gotemplate.NewEngine, wire.Bind(new(domain.TemplateEngine), new(*gotemplate.GoTemplateEngine))),
@@ -66,10 +75,15 @@ func initInjector() *injector {
 
 		// Stores
 		wire.NewSet(repositorystore.NewRepositoryStore, wire.Bind(new(domain.GitRepositoryStore), new(*repositorystore.RepositoryStore))),
+		repositorystore.NewGoGitRepositoryStore,
 		repositorystore.NewTestRepositoryStore,
 		wire.NewSet(valuestore.NewKoanfStore, wire.Bind(new(domain.ValueStore), new(*valuestore.KoanfStore))),
 		wire.NewSet(githosting.NewPullRequestStore, wire.Bind(new(domain.PullRequestStore), new(*githosting.PullRequestStore))),
 		wire.NewSet(githosting.NewLabelStore, wire.Bind(new(domain.LabelStore), new(*githosting.LabelStore))),
+		wire.NewSet(githosting.NewSettingsStore, wire.Bind(new(domain.SettingsStore), new(*githosting.SettingsStore))),
+		wire.NewSet(githosting.NewBranchProtectionStore, wire.Bind(new(domain.BranchProtectionStore), new(*githosting.BranchProtectionStore))),
+		wire.NewSet(githosting.NewForkStore, wire.Bind(new(domain.ForkStore), new(*githosting.ForkStore))),
+		wire.NewSet(githosting.NewRepositoryFinder, wire.Bind(new(domain.GitRepositoryFinder), new(*githosting.RepositoryFinder))),
 
 		// Services
 		domain.NewRenderService,
@@ -86,15 +100,21 @@ func initInjector() *injector {
 		wire.NewSet(instrumentation.NewUpdateInstrumentation, wire.Bind(new(instrumentation.BatchInstrumentation), new(*instrumentation.CommonBatchInstrumentation))),
 		repositorystore.NewRepositoryStoreInstrumentation,
 		github.NewGitHubInstrumentation,
+		gitlab.NewGitLabInstrumentation,
+		gitea.NewGiteaInstrumentation,
 
 		// Git providers
 		newGitProviders,
 		github.NewRemote,
+		gitlab.NewRemote,
+		gitea.NewRemote,
 	))
 }
 
-func newGitProviders(ghRemote *github.GhRemote) githosting.ProviderMap {
+func newGitProviders(ghRemote *github.GhRemote, glRemote *gitlab.GlRemote, gtRemote *gitea.GtRemote) githosting.ProviderMap {
 	return githosting.ProviderMap{
 		github.ProviderKey: ghRemote,
+		gitlab.ProviderKey: glRemote,
+		gitea.ProviderKey:  gtRemote,
 	}
 }
@@ -8,15 +8,19 @@ package main
 
 import (
 	"github.com/ccremer/greposync/application"
+	"github.com/ccremer/greposync/application/branchprotection"
 	"github.com/ccremer/greposync/application/initialize"
 	"github.com/ccremer/greposync/application/instrumentation"
 	"github.com/ccremer/greposync/application/labels"
+	"github.com/ccremer/greposync/application/settings"
 	"github.com/ccremer/greposync/application/test"
 	"github.com/ccremer/greposync/application/update"
 	"github.com/ccremer/greposync/cfg"
 	"github.com/ccremer/greposync/domain"
 	"github.com/ccremer/greposync/infrastructure/githosting"
+	"github.com/ccremer/greposync/infrastructure/githosting/gitea"
 	"github.com/ccremer/greposync/infrastructure/githosting/github"
+	"github.com/ccremer/greposync/infrastructure/githosting/gitlab"
 	"github.com/ccremer/greposync/infrastructure/repositorystore"
 	"github.com/ccremer/greposync/infrastructure/templateengine"
 	"github.com/ccremer/greposync/infrastructure/templateengine/gotemplate"
@@ -33,32 +37,46 @@ func initInjector() *injector {
 	consoleSink := ui.NewConsoleSink(coloredConsole)
 	consoleLoggerFactory := ui.NewConsoleLoggerFactory(consoleSink)
 	repositoryStoreInstrumentation := repositorystore.NewRepositoryStoreInstrumentation(consoleLoggerFactory)
-	repositoryStore := repositorystore.NewRepositoryStore(repositoryStoreInstrumentation)
 	gitHubInstrumentation := github.NewGitHubInstrumentation(consoleLoggerFactory)
-	ghRemote := github.NewRemote(gitHubInstrumentation)
-	providerMap := newGitProviders(ghRemote)
+	ghRemote := github.NewRemote(gitHubInstrumentation, configuration)
+	gitLabInstrumentation := gitlab.NewGitLabInstrumentation(consoleLoggerFactory)
+	glRemote := gitlab.NewRemote(gitLabInstrumentation, configuration)
+	giteaInstrumentation := gitea.NewGiteaInstrumentation(consoleLoggerFactory)
+	gtRemote := gitea.NewRemote(giteaInstrumentation, configuration)
+	providerMap := newGitProviders(ghRemote, glRemote, gtRemote)
+	repositoryFinder := githosting.NewRepositoryFinder(providerMap)
+	repositoryStore := repositorystore.NewRepositoryStore(repositoryStoreInstrumentation, repositoryFinder)
 	labelStore := githosting.NewLabelStore(providerMap)
-	appService := labels.NewConfigurator(repositoryStore, labelStore, configuration, consoleLoggerFactory)
+	valueStoreInstrumentation := valuestore.NewValueStoreInstrumentation(consoleLoggerFactory)
+	koanfStore := valuestore.NewKoanfStore(valueStoreInstrumentation)
+	labelPlanPrinter := ui.NewLabelPlanPrinter()
+	appService := labels.NewConfigurator(repositoryStore, labelStore, koanfStore, coloredConsole, labelPlanPrinter, configuration, consoleLoggerFactory)
 	commonBatchInstrumentation := instrumentation.NewUpdateInstrumentation(coloredConsole, consoleLoggerFactory)
 	command := labels.NewCommand(configuration, appService, commonBatchInstrumentation)
+	settingsStore := githosting.NewSettingsStore(providerMap)
+	settingsAppService := settings.NewConfigurator(repositoryStore, settingsStore, koanfStore, configuration, consoleLoggerFactory)
+	settingsCommand := settings.NewCommand(configuration, settingsAppService, commonBatchInstrumentation)
+	branchProtectionStore := githosting.NewBranchProtectionStore(providerMap)
+	branchprotectionAppService := branchprotection.NewConfigurator(repositoryStore, branchProtectionStore, configuration, consoleLoggerFactory)
+	branchprotectionCommand := branchprotection.NewCommand(configuration, branchprotectionAppService, commonBatchInstrumentation)
 	goTemplateEngine := gotemplate.NewEngine()
 	goTemplateStore := gotemplate.NewTemplateStore()
-	valueStoreInstrumentation := valuestore.NewValueStoreInstrumentation(consoleLoggerFactory)
-	koanfStore := valuestore.NewKoanfStore(valueStoreInstrumentation)
 	pullRequestStore := githosting.NewPullRequestStore(providerMap)
+	forkStore := githosting.NewForkStore(providerMap)
 	renderServiceInstrumentation := templateengine.NewRenderServiceInstrumentation(consoleLoggerFactory)
 	renderService := domain.NewRenderService(renderServiceInstrumentation)
 	cleanupServiceInstrumentation := templateengine.NewCleanupServiceInstrumentation(consoleLoggerFactory)
 	cleanupService := domain.NewCleanupService(cleanupServiceInstrumentation)
 	pullRequestService := domain.NewPullRequestService()
 	consoleDiffPrinter := ui.NewConsoleDiffPrinter()
-	updateAppService := update.NewConfigurator(goTemplateEngine, repositoryStore, goTemplateStore, koanfStore, pullRequestStore, renderService, cleanupService, pullRequestService, consoleDiffPrinter, configuration, coloredConsole)
+	goGitRepositoryStore := repositorystore.NewGoGitRepositoryStore(repositoryStore)
+	updateAppService := update.NewConfigurator(goTemplateEngine, repositoryStore, goGitRepositoryStore, goTemplateStore, koanfStore, pullRequestStore, forkStore, renderService, cleanupService, pullRequestService, consoleDiffPrinter, configuration, coloredConsole)
 	updateCommand := update.NewCommand(configuration, updateAppService, consoleLoggerFactory, commonBatchInstrumentation)
 	initializeCommand := initialize.NewCommand(configuration, consoleLoggerFactory)
 	testRepositoryStore := repositorystore.NewTestRepositoryStore(repositoryStoreInstrumentation)
 	testAppService := test.NewConfigurator(goTemplateEngine, testRepositoryStore, goTemplateStore, koanfStore, renderService, cleanupService, consoleDiffPrinter, configuration, coloredConsole)
 	testCommand := test.NewCommand(configuration, testAppService, consoleLoggerFactory, commonBatchInstrumentation)
-	app := application.NewApp(versionInfo, configuration, command, updateCommand, initializeCommand, testCommand, consoleLoggerFactory)
+	app := application.NewApp(versionInfo, configuration, command, settingsCommand, branchprotectionCommand, updateCommand, initializeCommand, testCommand, consoleLoggerFactory)
 	mainInjector := NewInjector(app)
 	return mainInjector
 }
@@ -86,6 +104,6 @@ func (i *injector) RunApp() {
 	i.app.Run()
 }
 
-func newGitProviders(ghRemote *github.GhRemote) githosting.ProviderMap {
-	return githosting.ProviderMap{github.ProviderKey: ghRemote}
+func newGitProviders(ghRemote *github.GhRemote, glRemote *gitlab.GlRemote, gtRemote *gitea.GtRemote) githosting.ProviderMap {
+	return githosting.ProviderMap{github.ProviderKey: ghRemote, gitlab.ProviderKey: glRemote, gitea.ProviderKey: gtRemote}
 }